@@ -0,0 +1,67 @@
+// Command migrate-media moves media files between storage backends and updates their records.
+//
+//	go run ./cmd/migrate-media -from local -to s3 [-delete-source]
+//
+// Sürücüler ana uygulamayla aynı ortam değişkenlerinden (.env) yapılandırılır.
+package main
+
+import (
+	"admin-panel/configs"
+	"admin-panel/services"
+	"context"
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+	"time"
+
+	"github.com/joho/godotenv"
+)
+
+func main() {
+	os.Exit(run())
+}
+
+// run returns the exit code; log.Fatal ertelenen kapatmaları atlayacağından hatalar burada kod olarak döner
+func run() int {
+	from := flag.String("from", "local", "Source storage backend")
+	to := flag.String("to", "", "Target storage backend")
+	deleteSource := flag.Bool("delete-source", false, "Delete the files from the source after copying")
+	flag.Parse()
+
+	if err := godotenv.Load(); err != nil {
+		log.Println(".env dosyası yüklenemedi, ortam değişkenleri kullanılacak")
+	}
+	if *to == "" {
+		flag.Usage()
+		return 2
+	}
+
+	if err := configs.Init(); err != nil {
+		log.Printf("Veritabanı başlatılamadı: %v", err)
+		return 1
+	}
+	defer configs.DB.Disconnect(context.Background())
+
+	if err := services.InitStorage(); err != nil {
+		log.Printf("Depolama sürücüsü başlatılamadı: %v", err)
+		return 1
+	}
+	services.InitMediaService(configs.DB)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 6*time.Hour)
+	defer cancel()
+	result, err := services.MigrateMediaStorage(ctx, *from, *to, *deleteSource)
+	if err != nil {
+		log.Printf("Medya taşıma başarısız: %v", err)
+		return 1
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	encoder.Encode(result)
+	if len(result.Failed) > 0 {
+		return 1
+	}
+	return 0
+}
@@ -3,5 +3,5 @@ package configs
 var LanguageConfig = struct {
 	DefaultLanguage string
 }{
-	DefaultLanguage: "en", // Varsayılan dil
+	DefaultLanguage: "en", // Dil kayıtlarında varsayılan dil tanımlı değilse kullanılır
 }
@@ -1,8 +1,8 @@
 package controllers
 
 import (
+	"admin-panel/helpers"
 	"admin-panel/services"
-	"net/http"
 
 	"github.com/gin-gonic/gin"
 )
@@ -12,19 +12,27 @@ import (
 // @Description Retrieve all activity logs with their details
 // @Tags Activity Logs
 // @Produce json
-// @Success 200 {array} models.ActivityLog "List of activity logs"
+// @Param limit query int false "Page size (1-100, default 20)"
+// @Param cursor query string false "Cursor returned as next_cursor by the previous page"
+// @Param sort query string false "Sort field, '-' prefix for descending (e.g., '-created_at')"
+// @Param fields query string false "Comma-separated fields to return (e.g., 'id,title')"
+// @Param total query bool false "Include the total count"
+// @Success 200 {object} models.ListResponse{items=[]models.ActivityLog} "List of activity logs"
 // @Failure 500 {object} map[string]interface{} "Failed to retrieve activity logs"
 // @Router /activity-logs [get]
 func GetActivityLogsHandler(c *gin.Context) {
-	// Filtreleme ve limit parametrelerini al
-	limit := 100
+	// Filtreleme ve sayfalama parametrelerini al
+	opts, ok := helpers.BindListOptions(c, "-timestamp")
+	if !ok {
+		return
+	}
 	filter := map[string]interface{}{}
 
-	logs, err := services.GetActivityLogs(filter, limit)
+	logs, info, err := services.GetActivityLogs(filter, opts)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve activity logs"})
+		helpers.RespondListError(c, "Failed to retrieve activity logs", err)
 		return
 	}
 
-	c.JSON(http.StatusOK, logs)
+	helpers.RespondList(c, logs, info, opts)
 }
@@ -0,0 +1,47 @@
+package controllers
+
+import (
+	"admin-panel/models"
+	"admin-panel/services"
+	"admin-panel/utils"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ConvertBlocksHandler converts between an HTML body and content blocks
+// @Summary Convert HTML and blocks
+// @Description Convert an HTML body to blocks (images must exist in the media library) or render blocks to HTML; both directions also return the plain text
+// @Tags Blocks
+// @Accept json
+// @Produce json
+// @Param conversion body models.BlockConversionRequest true "HTML or blocks"
+// @Success 200 {object} models.BlockConversion
+// @Failure 400 {object} map[string]interface{}
+// @Failure 500 {object} map[string]string
+// @Router /admin/blocks/convert [post]
+func ConvertBlocksHandler(c *gin.Context) {
+	var request models.BlockConversionRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if (request.HTML == "") == (len(request.Blocks) == 0) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Provide either html or blocks"})
+		return
+	}
+
+	conversion, err := services.ConvertBlocks(c.Request.Context(), request)
+	if err != nil {
+		var validationErrors utils.ValidationErrors
+		if errors.As(err, &validationErrors) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Validation failed", "errors": validationErrors})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to convert content", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, conversion)
+}
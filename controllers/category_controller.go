@@ -1,9 +1,9 @@
 package controllers
 
 import (
+	"admin-panel/helpers"
 	"admin-panel/models"
 	"admin-panel/services"
-	"admin-panel/utils"
 	"net/http"
 	"time"
 
@@ -37,9 +37,9 @@ func CreateCategoryHandler(c *gin.Context) {
 
 	for lang, localization := range category.Localizations {
 		if localization.Title != "" {
-			category.Slug[lang] = utils.GenerateSlug(localization.Title) // Her dil için slug oluştur
-		} else if lang == "en" {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "English title is required for slug generation"})
+			category.Slug[lang] = services.GenerateSlug(lang, localization.Title) // Her dil için slug oluştur
+		} else if lang == services.DefaultLanguage() {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Default language title is required for slug generation"})
 			return
 		}
 	}
@@ -58,17 +58,27 @@ func CreateCategoryHandler(c *gin.Context) {
 // @Description Retrieve all categories with their details
 // @Tags Categories
 // @Produce json
-// @Success 200 {array} models.Category
+// @Param limit query int false "Page size (1-100, default 20)"
+// @Param cursor query string false "Cursor returned as next_cursor by the previous page"
+// @Param sort query string false "Sort field, '-' prefix for descending (e.g., '-created_at')"
+// @Param fields query string false "Comma-separated fields to return (e.g., 'id,title')"
+// @Param total query bool false "Include the total count"
+// @Success 200 {object} models.ListResponse{items=[]models.Category}
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Router /categories [get]
 func GetAllCategoriesHandler(c *gin.Context) {
-	categories, err := services.GetAllCategories()
+	opts, ok := helpers.BindListOptions(c, "-id")
+	if !ok {
+		return
+	}
+
+	categories, info, err := services.GetAllCategories(opts)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve categories"})
+		helpers.RespondListError(c, "Failed to retrieve categories", err)
 		return
 	}
 
-	c.JSON(http.StatusOK, categories)
+	helpers.RespondList(c, categories, info, opts)
 }
 
 // GetCategoryByIDHandler retrieves a category by ID
@@ -148,7 +158,7 @@ func UpdateCategoryHandler(c *gin.Context) {
 
 // DeleteCategoryHandler deletes a category by ID
 // @Summary Delete a category
-// @Description Remove a category by its unique identifier
+// @Description Move a category to the trash; it can be restored from /admin/trash/categories
 // @Tags Categories
 // @Param id path string true "Category ID"
 // @Success 204 "No Content"
@@ -165,7 +175,8 @@ func DeleteCategoryHandler(c *gin.Context) {
 		return
 	}
 
-	if err := services.DeleteCategory(c.Request.Context(), objectID); err != nil {
+	_, username := helpers.CurrentUser(c)
+	if err := services.DeleteCategory(c.Request.Context(), objectID, username); err != nil {
 		if err == mongo.ErrNoDocuments {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Category not found"})
 			return
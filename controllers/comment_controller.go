@@ -1,6 +1,7 @@
 package controllers
 
 import (
+	"admin-panel/helpers"
 	"admin-panel/models"
 	"admin-panel/services"
 	"log"
@@ -10,6 +11,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
 // CreateCommentHandler creates a new comment
@@ -33,13 +35,13 @@ func CreateCommentHandler(c *gin.Context) {
 	comment.ID = primitive.NewObjectID()
 
 	// Yorumu oluştur
-	result, err := services.CreateComment(c.Request.Context(), &comment)
+	result, report, err := services.CreateComment(c.Request.Context(), &comment)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusCreated, gin.H{"message": "Comment created", "result": result})
+	c.JSON(http.StatusCreated, withSanitizeReport(gin.H{"message": "Comment created", "result": result}, report))
 }
 
 // GetCommentsByPostIDHandler bir gönderiye ait yorumları döndürür
@@ -186,7 +188,7 @@ func AddReplyHandler(c *gin.Context) {
 	reply.UpdatedAt = time.Now()
 
 	// Cevap yorumunu oluştur
-	replyResult, err := services.CreateComment(c.Request.Context(), &reply)
+	replyResult, report, err := services.CreateComment(c.Request.Context(), &reply)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create reply", "details": err.Error()})
 		return
@@ -220,7 +222,7 @@ func AddReplyHandler(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Reply added successfully", "reply_id": replyID})
+	c.JSON(http.StatusOK, withSanitizeReport(gin.H{"message": "Reply added successfully", "reply_id": replyID}, report))
 }
 
 // AddReactionHandler adds a reaction to a comment
@@ -255,6 +257,10 @@ func AddReactionHandler(c *gin.Context) {
 
 	// Reaksiyon ekle
 	err = services.AddReaction(c.Request.Context(), objectID, reaction)
+	if err == mongo.ErrNoDocuments {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Comment not found"})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to add reaction", "details": err.Error()})
 		return
@@ -287,6 +293,10 @@ func LikeCommentHandler(c *gin.Context) {
 
 	// Yorumu beğen
 	if err := services.LikeComment(c.Request.Context(), objectID); err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Comment not found"})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -314,9 +324,14 @@ func DeleteCommentHandler(c *gin.Context) {
 		return
 	}
 
-	// Yorum sil
-	err = services.DeleteComment(c.Request.Context(), objectID)
+	// Yorumu çöp kutusuna taşı
+	_, username := helpers.CurrentUser(c)
+	err = services.DeleteComment(c.Request.Context(), objectID, username)
 	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Comment not found"})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete comment", "details": err.Error()})
 		return
 	}
@@ -358,11 +373,15 @@ func UpdateCommentHandler(c *gin.Context) {
 	}
 
 	// Yorum güncelle
-	err = services.UpdateComment(c.Request.Context(), objectID, updatedData.Content)
+	report, err := services.UpdateComment(c.Request.Context(), objectID, updatedData.Content)
+	if err == mongo.ErrNoDocuments {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Comment not found"})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update comment", "details": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Comment updated successfully"})
+	c.JSON(http.StatusOK, withSanitizeReport(gin.H{"message": "Comment updated successfully"}, report))
 }
@@ -1,6 +1,7 @@
 package controllers
 
 import (
+	"admin-panel/helpers"
 	"admin-panel/models"
 	"admin-panel/services"
 	"net/http"
@@ -28,13 +29,13 @@ func CreateContactMessageHandler(c *gin.Context) {
 		return
 	}
 
-	createdMessage, err := services.CreateContactMessage(c.Request.Context(), &message)
+	createdMessage, report, err := services.CreateContactMessage(c.Request.Context(), &message)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save contact message", "details": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Contact message sent successfully", "data": createdMessage})
+	c.JSON(http.StatusOK, withSanitizeReport(gin.H{"message": "Contact message sent successfully", "data": createdMessage}, report))
 }
 
 // GetAllContactsHandler retrieves all contact messages
@@ -42,17 +43,27 @@ func CreateContactMessageHandler(c *gin.Context) {
 // @Description Retrieve all contact messages sent by users
 // @Tags Contacts
 // @Produce json
-// @Success 200 {array} models.ContactMessage
+// @Param limit query int false "Page size (1-100, default 20)"
+// @Param cursor query string false "Cursor returned as next_cursor by the previous page"
+// @Param sort query string false "Sort field, '-' prefix for descending (e.g., '-created_at')"
+// @Param fields query string false "Comma-separated fields to return (e.g., 'id,title')"
+// @Param total query bool false "Include the total count"
+// @Success 200 {object} models.ListResponse{items=[]models.ContactMessage}
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Router /contacts [get]
 func GetAllContactMessagesHandler(c *gin.Context) {
-	messages, err := services.GetAllContactMessages(c.Request.Context())
+	opts, ok := helpers.BindListOptions(c, "-id")
+	if !ok {
+		return
+	}
+
+	messages, info, err := services.GetAllContactMessages(c.Request.Context(), opts)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch contact messages", "details": err.Error()})
+		helpers.RespondListError(c, "Failed to fetch contact messages", err)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"data": messages})
+	helpers.RespondList(c, messages, info, opts)
 }
 
 // GetContactByIDHandler retrieves a contact message by ID
@@ -0,0 +1,100 @@
+package controllers
+
+import (
+	"admin-panel/models"
+	"admin-panel/services"
+	"admin-panel/utils"
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// ResolveContentHandler resolves a SEO-friendly URL to a published post or page
+// @Summary Resolve content by language and slug
+// @Description Return the published post or page and its SEO metadata using the slug; old slugs are answered with a 301 redirect to the current URL
+// @Tags Public
+// @Produce json
+// @Param lang path string true "Language code (e.g., 'en', 'tr')"
+// @Param slug path string true "Post or page slug"
+// @Success 200 {object} map[string]interface{}
+// @Success 301 {string} string "Redirect to the current slug"
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /{lang}/{slug} [get]
+func ResolveContentHandler(c *gin.Context) {
+	ctx := c.Request.Context()
+	lang := c.Param("lang")
+	slug := c.Param("slug")
+
+	post, err := services.GetPublishedPostByLangAndSlug(ctx, lang, slug)
+	if err == nil {
+		seo, err := services.ResolvePostSEO(ctx, post, lang, publicSiteURL(c))
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to resolve SEO metadata", "details": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"type": "post", "data": services.PublicPost(post, lang), "seo": seo})
+		return
+	}
+	if err != mongo.ErrNoDocuments {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch content", "details": err.Error()})
+		return
+	}
+
+	page, err := services.GetPublishedPageByLangAndSlug(ctx, lang, slug)
+	if err == nil {
+		seo, err := services.ResolvePageSEO(ctx, page, lang, publicSiteURL(c))
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to resolve SEO metadata", "details": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"type": "page", "data": services.PublicPage(page, lang), "seo": seo})
+		return
+	}
+	if err != mongo.ErrNoDocuments {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch content", "details": err.Error()})
+		return
+	}
+
+	// Eski bir slug ise güncel adrese yönlendir
+	redirect, err := services.GetRedirect(ctx, lang, slug)
+	if err == nil {
+		status := redirect.StatusCode
+		if status == 0 {
+			status = http.StatusMovedPermanently
+		}
+		c.Redirect(status, "/"+url.PathEscape(lang)+"/"+url.PathEscape(redirect.ToSlug))
+		return
+	}
+	if err != mongo.ErrNoDocuments {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch content", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusNotFound, gin.H{"error": "Content not found"})
+}
+
+// withSanitizeReport adds the sanitizer report to a write response when markup was removed
+func withSanitizeReport(response gin.H, report []models.SanitizeReport) gin.H {
+	if len(report) > 0 {
+		response["sanitized"] = report
+	}
+	return response
+}
+
+// contentFormat returns the body format of a localized field; eski kayıtlarda format boştur ve HTML'dir
+func contentFormat(field models.LocalizedField) string {
+	if field.Format == "" {
+		return models.FormatHTML
+	}
+	return field.Format
+}
+
+// isLocalizationError reports whether a content write failed because of invalid localizations, blocks or formats
+func isLocalizationError(err error) bool {
+	var validationErrors utils.ValidationErrors
+	return errors.Is(err, services.ErrInvalidLanguageCode) || errors.Is(err, services.ErrSlugUnavailable) || errors.As(err, &validationErrors)
+}
@@ -0,0 +1,297 @@
+package controllers
+
+import (
+	"admin-panel/helpers"
+	"admin-panel/models"
+	"admin-panel/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// CreateEntryHandler creates an entry of a content type
+// @Summary Create a content entry
+// @Description Create an entry; shared values go to data, per-language values to localizations and are validated against the schema
+// @Tags ContentEntries
+// @Accept json
+// @Produce json
+// @Param type path string true "Content type key"
+// @Param entry body models.ContentEntry true "Entry values"
+// @Success 201 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /admin/content-types/{type}/entries [post]
+func CreateEntryHandler(c *gin.Context) {
+	contentType, ok := bindContentType(c)
+	if !ok {
+		return
+	}
+
+	var entry models.ContentEntry
+	if err := c.ShouldBindJSON(&entry); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	_, username := helpers.CurrentUser(c)
+	entry.CreatedBy = username
+	entry.UpdatedBy = username
+	report, err := services.CreateEntry(c.Request.Context(), contentType, &entry)
+	if err != nil {
+		respondContentError(c, "Failed to create entry", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, withSanitizeReport(gin.H{"data": entry}, report))
+}
+
+// GetEntriesHandler lists the entries of a content type
+// @Summary List content entries
+// @Description List the entries of a content type; filter[field]=value and filter[field:gt|gte|lt|lte|ne]=value filter on schema fields
+// @Tags ContentEntries
+// @Produce json
+// @Param type path string true "Content type key"
+// @Param status query string false "Status (draft, published)"
+// @Param lang query string false "Language of localized filter fields (defaults to the site default language)"
+// @Param limit query int false "Page size (1-100, default 20)"
+// @Param cursor query string false "Cursor returned as next_cursor by the previous page"
+// @Param sort query string false "Sort field, '-' prefix for descending (default '-created_at')"
+// @Param total query bool false "Include the total count"
+// @Success 200 {object} models.ListResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /admin/content-types/{type}/entries [get]
+func GetEntriesHandler(c *gin.Context) {
+	contentType, ok := bindContentType(c)
+	if !ok {
+		return
+	}
+	opts, ok := helpers.BindListOptions(c, "-created_at")
+	if !ok {
+		return
+	}
+
+	filter, err := services.BuildEntryFilter(contentType, c.QueryMap("filter"), c.DefaultQuery("lang", services.DefaultLanguage()))
+	if err != nil {
+		respondContentError(c, "Failed to retrieve entries", err)
+		return
+	}
+	if status := c.Query("status"); status != "" {
+		filter["status"] = status
+	}
+
+	entries, info, err := services.GetEntries(c.Request.Context(), contentType.Key, filter, opts)
+	if err != nil {
+		helpers.RespondListError(c, "Failed to retrieve entries", err)
+		return
+	}
+
+	helpers.RespondList(c, entries, info, opts)
+}
+
+// GetEntryHandler retrieves an entry of a content type
+// @Summary Get a content entry
+// @Description Retrieve an entry of a content type with all languages
+// @Tags ContentEntries
+// @Produce json
+// @Param type path string true "Content type key"
+// @Param id path string true "Entry ID"
+// @Success 200 {object} models.ContentEntry
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /admin/content-types/{type}/entries/{id} [get]
+func GetEntryHandler(c *gin.Context) {
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid entry ID"})
+		return
+	}
+
+	entry, err := services.GetEntry(c.Request.Context(), c.Param("type"), id)
+	if err != nil {
+		respondContentError(c, "Failed to retrieve entry", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, entry)
+}
+
+// UpdateEntryHandler replaces the values of an entry
+// @Summary Update a content entry
+// @Description Replace the status and values of an entry; the values are validated against the current schema
+// @Tags ContentEntries
+// @Accept json
+// @Produce json
+// @Param type path string true "Content type key"
+// @Param id path string true "Entry ID"
+// @Param entry body models.ContentEntry true "Entry values"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /admin/content-types/{type}/entries/{id} [put]
+func UpdateEntryHandler(c *gin.Context) {
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid entry ID"})
+		return
+	}
+	contentType, ok := bindContentType(c)
+	if !ok {
+		return
+	}
+
+	var entry models.ContentEntry
+	if err := c.ShouldBindJSON(&entry); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	_, username := helpers.CurrentUser(c)
+	entry.UpdatedBy = username
+	report, err := services.UpdateEntry(c.Request.Context(), contentType, id, &entry)
+	if err != nil {
+		respondContentError(c, "Failed to update entry", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, withSanitizeReport(gin.H{"message": "Entry updated successfully"}, report))
+}
+
+// DeleteEntryHandler deletes an entry of a content type
+// @Summary Delete a content entry
+// @Description Delete an entry of a content type
+// @Tags ContentEntries
+// @Produce json
+// @Param type path string true "Content type key"
+// @Param id path string true "Entry ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /admin/content-types/{type}/entries/{id} [delete]
+func DeleteEntryHandler(c *gin.Context) {
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid entry ID"})
+		return
+	}
+
+	if err := services.DeleteEntry(c.Request.Context(), c.Param("type"), id); err != nil {
+		respondContentError(c, "Failed to delete entry", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Entry deleted successfully"})
+}
+
+// GetPublicEntriesHandler lists the published entries of a public content type
+// @Summary List published entries
+// @Description List the published entries of a public content type resolved to a language; filter[field] and filter[field:op] filter on schema fields
+// @Tags Public
+// @Produce json
+// @Param type path string true "Content type key"
+// @Param lang query string false "Language code (defaults to the site default language)"
+// @Param limit query int false "Page size (1-100, default 20)"
+// @Param cursor query string false "Cursor returned as next_cursor by the previous page"
+// @Param sort query string false "Sort field, '-' prefix for descending (default '-publish_date')"
+// @Param total query bool false "Include the total count"
+// @Success 200 {object} models.ListResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/public/v1/types/{type} [get]
+func GetPublicEntriesHandler(c *gin.Context) {
+	contentType, ok := bindPublicContentType(c)
+	if !ok {
+		return
+	}
+	lang := c.DefaultQuery("lang", services.DefaultLanguage())
+
+	opts, ok := helpers.BindListOptions(c, "-publish_date")
+	if !ok {
+		return
+	}
+	opts.Fields = nil // Yanıt dile göre çözümlenmiş alanlardan oluşur
+
+	filter, err := services.BuildEntryFilter(contentType, c.QueryMap("filter"), lang)
+	if err != nil {
+		respondContentError(c, "Failed to retrieve entries", err)
+		return
+	}
+
+	entries, info, err := services.GetPublishedEntries(c.Request.Context(), contentType, lang, filter, opts)
+	if err != nil {
+		helpers.RespondListError(c, "Failed to retrieve entries", err)
+		return
+	}
+
+	helpers.RespondList(c, entries, info, opts)
+}
+
+// GetPublicEntryHandler retrieves a published entry of a public content type
+// @Summary Get a published entry
+// @Description Retrieve a published entry resolved to a language; follows the language fallback chain when the translation is missing
+// @Tags Public
+// @Produce json
+// @Param type path string true "Content type key"
+// @Param id path string true "Entry ID"
+// @Param lang query string false "Language code (defaults to the site default language)"
+// @Success 200 {object} models.PublicEntry
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/public/v1/types/{type}/{id} [get]
+func GetPublicEntryHandler(c *gin.Context) {
+	contentType, ok := bindPublicContentType(c)
+	if !ok {
+		return
+	}
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid entry ID"})
+		return
+	}
+	lang := c.DefaultQuery("lang", services.DefaultLanguage())
+
+	entry, err := services.GetPublishedEntry(c.Request.Context(), contentType.Key, id)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Entry not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch entry", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": services.PublicEntry(entry, lang)})
+}
+
+// bindContentType loads the content type named in the route or writes the error response
+func bindContentType(c *gin.Context) (*models.ContentType, bool) {
+	contentType, err := services.GetContentType(c.Request.Context(), c.Param("type"))
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Content type not found"})
+			return nil, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve content type", "details": err.Error()})
+		return nil, false
+	}
+	return contentType, true
+}
+
+// bindPublicContentType is bindContentType for the public API; herkese açık olmayan tipler 404 döner
+func bindPublicContentType(c *gin.Context) (*models.ContentType, bool) {
+	contentType, ok := bindContentType(c)
+	if ok && !contentType.Public {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Content type not found"})
+		return nil, false
+	}
+	return contentType, ok
+}
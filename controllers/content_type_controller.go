@@ -0,0 +1,158 @@
+package controllers
+
+import (
+	"admin-panel/helpers"
+	"admin-panel/models"
+	"admin-panel/services"
+	"admin-panel/utils"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// CreateContentTypeHandler defines a new content type
+// @Summary Create a content type
+// @Description Define a content type with typed fields, per-field localization flags and validation rules
+// @Tags ContentTypes
+// @Accept json
+// @Produce json
+// @Param contentType body models.ContentType true "Content type definition"
+// @Success 201 {object} models.ContentType
+// @Failure 400 {object} map[string]interface{}
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /admin/content-types [post]
+func CreateContentTypeHandler(c *gin.Context) {
+	var contentType models.ContentType
+	if err := c.ShouldBindJSON(&contentType); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	_, username := helpers.CurrentUser(c)
+	contentType.CreatedBy = username
+	contentType.UpdatedBy = username
+	if err := services.CreateContentType(c.Request.Context(), &contentType); err != nil {
+		respondContentError(c, "Failed to create content type", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, contentType)
+}
+
+// GetContentTypesHandler lists the content types
+// @Summary List content types
+// @Description List the defined content types
+// @Tags ContentTypes
+// @Produce json
+// @Param limit query int false "Page size (1-100, default 20)"
+// @Param cursor query string false "Cursor returned as next_cursor by the previous page"
+// @Param sort query string false "Sort field, '-' prefix for descending (default 'key')"
+// @Param total query bool false "Include the total count"
+// @Success 200 {object} models.ListResponse
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /admin/content-types [get]
+func GetContentTypesHandler(c *gin.Context) {
+	opts, ok := helpers.BindListOptions(c, "key")
+	if !ok {
+		return
+	}
+
+	contentTypes, info, err := services.GetContentTypes(c.Request.Context(), opts)
+	if err != nil {
+		helpers.RespondListError(c, "Failed to retrieve content types", err)
+		return
+	}
+
+	helpers.RespondList(c, contentTypes, info, opts)
+}
+
+// GetContentTypeHandler retrieves a content type
+// @Summary Get a content type
+// @Description Retrieve the schema of a content type by its key
+// @Tags ContentTypes
+// @Produce json
+// @Param type path string true "Content type key"
+// @Success 200 {object} models.ContentType
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /admin/content-types/{type} [get]
+func GetContentTypeHandler(c *gin.Context) {
+	contentType, err := services.GetContentType(c.Request.Context(), c.Param("type"))
+	if err != nil {
+		respondContentError(c, "Failed to retrieve content type", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, contentType)
+}
+
+// UpdateContentTypeHandler replaces the definition of a content type
+// @Summary Update a content type
+// @Description Replace the names, fields and visibility of a content type; the key cannot be changed
+// @Tags ContentTypes
+// @Accept json
+// @Produce json
+// @Param type path string true "Content type key"
+// @Param contentType body models.ContentType true "Content type definition"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /admin/content-types/{type} [put]
+func UpdateContentTypeHandler(c *gin.Context) {
+	var contentType models.ContentType
+	if err := c.ShouldBindJSON(&contentType); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	_, username := helpers.CurrentUser(c)
+	contentType.UpdatedBy = username
+	if err := services.UpdateContentType(c.Request.Context(), c.Param("type"), &contentType); err != nil {
+		respondContentError(c, "Failed to update content type", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Content type updated successfully"})
+}
+
+// DeleteContentTypeHandler deletes a content type
+// @Summary Delete a content type
+// @Description Delete a content type; types that still have entries cannot be deleted
+// @Tags ContentTypes
+// @Produce json
+// @Param type path string true "Content type key"
+// @Success 200 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /admin/content-types/{type} [delete]
+func DeleteContentTypeHandler(c *gin.Context) {
+	if err := services.DeleteContentType(c.Request.Context(), c.Param("type")); err != nil {
+		respondContentError(c, "Failed to delete content type", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Content type deleted successfully"})
+}
+
+// respondContentError maps content type and entry errors to HTTP responses
+func respondContentError(c *gin.Context, message string, err error) {
+	var validationErrors utils.ValidationErrors
+	switch {
+	case errors.As(err, &validationErrors):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Validation failed", "errors": validationErrors})
+	case errors.Is(err, services.ErrInvalidEntryFilter):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid filter", "details": err.Error()})
+	case errors.Is(err, services.ErrContentTypeExists), errors.Is(err, services.ErrContentTypeInUse):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case err == mongo.ErrNoDocuments:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Not found"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message, "details": err.Error()})
+	}
+}
@@ -0,0 +1,164 @@
+package controllers
+
+import (
+	"admin-panel/helpers"
+	"admin-panel/models"
+	"admin-panel/services"
+	"admin-panel/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// GetFeedHandler serves the feed of a language
+// @Summary Get a language feed
+// @Description Latest published posts of a language as RSS 2.0 (rss.xml), Atom (atom.xml) or JSON Feed (feed.json)
+// @Tags Feeds
+// @Produce xml
+// @Produce json
+// @Param lang path string true "Language code (e.g., 'en', 'tr')"
+// @Param format path string true "rss.xml, atom.xml or feed.json"
+// @Param limit query int false "Item count (1-100, default FEED_ITEM_COUNT)"
+// @Success 200 {string} string
+// @Success 304 {string} string "Not modified"
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /feeds/{lang}/{format} [get]
+func GetFeedHandler(c *gin.Context) {
+	serveFeed(c, nil, nil)
+}
+
+// GetCategoryFeedHandler serves the feed of a category
+// @Summary Get a category feed
+// @Description Latest published posts of a category in a language as RSS 2.0, Atom or JSON Feed
+// @Tags Feeds
+// @Produce xml
+// @Produce json
+// @Param lang path string true "Language code (e.g., 'en', 'tr')"
+// @Param id path string true "Category ID"
+// @Param format path string true "rss.xml, atom.xml or feed.json"
+// @Param limit query int false "Item count (1-100, default FEED_ITEM_COUNT)"
+// @Success 200 {string} string
+// @Success 304 {string} string "Not modified"
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /feeds/{lang}/categories/{id}/{format} [get]
+func GetCategoryFeedHandler(c *gin.Context) {
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid category ID"})
+		return
+	}
+	serveFeed(c, &id, nil)
+}
+
+// GetTagFeedHandler serves the feed of a tag
+// @Summary Get a tag feed
+// @Description Latest published posts of a tag in a language as RSS 2.0, Atom or JSON Feed
+// @Tags Feeds
+// @Produce xml
+// @Produce json
+// @Param lang path string true "Language code (e.g., 'en', 'tr')"
+// @Param id path string true "Tag ID"
+// @Param format path string true "rss.xml, atom.xml or feed.json"
+// @Param limit query int false "Item count (1-100, default FEED_ITEM_COUNT)"
+// @Success 200 {string} string
+// @Success 304 {string} string "Not modified"
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /feeds/{lang}/tags/{id}/{format} [get]
+func GetTagFeedHandler(c *gin.Context) {
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tag ID"})
+		return
+	}
+	serveFeed(c, nil, &id)
+}
+
+// serveFeed builds the requested feed and writes it in the format named by the route
+func serveFeed(c *gin.Context, categoryID, tagID *primitive.ObjectID) {
+	lang := c.Param("lang")
+	format := c.Param("format")
+
+	var render func(*models.Feed) ([]byte, error)
+	var contentType string
+	switch format {
+	case models.FeedRSS:
+		render, contentType = utils.RenderRSS, "application/rss+xml; charset=utf-8"
+	case models.FeedAtom:
+		render, contentType = utils.RenderAtom, "application/atom+xml; charset=utf-8"
+	case models.FeedJSON:
+		render, contentType = utils.RenderJSONFeed, "application/feed+json; charset=utf-8"
+	default:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Unknown feed format"})
+		return
+	}
+
+	if !containsLanguage(services.ActiveLanguages(), lang) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Language not found"})
+		return
+	}
+
+	limit := services.FeedItemCount
+	if value := c.Query("limit"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 || n > services.MaxFeedItems {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit", "details": "limit must be between 1 and " + strconv.Itoa(services.MaxFeedItems)})
+			return
+		}
+		limit = n
+	}
+
+	siteURL := publicSiteURL(c)
+	feed, err := services.BuildFeed(c.Request.Context(), models.FeedQuery{
+		Lang:       lang,
+		CategoryID: categoryID,
+		TagID:      tagID,
+		Limit:      limit,
+		SiteURL:    siteURL,
+		FeedURL:    siteURL + c.Request.URL.Path,
+	})
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Feed not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to build feed", "details": err.Error()})
+		return
+	}
+
+	body, err := render(feed)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to render feed", "details": err.Error()})
+		return
+	}
+	helpers.RespondCached(c, contentType, body, feed.Updated, services.FeedCacheMaxAge)
+}
+
+// publicSiteURL returns SITE_URL or, if unset, the scheme and host of the request
+func publicSiteURL(c *gin.Context) string {
+	if siteURL := services.SiteURL(); siteURL != "" {
+		return siteURL
+	}
+	scheme := "http"
+	if c.Request.TLS != nil || c.GetHeader("X-Forwarded-Proto") == "https" {
+		scheme = "https"
+	}
+	return scheme + "://" + c.Request.Host
+}
+
+func containsLanguage(languages []string, lang string) bool {
+	for _, code := range languages {
+		if code == lang {
+			return true
+		}
+	}
+	return false
+}
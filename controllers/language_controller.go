@@ -1,9 +1,9 @@
 package controllers
 
 import (
-	"admin-panel/configs"
 	"admin-panel/models"
 	"admin-panel/services"
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -29,6 +29,10 @@ func CreateLanguageHandler(c *gin.Context) {
 	}
 
 	if err := services.CreateLanguage(&language); err != nil {
+		if errors.Is(err, services.ErrInvalidLanguageCode) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create language"})
 		return
 	}
@@ -47,7 +51,7 @@ func CreateLanguageHandler(c *gin.Context) {
 // @Router /languages [get]
 func GetLanguagesHandler(c *gin.Context) {
 	// Aktif dil parametresini al
-	activeLang := c.DefaultQuery("lang", "en") // Varsayılan dil "en"
+	activeLang := c.DefaultQuery("lang", services.DefaultLanguage())
 
 	// Tüm dilleri al
 	languages, err := services.GetLanguagesWithActiveAndDefault(activeLang)
@@ -59,7 +63,7 @@ func GetLanguagesHandler(c *gin.Context) {
 	// Varsayılan ve aktif dil bilgilerini ekle
 	response := gin.H{
 		"active_language":  activeLang,
-		"default_language": configs.LanguageConfig.DefaultLanguage, // Varsayılan dili config'den çek
+		"default_language": services.DefaultLanguage(), // Dil kayıtlarındaki varsayılan dil
 		"languages":        languages,
 	}
 
@@ -94,6 +98,10 @@ func UpdateLanguageHandler(c *gin.Context) {
 	}
 
 	if err := services.UpdateLanguage(objectID, update); err != nil {
+		if errors.Is(err, services.ErrInvalidLanguageCode) || errors.Is(err, services.ErrUnknownLanguage) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update language"})
 		return
 	}
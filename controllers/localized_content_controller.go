@@ -27,13 +27,13 @@ func CreateLocalizedContentHandler(c *gin.Context) {
 		return
 	}
 
-	err := services.CreateLocalizedContent(c.Request.Context(), &input)
+	report, err := services.CreateLocalizedContent(c.Request.Context(), &input)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create content"})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Content created successfully"})
+	c.JSON(http.StatusOK, withSanitizeReport(gin.H{"message": "Content created successfully"}, report))
 }
 
 // GetLocalizedContentHandler retrieves localized content by ID and language
@@ -50,7 +50,7 @@ func CreateLocalizedContentHandler(c *gin.Context) {
 // @Router /localized-content/{id} [get]
 func GetLocalizedContentHandler(c *gin.Context) {
 	id := c.Param("id")
-	lang := c.DefaultQuery("lang", "en")
+	lang := c.DefaultQuery("lang", services.DefaultLanguage())
 
 	objectID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
@@ -64,6 +64,6 @@ func GetLocalizedContentHandler(c *gin.Context) {
 		return
 	}
 
-	translation := content.Translations[lang]
-	c.JSON(http.StatusOK, gin.H{"content": translation})
+	translation, resolvedLang, _ := services.ResolveTranslation(content.Translations, lang)
+	c.JSON(http.StatusOK, gin.H{"content": translation, "lang": resolvedLang})
 }
@@ -1,48 +1,70 @@
 package controllers
 
 import (
+	"admin-panel/helpers"
 	"admin-panel/models"
 	"admin-panel/services"
-	"fmt"
+	"admin-panel/utils"
+	"errors"
 	"net/http"
-	"os"
-	"path/filepath"
+	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
+// Tek istekte taşınabilecek azami medya sayısı
+const maxMediaMoveItems = 500
+
 // UploadMediaHandler uploads a new media file
 // @Summary Upload a new media file
-// @Description Upload a file to the media library
+// @Description Upload a file to the media library in the storage backend set by MEDIA_STORAGE. The type is detected from the content and checked against the upload policies of the user's roles; the extension must match the content. EXIF/GPS metadata is removed from images, which also get their width, height and dominant colour recorded and the configured renditions (MEDIA_RENDITIONS) generated, with WebP variants. Rejections carry a code: missing_file, empty_file, invalid_file_name, mime_type_not_allowed, file_too_large, extension_mismatch, corrupt_file. When a file with the same content (SHA-256) is already in the library, nothing is stored again: dedupe=reuse (default) returns the existing item, dedupe=link creates a new item sharing its file; the response then has duplicate=true. A reused item keeps its own folder: when it differs from folder_id the response has folder_applied=false
 // @Tags Media
 // @Accept multipart/form-data
 // @Produce json
 // @Param file formData file true "Media file to upload"
-// @Success 201 {object} models.Media "File uploaded successfully"
-// @Failure 400 {object} map[string]interface{} "No file uploaded or invalid request"
+// @Param folder_id formData string false "Folder to put the file in (root when empty)"
+// @Param dedupe query string false "reuse (default) or link"
+// @Success 200 {object} map[string]interface{} "File uploaded or already in the library: message, file_path, media (models.Media), duplicate and folder_applied"
+// @Failure 400 {object} utils.UploadError "No file uploaded, invalid name, extension mismatch or corrupt file"
+// @Failure 413 {object} utils.UploadError "File is larger than the role allows"
+// @Failure 415 {object} utils.UploadError "File type is not allowed for the role"
 // @Failure 500 {object} map[string]interface{} "Failed to save file or record"
 // @Router /media/upload [post]
 func UploadMediaHandler(c *gin.Context) {
-	file, err := c.FormFile("file")
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "No file is uploaded"})
-		return
-	}
+	roles, _ := c.Get("roles")
+	userRoles, _ := roles.([]string)
 
-	// Dosyayı uploads klasörüne kaydet
-	uploadPath := "uploads"
-	if _, err := os.Stat(uploadPath); os.IsNotExist(err) {
-		os.Mkdir(uploadPath, os.ModePerm)
+	dedupe := c.DefaultQuery("dedupe", models.MediaDedupeReuse)
+	if dedupe != models.MediaDedupeReuse && dedupe != models.MediaDedupeLink {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "dedupe must be reuse or link"})
+		return
 	}
 
-	filePath := filepath.Join(uploadPath, file.Filename)
-	if err := c.SaveUploadedFile(file, filePath); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save file"})
+	// Gövde, rolün en büyük sınırı ve form alanları için pay ile sınırlanır; tip sınırı tespitten sonra uygulanır
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, services.MaxUploadSizeForRoles(userRoles)+1<<20)
+	file, err := c.FormFile("file")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			respondUploadError(c, &utils.UploadError{Code: utils.UploadErrorTooLarge, Message: "File is larger than your role allows"})
+			return
+		}
+		respondUploadError(c, &utils.UploadError{Code: utils.UploadErrorMissingFile, Message: "No file is uploaded"})
 		return
 	}
+	var folderID *primitive.ObjectID
+	if value := c.PostForm("folder_id"); value != "" {
+		id, err := primitive.ObjectIDFromHex(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid folder ID"})
+			return
+		}
+		folderID = &id
+	}
 
 	// Yükleyen kullanıcıyı alın
 	uploadedBy, exists := c.Get("username")
@@ -51,21 +73,51 @@ func UploadMediaHandler(c *gin.Context) {
 		return
 	}
 
-	// Medya kaydı ekle
-	media := models.Media{
+	content, err := file.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read file"})
+		return
+	}
+	defer content.Close()
+
+	// Dosyayı depolama sürücüsüne yaz ve medya kaydını ekle
+	media, duplicate, err := services.UploadMedia(c.Request.Context(), services.MediaUpload{
 		FileName:   file.Filename,
-		FilePath:   filePath,
-		FileType:   filepath.Ext(file.Filename),
-		FileSize:   file.Size,
+		Content:    content,
+		Size:       file.Size,
 		UploadedBy: uploadedBy.(string),
-	}
-	_, err = services.SaveMediaRecord(media)
+		Roles:      userRoles,
+		Dedupe:     dedupe,
+		FolderID:   folderID,
+	})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save media record"})
+		var uploadErr *utils.UploadError
+		if errors.As(err, &uploadErr) {
+			respondUploadError(c, uploadErr)
+			return
+		}
+		if errors.Is(err, services.ErrMediaFolderNotFound) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Folder not found"})
+			return
+		}
+		if errors.Is(err, utils.ErrInvalidStorageKey) {
+			respondUploadError(c, &utils.UploadError{Code: utils.UploadErrorInvalidName, Message: "Invalid file name"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save file", "details": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "File uploaded successfully", "file_path": filePath})
+	// Yeniden kullanılan kayıt kendi klasöründe kalır; istenen klasör uygulanmadıysa yanıtta belirtilir
+	folderApplied := folderID == nil || (media.FolderID != nil && *media.FolderID == *folderID)
+	message := "File uploaded successfully"
+	switch {
+	case !folderApplied:
+		message = "File is already in the media library in another folder; the folder was not applied (use dedupe=link or move the item)"
+	case duplicate:
+		message = "File is already in the media library"
+	}
+	c.JSON(http.StatusOK, gin.H{"message": message, "file_path": services.MediaURL(*media), "media": media, "duplicate": duplicate, "folder_applied": folderApplied})
 }
 
 // GetAllMediaHandler retrieves all media files
@@ -73,27 +125,39 @@ func UploadMediaHandler(c *gin.Context) {
 // @Description Retrieve all media files in the library
 // @Tags Media
 // @Produce json
-// @Success 200 {array} models.Media "List of media files"
+// @Param limit query int false "Page size (1-100, default 20)"
+// @Param cursor query string false "Cursor returned as next_cursor by the previous page"
+// @Param sort query string false "Sort field, '-' prefix for descending (e.g., '-created_at')"
+// @Param fields query string false "Comma-separated fields to return (e.g., 'id,title')"
+// @Param total query bool false "Include the total count"
+// @Success 200 {object} models.ListResponse{items=[]models.Media} "List of media files"
 // @Failure 500 {object} map[string]interface{} "Failed to retrieve media files"
 // @Router /media [get]
 func GetAllMediaHandler(c *gin.Context) {
-	media, err := services.GetAllMedia()
+	opts, ok := helpers.BindListOptions(c, "-id")
+	if !ok {
+		return
+	}
+
+	media, info, err := services.GetAllMedia(opts)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve media files"})
+		helpers.RespondListError(c, "Failed to retrieve media files", err)
 		return
 	}
 
-	c.JSON(http.StatusOK, media)
+	helpers.RespondList(c, media, info, opts)
 }
 
 // DeleteMediaHandler deletes a media file
 // @Summary Delete media file
-// @Description Remove a specific media file by its ID
+// @Description Move a media file to the trash; the file is removed when the trash is purged (unless another item shares it). Media that is still used in content, sliders, settings or user avatars is not deleted without force=true; the places it is used are returned either way
 // @Tags Media
 // @Param id path string true "Media file ID"
+// @Param force query bool false "Delete even if the media is still used"
 // @Success 204 "No Content"
 // @Failure 400 {object} map[string]interface{} "Invalid media ID"
 // @Failure 404 {object} map[string]interface{} "Media file not found"
+// @Failure 409 {object} map[string]interface{} "Media is still in use"
 // @Failure 500 {object} map[string]interface{} "Failed to delete media file"
 // @Router /media/{id} [delete]
 func DeleteMediaHandler(c *gin.Context) {
@@ -104,20 +168,28 @@ func DeleteMediaHandler(c *gin.Context) {
 		return
 	}
 
-	// Veritabanından medya kaydını sil
-	result, err := services.DeleteMedia(id)
-	if err != nil || result.DeletedCount == 0 {
+	// Medya kaydını çöp kutusuna taşı; dosya kalıcı silmede kaldırılır
+	_, username := helpers.CurrentUser(c)
+	references, err := services.DeleteMedia(id, username, c.Query("force") == "true")
+	if err != nil {
+		if errors.Is(err, services.ErrMediaInUse) {
+			c.JSON(http.StatusConflict, gin.H{"error": "Media is still in use; pass force=true to delete it anyway", "code": "media_in_use", "references": references})
+			return
+		}
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Media not found"})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete media"})
 		return
 	}
 
-	// Opsiyonel: Dosya sisteminden de sil
-	filePath := "uploads/" + idParam // Örnek dosya yolu, MongoDB'den dosya yolunu da çekebilirsiniz.
-	if err := os.Remove(filePath); err != nil {
-		fmt.Println("Warning: File not found or failed to delete:", err)
+	response := gin.H{"message": "Media deleted successfully"}
+	if len(references) > 0 {
+		response["warning"] = "Media is still in use"
+		response["references"] = references
 	}
-
-	c.JSON(http.StatusOK, gin.H{"message": "Media deleted successfully"})
+	c.JSON(http.StatusOK, response)
 }
 
 // GetMediaDetailHandler retrieves details of a media file
@@ -148,19 +220,267 @@ func GetMediaDetailHandler(c *gin.Context) {
 	c.JSON(http.StatusOK, media)
 }
 
+// UpdateMediaMetadataHandler updates the texts, tags and focal point of a media file
+// @Summary Update media metadata
+// @Description Set the alt text, caption and title per active language code, the tags and the focal point (0-1 coordinates) of a media file. Omitted fields are left unchanged; localizations and tags replace the stored values. Tags are trimmed, lowercased and deduplicated
+// @Tags Media
+// @Accept json
+// @Produce json
+// @Param id path string true "Media file ID"
+// @Param metadata body models.MediaMetadataUpdate true "Metadata"
+// @Success 200 {object} models.Media
+// @Failure 400 {object} map[string]interface{} "Invalid media ID or validation errors"
+// @Failure 404 {object} map[string]interface{} "Media file not found"
+// @Failure 500 {object} map[string]interface{} "Failed to update media"
+// @Router /media/{id} [put]
+func UpdateMediaMetadataHandler(c *gin.Context) {
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid media ID"})
+		return
+	}
+
+	var update models.MediaMetadataUpdate
+	if err := c.ShouldBindJSON(&update); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	_, username := helpers.CurrentUser(c)
+	media, err := services.UpdateMediaMetadata(c.Request.Context(), id, update, username)
+	if err != nil {
+		respondMediaLibraryError(c, "Failed to update media", err)
+		return
+	}
+	c.JSON(http.StatusOK, media)
+}
+
+// MoveMediaHandler moves media files into a folder
+// @Summary Move media files
+// @Description Move media files into a folder; folder_id null moves them to the root. Files are not moved in storage
+// @Tags Media
+// @Accept json
+// @Produce json
+// @Param move body models.MediaMove true "Media IDs and target folder"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{} "Invalid request or folder not found"
+// @Failure 500 {object} map[string]interface{} "Failed to move media"
+// @Router /media/move [post]
+func MoveMediaHandler(c *gin.Context) {
+	var move models.MediaMove
+	if err := c.ShouldBindJSON(&move); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if len(move.IDs) == 0 || len(move.IDs) > maxMediaMoveItems {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ids must contain between 1 and " + strconv.Itoa(maxMediaMoveItems) + " media IDs"})
+		return
+	}
+
+	_, username := helpers.CurrentUser(c)
+	moved, err := services.MoveMedia(c.Request.Context(), move.IDs, move.FolderID, username)
+	if err != nil {
+		if errors.Is(err, services.ErrMediaFolderNotFound) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Folder not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to move media", "details": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Media moved successfully", "moved": moved})
+}
+
+// RegenerateMediaRenditionsHandler generates the renditions of an image again
+// @Summary Regenerate image renditions
+// @Description Re-read the dimensions and dominant colour of an image and generate its renditions with the current MEDIA_RENDITIONS settings; cached resizes are cleared
+// @Tags Media
+// @Produce json
+// @Param id path string true "Media file ID"
+// @Success 200 {object} models.Media
+// @Failure 400 {object} map[string]interface{} "Invalid media ID or not an image"
+// @Failure 404 {object} map[string]interface{} "Media file not found"
+// @Failure 500 {object} map[string]interface{} "Failed to process image"
+// @Router /media/{id}/renditions [post]
+func RegenerateMediaRenditionsHandler(c *gin.Context) {
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid media ID"})
+		return
+	}
+
+	media, err := services.RegenerateMediaRenditions(c.Request.Context(), id)
+	if err != nil {
+		respondMediaImageError(c, "Failed to process image", err)
+		return
+	}
+	c.JSON(http.StatusOK, media)
+}
+
+// GetSignedMediaURLHandler returns a temporary URL of a media file
+// @Summary Get a signed media URL
+// @Description Return a time-limited URL of the original file that works without a session (S3 presigned URL; local files are public and the URL does not expire)
+// @Tags Media
+// @Produce json
+// @Param id path string true "Media file ID"
+// @Param expires_in query int false "Validity in seconds (default 3600, max 604800)"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{} "Invalid media ID or expiry"
+// @Failure 404 {object} map[string]interface{} "Media file not found"
+// @Failure 500 {object} map[string]interface{} "Failed to sign URL"
+// @Router /media/{id}/signed-url [get]
+func GetSignedMediaURLHandler(c *gin.Context) {
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid media ID"})
+		return
+	}
+	expires := services.DefaultSignedMediaURLTTL
+	if value := c.Query("expires_in"); value != "" {
+		seconds, err := strconv.Atoi(value)
+		expires = time.Duration(seconds) * time.Second
+		if err != nil || expires <= 0 || expires > services.MaxSignedMediaURLTTL {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "expires_in must be between 1 and 604800 seconds"})
+			return
+		}
+	}
+
+	url, err := services.SignedMediaURL(c.Request.Context(), id, expires)
+	if err != nil {
+		respondMediaImageError(c, "Failed to sign media URL", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"url": url, "expires_at": time.Now().Add(expires)})
+}
+
+// GetMediaTransformURLHandler signs an on-demand resize of an image
+// @Summary Get a signed resize URL
+// @Description Return the signed URL of a resized copy of an image. Parameters: w_<px>, h_<px>, fit_<contain|cover|fill>, f_<jpeg|png|webp>, q_<1-100>
+// @Tags Media
+// @Produce json
+// @Param id path string true "Media file ID"
+// @Param transform query string true "Parameter set (e.g., 'w_800,h_600,fit_cover')"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]interface{} "Invalid media ID or parameters"
+// @Failure 404 {object} map[string]interface{} "Media file not found"
+// @Router /media/{id}/transform-url [get]
+func GetMediaTransformURLHandler(c *gin.Context) {
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid media ID"})
+		return
+	}
+	transform, err := utils.ParseImageTransform(c.Query("transform"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if _, err := services.GetMediaByID(id); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Media not found"})
+		return
+	}
+
+	url := "/media/" + id.Hex() + "/" + transform.String() + "?s=" + services.SignImageTransform(id, transform)
+	c.JSON(http.StatusOK, gin.H{"url": url, "transform": transform.String()})
+}
+
+// ServeMediaTransformHandler serves a resized copy of an image
+// @Summary Resize an image on demand
+// @Description Serve a resized copy of an image for a signed parameter set (e.g., '/media/{id}/w_800,h_600,fit_cover?s=<signature>'). Results are cached on disk
+// @Tags Media
+// @Produce image/jpeg,image/png,image/webp
+// @Param id path string true "Media file ID"
+// @Param transform path string true "Parameter set"
+// @Param s query string true "Signature returned by /media/{id}/transform-url"
+// @Success 200 {file} file
+// @Failure 400 {object} map[string]interface{} "Invalid parameters or not an image"
+// @Failure 403 {object} map[string]interface{} "Invalid signature"
+// @Failure 404 {object} map[string]interface{} "Media file not found"
+// @Router /media/{id}/{transform} [get]
+func ServeMediaTransformHandler(c *gin.Context) {
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Media not found"})
+		return
+	}
+	transform, err := utils.ParseImageTransform(c.Param("transform"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if !services.VerifyImageTransform(id, transform, c.Query("s")) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Invalid signature"})
+		return
+	}
+
+	path, contentType, err := services.TransformMediaImage(c.Request.Context(), id, transform)
+	if err != nil {
+		respondMediaImageError(c, "Failed to resize image", err)
+		return
+	}
+	c.Header("Content-Type", contentType)
+	c.Header("Cache-Control", "public, max-age=31536000, immutable")
+	c.File(path)
+}
+
+// respondMediaImageError maps image processing errors to HTTP responses
+func respondMediaImageError(c *gin.Context, message string, err error) {
+	switch {
+	case err == mongo.ErrNoDocuments:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Media not found"})
+	case errors.Is(err, utils.ErrStorageNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Media file is missing from storage"})
+	case errors.Is(err, services.ErrNotAnImage), errors.Is(err, services.ErrImageTooLarge):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message, "details": err.Error()})
+	}
+}
+
+// respondUploadError returns a rejected upload with its code
+func respondUploadError(c *gin.Context, err *utils.UploadError) {
+	status := http.StatusBadRequest
+	switch err.Code {
+	case utils.UploadErrorTooLarge:
+		status = http.StatusRequestEntityTooLarge
+	case utils.UploadErrorTypeNotAllowed:
+		status = http.StatusUnsupportedMediaType
+	}
+	c.JSON(status, err)
+}
+
 // GetFilteredMediaHandler retrieves media files based on filters
 // @Summary Filter media files
-// @Description Retrieve media files filtered by file name, type, or upload date
+// @Description Retrieve media files filtered by file name, type, upload date, folder, tag, MIME group, dimensions or uploader
 // @Tags Media
 // @Produce json
 // @Param file_name query string false "Filter by file name"
 // @Param file_type query string false "Filter by file type"
+// @Param folder_id query string false "Folder ID, or 'root' for media outside folders"
+// @Param include_subfolders query bool false "Include the media of subfolders of folder_id"
+// @Param tag query []string false "Tags the media must all have" collectionFormat(multi)
+// @Param mime_group query string false "image, video, audio, document or archive"
+// @Param min_width query int false "Minimum image width"
+// @Param max_width query int false "Maximum image width"
+// @Param min_height query int false "Minimum image height"
+// @Param max_height query int false "Maximum image height"
+// @Param uploaded_by query string false "Uploader username"
 // @Param start_date query string false "Start date for upload filter (YYYY-MM-DD)"
 // @Param end_date query string false "End date for upload filter (YYYY-MM-DD)"
-// @Success 200 {array} models.Media "Filtered list of media files"
+// @Param limit query int false "Page size (1-100, default 20)"
+// @Param cursor query string false "Cursor returned as next_cursor by the previous page"
+// @Param sort query string false "Sort field, '-' prefix for descending (e.g., '-created_at')"
+// @Param fields query string false "Comma-separated fields to return (e.g., 'id,title')"
+// @Param total query bool false "Include the total count"
+// @Success 200 {object} models.ListResponse{items=[]models.Media} "Filtered list of media files"
+// @Failure 400 {object} map[string]interface{} "Invalid filter"
 // @Failure 500 {object} map[string]interface{} "Failed to retrieve filtered media files"
 // @Router /media/filter [get]
 func GetFilteredMediaHandler(c *gin.Context) {
+	opts, ok := helpers.BindListOptions(c, "-id")
+	if !ok {
+		return
+	}
+
 	// Sorgu parametrelerini al
 	fileName := c.Query("file_name")
 	fileType := c.Query("file_type")
@@ -186,12 +506,71 @@ func GetFilteredMediaHandler(c *gin.Context) {
 		}
 	}
 
+	if uploadedBy := c.Query("uploaded_by"); uploadedBy != "" {
+		filter["uploadedby"] = uploadedBy
+	}
+	if tags := c.QueryArray("tag"); len(tags) > 0 {
+		for i := range tags {
+			tags[i] = utils.NormalizeMediaTag(tags[i])
+		}
+		filter["tags"] = bson.M{"$all": tags}
+	}
+	if group := c.Query("mime_group"); group != "" {
+		pattern, ok := utils.MediaMimeGroupPattern(group)
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid mime_group", "allowed": utils.MediaMimeGroups()})
+			return
+		}
+		filter["mime_type"] = bson.M{"$regex": pattern}
+	}
+
+	// Boyut aralıkları; boyutu olmayan (görsel olmayan) dosyalar eşleşmez
+	for _, dimension := range []string{"width", "height"} {
+		bounds := bson.M{}
+		for operator, param := range map[string]string{"$gte": "min_" + dimension, "$lte": "max_" + dimension} {
+			value := c.Query(param)
+			if value == "" {
+				continue
+			}
+			size, err := strconv.Atoi(value)
+			if err != nil || size < 0 {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid " + param})
+				return
+			}
+			bounds[operator] = size
+		}
+		if len(bounds) > 0 {
+			filter[dimension] = bounds
+		}
+	}
+
+	// Klasör; "root" klasörsüz medyayı seçer
+	if folder := c.Query("folder_id"); folder == "root" {
+		filter["folder_id"] = nil
+	} else if folder != "" {
+		id, err := primitive.ObjectIDFromHex(folder)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid folder ID"})
+			return
+		}
+		ids, err := services.MediaFolderIDs(c.Request.Context(), id, c.Query("include_subfolders") == "true")
+		if err != nil {
+			if errors.Is(err, services.ErrMediaFolderNotFound) {
+				c.JSON(http.StatusNotFound, gin.H{"error": "Folder not found"})
+				return
+			}
+			helpers.RespondListError(c, "Failed to retrieve media files", err)
+			return
+		}
+		filter["folder_id"] = bson.M{"$in": ids}
+	}
+
 	// Medya dosyalarını getir
-	media, err := services.GetFilteredMedia(filter)
+	media, info, err := services.GetFilteredMedia(filter, opts)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve media files"})
+		helpers.RespondListError(c, "Failed to retrieve media files", err)
 		return
 	}
 
-	c.JSON(http.StatusOK, media)
+	helpers.RespondList(c, media, info, opts)
 }
@@ -0,0 +1,199 @@
+package controllers
+
+import (
+	"admin-panel/helpers"
+	"admin-panel/models"
+	"admin-panel/services"
+	"admin-panel/utils"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// CreateMediaFolderHandler creates a media folder
+// @Summary Create a media folder
+// @Description Create a virtual folder in the media library, at the root or under parent_id. Names must be unique within the parent folder and cannot contain slashes
+// @Tags Media Folders
+// @Accept json
+// @Produce json
+// @Param folder body models.MediaFolder true "Folder name and optional parent_id"
+// @Success 201 {object} models.MediaFolder
+// @Failure 400 {object} map[string]interface{} "Validation errors"
+// @Failure 409 {object} map[string]interface{} "Folder already exists"
+// @Failure 500 {object} map[string]interface{} "Failed to create folder"
+// @Router /media/folders [post]
+func CreateMediaFolderHandler(c *gin.Context) {
+	var folder models.MediaFolder
+	if err := c.ShouldBindJSON(&folder); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	_, username := helpers.CurrentUser(c)
+	folder.CreatedBy = username
+	if err := services.CreateMediaFolder(c.Request.Context(), &folder); err != nil {
+		respondMediaLibraryError(c, "Failed to create folder", err)
+		return
+	}
+	c.JSON(http.StatusCreated, folder)
+}
+
+// GetMediaFoldersHandler lists media folders
+// @Summary List media folders
+// @Description List the media folders ordered by their full path; parent_id limits the list to the direct children of a folder ('root' for top-level folders)
+// @Tags Media Folders
+// @Produce json
+// @Param parent_id query string false "Parent folder ID or 'root'"
+// @Param limit query int false "Page size (1-100, default 20)"
+// @Param cursor query string false "Cursor returned as next_cursor by the previous page"
+// @Param sort query string false "Sort field, '-' prefix for descending (default 'path')"
+// @Param total query bool false "Include the total count"
+// @Success 200 {object} models.ListResponse{items=[]models.MediaFolder}
+// @Failure 400 {object} map[string]interface{} "Invalid parent ID"
+// @Failure 500 {object} map[string]interface{} "Failed to retrieve folders"
+// @Router /media/folders [get]
+func GetMediaFoldersHandler(c *gin.Context) {
+	opts, ok := helpers.BindListOptions(c, "path")
+	if !ok {
+		return
+	}
+
+	filter := bson.M{}
+	if parent := c.Query("parent_id"); parent == "root" {
+		filter["parent_id"] = nil
+	} else if parent != "" {
+		id, err := primitive.ObjectIDFromHex(parent)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid parent ID"})
+			return
+		}
+		filter["parent_id"] = id
+	}
+
+	folders, info, err := services.GetMediaFolders(c.Request.Context(), filter, opts)
+	if err != nil {
+		helpers.RespondListError(c, "Failed to retrieve folders", err)
+		return
+	}
+	helpers.RespondList(c, folders, info, opts)
+}
+
+// RenameMediaFolderHandler renames a media folder
+// @Summary Rename a media folder
+// @Description Change the name of a folder; the paths of its subfolders are updated
+// @Tags Media Folders
+// @Accept json
+// @Produce json
+// @Param id path string true "Folder ID"
+// @Param folder body models.MediaFolderRename true "New name"
+// @Success 200 {object} models.MediaFolder
+// @Failure 400 {object} map[string]interface{} "Invalid folder ID or validation errors"
+// @Failure 404 {object} map[string]interface{} "Folder not found"
+// @Failure 409 {object} map[string]interface{} "Folder already exists"
+// @Failure 500 {object} map[string]interface{} "Failed to rename folder"
+// @Router /media/folders/{id} [put]
+func RenameMediaFolderHandler(c *gin.Context) {
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid folder ID"})
+		return
+	}
+
+	var rename models.MediaFolderRename
+	if err := c.ShouldBindJSON(&rename); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	_, username := helpers.CurrentUser(c)
+	folder, err := services.RenameMediaFolder(c.Request.Context(), id, rename.Name, username)
+	if err != nil {
+		respondMediaLibraryError(c, "Failed to rename folder", err)
+		return
+	}
+	c.JSON(http.StatusOK, folder)
+}
+
+// MoveMediaFolderHandler moves a media folder
+// @Summary Move a media folder
+// @Description Move a folder with its subfolders and media under another folder; parent_id null moves it to the root. A folder cannot be moved into itself or its subfolders
+// @Tags Media Folders
+// @Accept json
+// @Produce json
+// @Param id path string true "Folder ID"
+// @Param move body models.MediaFolderMove true "Target parent folder"
+// @Success 200 {object} models.MediaFolder
+// @Failure 400 {object} map[string]interface{} "Invalid folder ID, parent not found or cyclic move"
+// @Failure 404 {object} map[string]interface{} "Folder not found"
+// @Failure 409 {object} map[string]interface{} "Folder already exists in the target"
+// @Failure 500 {object} map[string]interface{} "Failed to move folder"
+// @Router /media/folders/{id}/move [post]
+func MoveMediaFolderHandler(c *gin.Context) {
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid folder ID"})
+		return
+	}
+
+	var move models.MediaFolderMove
+	if err := c.ShouldBindJSON(&move); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	_, username := helpers.CurrentUser(c)
+	folder, err := services.MoveMediaFolder(c.Request.Context(), id, move.ParentID, username)
+	if err != nil {
+		respondMediaLibraryError(c, "Failed to move folder", err)
+		return
+	}
+	c.JSON(http.StatusOK, folder)
+}
+
+// DeleteMediaFolderHandler deletes an empty media folder
+// @Summary Delete a media folder
+// @Description Delete a folder that has no subfolders and no media; trashed media in it is moved to the root
+// @Tags Media Folders
+// @Produce json
+// @Param id path string true "Folder ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]interface{} "Invalid folder ID"
+// @Failure 404 {object} map[string]interface{} "Folder not found"
+// @Failure 409 {object} map[string]interface{} "Folder is not empty"
+// @Failure 500 {object} map[string]interface{} "Failed to delete folder"
+// @Router /media/folders/{id} [delete]
+func DeleteMediaFolderHandler(c *gin.Context) {
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid folder ID"})
+		return
+	}
+
+	if err := services.DeleteMediaFolder(c.Request.Context(), id); err != nil {
+		respondMediaLibraryError(c, "Failed to delete folder", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Folder deleted successfully"})
+}
+
+func respondMediaLibraryError(c *gin.Context, message string, err error) {
+	var validationErrors utils.ValidationErrors
+	switch {
+	case errors.As(err, &validationErrors):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Validation failed", "errors": validationErrors})
+	case errors.Is(err, services.ErrMediaFolderCycle):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrMediaFolderNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Folder not found"})
+	case errors.Is(err, mongo.ErrNoDocuments):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Media not found"})
+	case errors.Is(err, services.ErrMediaFolderExists), errors.Is(err, services.ErrMediaFolderNotEmpty):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message, "details": err.Error()})
+	}
+}
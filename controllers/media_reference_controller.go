@@ -0,0 +1,80 @@
+package controllers
+
+import (
+	"admin-panel/helpers"
+	"admin-panel/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// GetMediaReferencesHandler lists where a media item is used
+// @Summary List media references
+// @Description Return the posts, pages (including trashed ones), sliders, site settings and user avatars that use a media item, with the field it is used in
+// @Tags Media
+// @Produce json
+// @Param id path string true "Media file ID"
+// @Success 200 {array} models.MediaReference
+// @Failure 400 {object} map[string]interface{} "Invalid media ID"
+// @Failure 500 {object} map[string]interface{} "Failed to retrieve references"
+// @Router /media/{id}/references [get]
+func GetMediaReferencesHandler(c *gin.Context) {
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid media ID"})
+		return
+	}
+
+	references, err := services.GetMediaReferences(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve media references", "details": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, references)
+}
+
+// GetOrphanedMediaHandler lists the media items that are not used anywhere
+// @Summary List orphaned media
+// @Description Return the media items no post, page, slider, site setting or user avatar refers to
+// @Tags Media
+// @Produce json
+// @Param limit query int false "Page size (1-100, default 20)"
+// @Param cursor query string false "Cursor returned as next_cursor by the previous page"
+// @Param sort query string false "Sort field, '-' prefix for descending (e.g., '-file_size')"
+// @Param fields query string false "Comma-separated fields to return (e.g., 'id,file_name')"
+// @Param total query bool false "Include the total count"
+// @Success 200 {object} models.ListResponse{items=[]models.Media} "Orphaned media files"
+// @Failure 500 {object} map[string]interface{} "Failed to retrieve orphaned media"
+// @Router /media/orphans [get]
+func GetOrphanedMediaHandler(c *gin.Context) {
+	opts, ok := helpers.BindListOptions(c, "-id")
+	if !ok {
+		return
+	}
+
+	media, info, err := services.GetOrphanedMedia(c.Request.Context(), opts)
+	if err != nil {
+		helpers.RespondListError(c, "Failed to retrieve orphaned media", err)
+		return
+	}
+
+	helpers.RespondList(c, media, info, opts)
+}
+
+// RebuildMediaReferencesHandler rebuilds the media reference index
+// @Summary Rebuild media references
+// @Description Scan all posts, pages, sliders, site settings and users again and add the missing content hashes of media uploaded before deduplication
+// @Tags Media
+// @Produce json
+// @Success 200 {object} models.MediaReferenceRebuildResult
+// @Failure 500 {object} map[string]interface{} "Failed to rebuild references"
+// @Router /media/references/rebuild [post]
+func RebuildMediaReferencesHandler(c *gin.Context) {
+	result, err := services.RebuildMediaReferences(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to rebuild media references", "details": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
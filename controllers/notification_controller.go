@@ -1,6 +1,7 @@
 package controllers
 
 import (
+	"admin-panel/helpers"
 	"admin-panel/services"
 	"net/http"
 
@@ -34,12 +35,17 @@ func GetNotificationsHandler(c *gin.Context) {
 		return
 	}
 
+	opts, ok := helpers.BindListOptions(c, "-id")
+	if !ok {
+		return
+	}
+
 	// Bildirimleri getir
-	notifications, err := services.FetchNotificationsByUserID(c.Request.Context(), userObjectID)
+	notifications, info, err := services.FetchNotificationsByUserID(c.Request.Context(), userObjectID, opts)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch notifications", "details": err.Error()})
+		helpers.RespondListError(c, "Failed to fetch notifications", err)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"notifications": notifications})
+	helpers.RespondList(c, notifications, info, opts)
 }
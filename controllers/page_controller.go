@@ -1,9 +1,11 @@
 package controllers
 
 import (
+	"admin-panel/helpers"
 	"admin-panel/models"
 	"admin-panel/services"
 	"admin-panel/utils"
+	"log"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -30,13 +32,38 @@ func CreatePageHandler(c *gin.Context) {
 		return
 	}
 
-	_, err := services.CreatePage(page)
+	// Varsayılan durum
+	if page.Status == "" {
+		page.Status = "draft"
+	}
+
+	// Yeni kayıt taslaktan başlar; diğer durumlar iş akışı geçişleriyle atanır
+	allowed, err := canSetStatus(c, "pages", "draft", page.Status)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check permissions", "details": err.Error()})
+		return
+	}
+	if !allowed {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Status can only be changed through the workflow"})
+		return
+	}
+
+	authorID, username := helpers.CurrentUser(c)
+	page.AuthorID = authorID
+	page.CreatedBy = username
+	page.UpdatedBy = username
+
+	_, report, err := services.CreatePage(page)
 	if err != nil {
+		if isLocalizationError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid localizations", "details": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create page"})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Page created successfully"})
+	c.JSON(http.StatusOK, withSanitizeReport(gin.H{"message": "Page created successfully"}, report))
 }
 
 // GetAllPagesHandler retrieves all pages
@@ -44,17 +71,27 @@ func CreatePageHandler(c *gin.Context) {
 // @Description Retrieve all pages with their details
 // @Tags Pages
 // @Produce json
-// @Success 200 {array} models.Page
+// @Param limit query int false "Page size (1-100, default 20)"
+// @Param cursor query string false "Cursor returned as next_cursor by the previous page"
+// @Param sort query string false "Sort field, '-' prefix for descending (e.g., '-created_at')"
+// @Param fields query string false "Comma-separated fields to return (e.g., 'id,title')"
+// @Param total query bool false "Include the total count"
+// @Success 200 {object} models.ListResponse{items=[]models.Page}
 // @Failure 500 {object} map[string]string
 // @Router /pages [get]
 func GetAllPagesHandler(c *gin.Context) {
-	pages, err := services.GetAllPages()
+	opts, ok := helpers.BindListOptions(c, "-id")
+	if !ok {
+		return
+	}
+
+	pages, info, err := services.GetAllPages(opts)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve pages"})
+		helpers.RespondListError(c, "Failed to retrieve pages", err)
 		return
 	}
 
-	c.JSON(http.StatusOK, pages)
+	helpers.RespondList(c, pages, info, opts)
 }
 
 // GetPageByIDHandler retrieves a page by ID
@@ -121,18 +158,54 @@ func UpdatePageHandler(c *gin.Context) {
 		update["slug"] = utils.GenerateSlug(title)
 	}
 
-	_, err = services.UpdatePage(id, update)
+	// Revizyon için güncelleme öncesi hali al
+	previous, err := services.GetPageByID(c.Request.Context(), id)
 	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Page not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch page", "details": err.Error()})
+		return
+	}
+
+	// Durum yalnızca iş akışında izin verilen bir geçişle değişebilir
+	if status, ok := update["status"].(string); ok && status != previous.Status {
+		allowed, err := canSetStatus(c, "pages", previous.Status, status)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check permissions", "details": err.Error()})
+			return
+		}
+		if !allowed {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Status can only be changed through the workflow"})
+			return
+		}
+	}
+
+	_, report, err := services.UpdatePage(id, update)
+	if err != nil {
+		if isLocalizationError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid localizations", "details": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update page"})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Page updated successfully"})
+	// Revizyon kaydı oluştur
+	if current, err := services.GetPageByID(c.Request.Context(), id); err == nil {
+		userID, username := helpers.CurrentUser(c)
+		if _, err := services.SaveRevision(c.Request.Context(), "pages", id, previous, current, userID, username, "update"); err != nil {
+			log.Printf("Failed to save page revision: %v", err)
+		}
+	}
+
+	c.JSON(http.StatusOK, withSanitizeReport(gin.H{"message": "Page updated successfully"}, report))
 }
 
 // DeletePageHandler deletes a page by ID
 // @Summary Delete a page
-// @Description Remove a page by its unique identifier
+// @Description Move a page to the trash; it can be restored from /admin/trash/pages
 // @Tags Pages
 // @Param id path string true "Page ID"
 // @Success 204 "No Content"
@@ -147,8 +220,12 @@ func DeletePageHandler(c *gin.Context) {
 		return
 	}
 
-	_, err = services.DeletePage(id)
-	if err != nil {
+	_, username := helpers.CurrentUser(c)
+	if err := services.DeletePage(id, username); err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Page not found"})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete page"})
 		return
 	}
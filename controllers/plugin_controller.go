@@ -1,6 +1,7 @@
 package controllers
 
 import (
+	"admin-panel/helpers"
 	"admin-panel/models"
 	"admin-panel/services"
 	"log"
@@ -42,17 +43,27 @@ func CreatePluginHandler(c *gin.Context) {
 // @Description Retrieve all plugins available in the system
 // @Tags Plugins
 // @Produce json
-// @Success 200 {array} models.Plugin "List of plugins"
+// @Param limit query int false "Page size (1-100, default 20)"
+// @Param cursor query string false "Cursor returned as next_cursor by the previous page"
+// @Param sort query string false "Sort field, '-' prefix for descending (e.g., '-created_at')"
+// @Param fields query string false "Comma-separated fields to return (e.g., 'id,title')"
+// @Param total query bool false "Include the total count"
+// @Success 200 {object} models.ListResponse{items=[]models.Plugin} "List of plugins"
 // @Failure 500 {object} map[string]interface{} "Failed to retrieve plugins"
 // @Router /plugins [get]
 func GetAllPluginsHandler(c *gin.Context) {
-	plugins, err := services.GetAllPlugins()
+	opts, ok := helpers.BindListOptions(c, "-id")
+	if !ok {
+		return
+	}
+
+	plugins, info, err := services.GetAllPlugins(opts)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve plugins"})
+		helpers.RespondListError(c, "Failed to retrieve plugins", err)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"plugins": plugins})
+	helpers.RespondList(c, plugins, info, opts)
 }
 
 // UpdatePluginHandler updates an existing plugin
@@ -5,6 +5,7 @@ import (
 	"admin-panel/models"
 	"admin-panel/services"
 	"admin-panel/utils"
+	"log"
 	"net/http"
 	"time"
 
@@ -33,6 +34,7 @@ func CreatePostHandler(c *gin.Context) {
 		CategoryIDs   []primitive.ObjectID             `json:"category_ids"`
 		TagIDs        []primitive.ObjectID             `json:"tag_ids"`
 		PublishDate   *time.Time                       `json:"publish_date"`
+		UnpublishDate *time.Time                       `json:"unpublish_date"`
 		MetaTags      map[string]models.MetaTag        `json:"meta_tags"`
 	}
 
@@ -41,19 +43,24 @@ func CreatePostHandler(c *gin.Context) {
 		return
 	}
 
-	// Slug oluşturma
-	for lang, localization := range input.Localizations {
-		if localization.Slug == "" {
-			localization.Slug = utils.GenerateSlug(localization.Title)
-		}
-		input.Localizations[lang] = localization
-	}
-
 	// Varsayılan durum
 	if input.Status == "" {
 		input.Status = "draft"
 	}
 
+	// Yeni kayıt taslaktan başlar; diğer durumlar iş akışı geçişleriyle atanır
+	allowed, err := canSetStatus(c, "posts", "draft", input.Status)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check permissions", "details": err.Error()})
+		return
+	}
+	if !allowed {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Status can only be changed through the workflow"})
+		return
+	}
+
+	authorID, username := helpers.CurrentUser(c)
+
 	// Yeni Post oluşturma
 	post := models.Post{
 		ID:            primitive.NewObjectID(),
@@ -62,33 +69,53 @@ func CreatePostHandler(c *gin.Context) {
 		CategoryIDs:   input.CategoryIDs,
 		TagIDs:        input.TagIDs,
 		PublishDate:   input.PublishDate,
+		UnpublishDate: input.UnpublishDate,
+		AuthorID:      authorID,
 		MetaTags:      input.MetaTags,
 		CreatedAt:     time.Now(),
 		UpdatedAt:     time.Now(),
+		CreatedBy:     username,
+		UpdatedBy:     username,
 	}
 
 	// Veritabanına kaydet
-	if err := services.CreatePost(c.Request.Context(), &post); err != nil {
+	report, err := services.CreatePost(c.Request.Context(), &post)
+	if err != nil {
+		if isLocalizationError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid localizations", "details": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create post", "details": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Post created successfully", "post": post})
+	c.JSON(http.StatusOK, withSanitizeReport(gin.H{"message": "Post created successfully", "post": post}, report))
 }
 
 // @Summary Get all posts
 // @Description Retrieve all posts with their details
 // @Tags Posts
 // @Produce json
-// @Success 200 {array} models.Post
+// @Param limit query int false "Page size (1-100, default 20)"
+// @Param cursor query string false "Cursor returned as next_cursor by the previous page"
+// @Param sort query string false "Sort field, '-' prefix for descending (e.g., '-created_at')"
+// @Param fields query string false "Comma-separated fields to return (e.g., 'id,title')"
+// @Param total query bool false "Include the total count"
+// @Success 200 {object} models.ListResponse{items=[]models.Post}
+// @Failure 400 {object} map[string]string
 // @Router /posts [get]
 func GetAllPostsHandler(c *gin.Context) {
-	posts, err := services.GetAllPosts(c.Request.Context())
+	opts, ok := helpers.BindListOptions(c, "-created_at")
+	if !ok {
+		return
+	}
+
+	posts, info, err := services.GetAllPosts(c.Request.Context(), opts)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve posts"})
+		helpers.RespondListError(c, "Failed to retrieve posts", err)
 		return
 	}
-	c.JSON(http.StatusOK, posts)
+	helpers.RespondList(c, posts, info, opts)
 }
 
 // GetPostByIDHandler retrieves a post by its ID
@@ -105,7 +132,7 @@ func GetAllPostsHandler(c *gin.Context) {
 // @Router /posts/{id} [get]
 func GetPostByIDHandler(c *gin.Context) {
 	id := c.Param("id")
-	lang := c.DefaultQuery("lang", "en")
+	lang := c.DefaultQuery("lang", services.DefaultLanguage())
 
 	objectID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
@@ -119,20 +146,25 @@ func GetPostByIDHandler(c *gin.Context) {
 		return
 	}
 
-	localizedContent, exists := post.Localizations[lang]
-	if !exists {
-		localizedContent = post.Localizations["en"] // Varsayılan dil
-	}
+	// Çeviri yoksa dil zincirindeki ilk çeviri kullanılır (örn: de-AT -> de -> en)
+	localizedContent, resolvedLang, _ := services.ResolveTranslation(post.Localizations, lang)
+	excerpt, readingTime := services.ContentSummary(localizedContent)
 
 	c.JSON(http.StatusOK, gin.H{
-		"id":         post.ID.Hex(),
-		"slug":       localizedContent.Slug,
-		"title":      localizedContent.Title,
-		"content":    localizedContent.Content,
-		"status":     post.Status,
-		"categories": post.CategoryIDs,
-		"tags":       post.TagIDs,
-		"meta_tags":  post.MetaTags[lang],
+		"id":                 post.ID.Hex(),
+		"lang":               resolvedLang,
+		"slug":               localizedContent.Slug,
+		"title":              localizedContent.Title,
+		"content":            localizedContent.Content,
+		"format":             contentFormat(localizedContent),
+		"markdown":           localizedContent.Markdown,
+		"excerpt":            excerpt,
+		"reading_time":       readingTime,
+		"status":             post.Status,
+		"categories":         post.CategoryIDs,
+		"tags":               post.TagIDs,
+		"meta_tags":          post.MetaTags[resolvedLang],
+		"translation_status": utils.TranslationStatus(post.TranslationMeta, post.Localizations, services.DefaultLanguage(), lang),
 	})
 }
 
@@ -151,23 +183,8 @@ func GetPostByIDHandler(c *gin.Context) {
 // @Router /posts/{id} [put]
 func UpdatePostHandler(c *gin.Context) {
 	id := c.Param("id")
-	role, exists := c.Get("role")
-	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
-		return
-	}
-
-	hasPermission, err := helpers.HasModulePermission(c.Request.Context(), role.(string), "posts", "update")
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check permissions", "details": err.Error()})
-		return
-	}
-
-	if !hasPermission {
-		c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to update posts"})
-		return
-	}
 
+	// Yetki kontrolü route seviyesinde ModulePermissionMiddleware("posts", "update") ile yapılır
 	objectID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid post ID"})
@@ -180,6 +197,7 @@ func UpdatePostHandler(c *gin.Context) {
 		CategoryIDs   []primitive.ObjectID             `json:"category_ids"`
 		TagIDs        []primitive.ObjectID             `json:"tag_ids"`
 		PublishDate   *time.Time                       `json:"publish_date"`
+		UnpublishDate *time.Time                       `json:"unpublish_date"`
 		MetaTags      map[string]models.MetaTag        `json:"meta_tags"`
 	}
 
@@ -195,16 +213,31 @@ func UpdatePostHandler(c *gin.Context) {
 		return
 	}
 
+	// Revizyon için güncelleme öncesi hali sakla
+	previous, err := services.ToDocument(post)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to snapshot post", "details": err.Error()})
+		return
+	}
+
 	// Alanları güncelle
 	if input.Localizations != nil {
+		// Boş slug'lar servis tarafından başlıktan üretilir
 		for lang, localization := range input.Localizations {
-			if localization.Slug == "" {
-				localization.Slug = utils.GenerateSlug(localization.Title)
-			}
 			post.Localizations[lang] = localization
 		}
 	}
-	if input.Status != "" {
+	if input.Status != "" && input.Status != post.Status {
+		// Durum yalnızca iş akışında izin verilen bir geçişle değişebilir
+		allowed, err := canSetStatus(c, "posts", post.Status, input.Status)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check permissions", "details": err.Error()})
+			return
+		}
+		if !allowed {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Status can only be changed through the workflow"})
+			return
+		}
 		post.Status = input.Status
 	}
 	if input.CategoryIDs != nil {
@@ -216,6 +249,9 @@ func UpdatePostHandler(c *gin.Context) {
 	if input.PublishDate != nil {
 		post.PublishDate = input.PublishDate
 	}
+	if input.UnpublishDate != nil {
+		post.UnpublishDate = input.UnpublishDate
+	}
 	if input.MetaTags != nil {
 		post.MetaTags = input.MetaTags
 	}
@@ -223,12 +259,23 @@ func UpdatePostHandler(c *gin.Context) {
 	post.UpdatedAt = time.Now()
 
 	// Veritabanında güncelle
-	if err := services.UpdatePost(c.Request.Context(), post); err != nil {
+	report, err := services.UpdatePost(c.Request.Context(), post)
+	if err != nil {
+		if isLocalizationError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid localizations", "details": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update post", "details": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Post updated successfully", "post": post})
+	// Revizyon kaydı oluştur
+	userID, username := helpers.CurrentUser(c)
+	if _, err := services.SaveRevision(c.Request.Context(), "posts", post.ID, previous, post, userID, username, "update"); err != nil {
+		log.Printf("Failed to save post revision: %v", err)
+	}
+
+	c.JSON(http.StatusOK, withSanitizeReport(gin.H{"message": "Post updated successfully", "post": post}, report))
 }
 
 // GetFilteredPostsHandler retrieves posts based on filters
@@ -239,32 +286,53 @@ func UpdatePostHandler(c *gin.Context) {
 // @Param category query string false "Category ID to filter posts"
 // @Param tag query string false "Tag ID to filter posts"
 // @Param status query string false "Status to filter posts (e.g., 'draft', 'published')"
-// @Success 200 {array} models.Post
+// @Param limit query int false "Page size (1-100, default 20)"
+// @Param cursor query string false "Cursor returned as next_cursor by the previous page"
+// @Param sort query string false "Sort field, '-' prefix for descending (e.g., '-created_at')"
+// @Param fields query string false "Comma-separated fields to return (e.g., 'id,title')"
+// @Param total query bool false "Include the total count"
+// @Success 200 {object} models.ListResponse{items=[]models.Post}
+// @Failure 400 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /posts/filter [get]
 func GetFilteredPostsHandler(c *gin.Context) {
+	opts, ok := helpers.BindListOptions(c, "-created_at")
+	if !ok {
+		return
+	}
+
 	category := c.Query("category")
 	tag := c.Query("tag")
 	status := c.Query("status")
 
 	filter := bson.M{}
 	if category != "" {
-		filter["categories"] = category
+		categoryID, err := primitive.ObjectIDFromHex(category)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid category ID"})
+			return
+		}
+		filter["category_ids"] = categoryID
 	}
 	if tag != "" {
-		filter["tags"] = tag
+		tagID, err := primitive.ObjectIDFromHex(tag)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tag ID"})
+			return
+		}
+		filter["tag_ids"] = tagID
 	}
 	if status != "" {
 		filter["status"] = status
 	}
 
-	posts, err := services.GetFilteredPosts(c.Request.Context(), filter)
+	posts, info, err := services.GetFilteredPosts(c.Request.Context(), filter, opts)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve filtered posts", "details": err.Error()})
+		helpers.RespondListError(c, "Failed to retrieve filtered posts", err)
 		return
 	}
 
-	c.JSON(http.StatusOK, posts)
+	helpers.RespondList(c, posts, info, opts)
 }
 
 // GetPostsByLanguageHandler retrieves posts in a specific language
@@ -273,41 +341,56 @@ func GetFilteredPostsHandler(c *gin.Context) {
 // @Tags Posts
 // @Produce json
 // @Param lang path string true "Language code (e.g., 'en', 'tr')"
-// @Success 200 {array} models.Post
+// @Param limit query int false "Page size (1-100, default 20)"
+// @Param cursor query string false "Cursor returned as next_cursor by the previous page"
+// @Param sort query string false "Sort field, '-' prefix for descending (e.g., '-created_at')"
+// @Param total query bool false "Include the total count"
+// @Success 200 {object} models.ListResponse
 // @Failure 404 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /posts/lang/{lang} [get]
 func GetPostsByLanguageHandler(c *gin.Context) {
 	lang := c.Param("lang")
 
-	posts, err := services.GetAllPosts(c.Request.Context())
+	opts, ok := helpers.BindListOptions(c, "-created_at")
+	if !ok {
+		return
+	}
+	opts.Fields = nil // Yanıt yerelleştirilmiş alanlardan oluşur, projeksiyon desteklenmez
+
+	filter := bson.M{"localizations." + lang: bson.M{"$exists": true}}
+	posts, info, err := services.GetFilteredPosts(c.Request.Context(), filter, opts)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve posts", "details": err.Error()})
+		helpers.RespondListError(c, "Failed to retrieve posts", err)
 		return
 	}
 
 	localizedPosts := []map[string]interface{}{}
 	for _, post := range posts {
 		if localization, ok := post.Localizations[lang]; ok {
+			excerpt, readingTime := services.ContentSummary(localization)
 			localizedPosts = append(localizedPosts, map[string]interface{}{
-				"id":         post.ID.Hex(),
-				"slug":       localization.Slug,
-				"title":      localization.Title,
-				"content":    localization.Content,
-				"status":     post.Status,
-				"categories": post.CategoryIDs,
-				"tags":       post.TagIDs,
-				"meta_tags":  post.MetaTags[lang],
+				"id":           post.ID.Hex(),
+				"slug":         localization.Slug,
+				"title":        localization.Title,
+				"content":      localization.Content,
+				"format":       contentFormat(localization),
+				"excerpt":      excerpt,
+				"reading_time": readingTime,
+				"status":       post.Status,
+				"categories":   post.CategoryIDs,
+				"tags":         post.TagIDs,
+				"meta_tags":    post.MetaTags[lang],
 			})
 		}
 	}
 
-	if len(localizedPosts) == 0 {
+	if len(localizedPosts) == 0 && opts.Cursor == "" {
 		c.JSON(http.StatusNotFound, gin.H{"message": "No posts found for the specified language"})
 		return
 	}
 
-	c.JSON(http.StatusOK, localizedPosts)
+	helpers.RespondList(c, localizedPosts, info, opts)
 }
 
 func GetPostByLangAndSlugHandler(c *gin.Context) {
@@ -326,7 +409,7 @@ func GetPostByLangAndSlugHandler(c *gin.Context) {
 
 // DeletePostHandler deletes a post by its ID
 // @Summary Delete a post
-// @Description Remove a post permanently by its unique identifier
+// @Description Move a post to the trash; it can be restored from /admin/trash/posts
 // @Tags Posts
 // @Param id path string true "Post ID"
 // @Success 204 "No Content"
@@ -344,8 +427,9 @@ func DeletePostHandler(c *gin.Context) {
 		return
 	}
 
-	// Call the service to delete the post
-	err = services.DeletePost(c.Request.Context(), objectID)
+	// Çöp kutusuna taşı
+	_, username := helpers.CurrentUser(c)
+	err = services.DeletePost(c.Request.Context(), objectID, username)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Post not found"})
@@ -0,0 +1,172 @@
+package controllers
+
+import (
+	"admin-panel/helpers"
+	"admin-panel/models"
+	"admin-panel/services"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// CreatePreviewHandler creates a signed preview link for a post or page
+// @Summary Create a preview link
+// @Description Create a signed, expiring link that shows the draft (or a revision) of a post or page in one language without an admin account
+// @Tags Previews
+// @Accept json
+// @Produce json
+// @Param preview body models.PreviewRequest true "Document, language and optional revision"
+// @Success 201 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /admin/previews [post]
+func CreatePreviewHandler(c *gin.Context) {
+	var request models.PreviewRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if request.ExpiresIn < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "expires_in must be positive"})
+		return
+	}
+
+	_, username := helpers.CurrentUser(c)
+	token, preview, err := services.CreatePreviewToken(c.Request.Context(), request, username)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrUnsupportedPreview), errors.Is(err, services.ErrPreviewLanguage):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case err == mongo.ErrNoDocuments:
+			c.JSON(http.StatusNotFound, gin.H{"error": "Document or revision not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create preview link", "details": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"token":   token,
+		"url":     "/api/public/v1/preview?token=" + url.QueryEscape(token),
+		"preview": preview,
+	})
+}
+
+// GetPreviewsHandler lists the preview links of a post or page
+// @Summary List preview links
+// @Description List the preview links created for a post or page, including revoked and expired ones
+// @Tags Previews
+// @Produce json
+// @Param type path string true "Entity type (posts, pages)"
+// @Param id path string true "Document ID"
+// @Success 200 {array} models.PreviewToken
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /admin/previews/{type}/{id} [get]
+func GetPreviewsHandler(c *gin.Context) {
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid document ID"})
+		return
+	}
+
+	previews, err := services.GetPreviewTokens(c.Request.Context(), c.Param("type"), id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve preview links", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": previews})
+}
+
+// RevokePreviewHandler revokes a preview link
+// @Summary Revoke a preview link
+// @Description Invalidate a preview link before it expires
+// @Tags Previews
+// @Produce json
+// @Param id path string true "Preview link ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /admin/previews/{id} [delete]
+func RevokePreviewHandler(c *gin.Context) {
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid preview link ID"})
+		return
+	}
+
+	_, username := helpers.CurrentUser(c)
+	if err := services.RevokePreviewToken(c.Request.Context(), id, username); err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Preview link not found or already revoked"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to revoke preview link", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Preview link revoked successfully"})
+}
+
+// GetPublicPreviewHandler renders draft content for a preview token
+// @Summary Preview draft content
+// @Description Render the draft or revision a preview token is scoped to; the token is passed as query parameter or X-Preview-Token header
+// @Tags Public
+// @Produce json
+// @Param token query string false "Preview token"
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/public/v1/preview [get]
+func GetPublicPreviewHandler(c *gin.Context) {
+	// Önizlemeler önbelleğe alınmamalı ve indekslenmemeli
+	c.Header("Cache-Control", "no-store")
+	c.Header("X-Robots-Tag", "noindex, nofollow")
+
+	token := c.Query("token")
+	if token == "" {
+		token = c.GetHeader("X-Preview-Token")
+	}
+	if token == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Preview token is required"})
+		return
+	}
+
+	preview, err := services.ResolvePreviewToken(c.Request.Context(), token)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidPreviewToken) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to verify preview token", "details": err.Error()})
+		return
+	}
+
+	content, status, err := services.GetPreviewContent(c.Request.Context(), preview)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Content not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch content", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"type":        strings.TrimSuffix(preview.EntityType, "s"),
+		"preview":     true,
+		"status":      status,
+		"revision_id": preview.RevisionID,
+		"expires_at":  preview.ExpiresAt,
+		"data":        content,
+	})
+}
@@ -0,0 +1,271 @@
+package controllers
+
+import (
+	"admin-panel/helpers"
+	"admin-panel/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// GetPublicPostHandler retrieves a published post by language and slug
+// @Summary Get a published post
+// @Description Retrieve a published post by language and slug with its resolved SEO metadata (seo); follows the language fallback chain when the translation is missing
+// @Tags Public
+// @Produce json
+// @Param lang path string true "Language code (e.g., 'en', 'tr')"
+// @Param slug path string true "Post slug"
+// @Success 200 {object} models.PublicContent
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/public/v1/posts/{lang}/{slug} [get]
+func GetPublicPostHandler(c *gin.Context) {
+	lang := c.Param("lang")
+
+	post, err := services.GetPublishedPostByLangAndSlug(c.Request.Context(), lang, c.Param("slug"))
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Post not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch post", "details": err.Error()})
+		return
+	}
+
+	seo, err := services.ResolvePostSEO(c.Request.Context(), post, lang, publicSiteURL(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to resolve SEO metadata", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": services.PublicPost(post, lang), "seo": seo})
+}
+
+// GetPublicPostsHandler lists published posts
+// @Summary List published posts
+// @Description Retrieve published posts in a language, optionally filtered by category or tag
+// @Tags Public
+// @Produce json
+// @Param lang query string false "Language code (defaults to the site default language)"
+// @Param category query string false "Category ID"
+// @Param tag query string false "Tag ID"
+// @Param limit query int false "Page size (1-100, default 20)"
+// @Param cursor query string false "Cursor returned as next_cursor by the previous page"
+// @Param sort query string false "Sort field, '-' prefix for descending (default '-publish_date')"
+// @Param total query bool false "Include the total count"
+// @Success 200 {object} models.ListResponse{items=[]models.PublicContent}
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/public/v1/posts [get]
+func GetPublicPostsHandler(c *gin.Context) {
+	lang := c.DefaultQuery("lang", services.DefaultLanguage())
+
+	opts, ok := helpers.BindListOptions(c, "-publish_date")
+	if !ok {
+		return
+	}
+	opts.Fields = nil // Yanıt dile göre çözümlenmiş alanlardan oluşur
+
+	filter := bson.M{}
+	for param, field := range map[string]string{"category": "category_ids", "tag": "tag_ids"} {
+		if value := c.Query(param); value != "" {
+			id, err := primitive.ObjectIDFromHex(value)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid " + param + " ID"})
+				return
+			}
+			filter[field] = id
+		}
+	}
+
+	posts, info, err := services.GetPublishedPosts(c.Request.Context(), lang, filter, opts)
+	if err != nil {
+		helpers.RespondListError(c, "Failed to retrieve posts", err)
+		return
+	}
+
+	helpers.RespondList(c, posts, info, opts)
+}
+
+// GetPublicPageHandler retrieves a published page by language and slug
+// @Summary Get a published page
+// @Description Retrieve a published page by language and slug with its resolved SEO metadata (seo); follows the language fallback chain when the translation is missing
+// @Tags Public
+// @Produce json
+// @Param lang path string true "Language code (e.g., 'en', 'tr')"
+// @Param slug path string true "Page slug"
+// @Success 200 {object} models.PublicContent
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/public/v1/pages/{lang}/{slug} [get]
+func GetPublicPageHandler(c *gin.Context) {
+	lang := c.Param("lang")
+
+	page, err := services.GetPublishedPageByLangAndSlug(c.Request.Context(), lang, c.Param("slug"))
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Page not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch page", "details": err.Error()})
+		return
+	}
+
+	seo, err := services.ResolvePageSEO(c.Request.Context(), page, lang, publicSiteURL(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to resolve SEO metadata", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": services.PublicPage(page, lang), "seo": seo})
+}
+
+// GetPublicCategoriesHandler lists categories
+// @Summary List categories
+// @Description Retrieve categories resolved to a language
+// @Tags Public
+// @Produce json
+// @Param lang query string false "Language code (defaults to the site default language)"
+// @Param limit query int false "Page size (1-100, default 20)"
+// @Param cursor query string false "Cursor returned as next_cursor by the previous page"
+// @Param total query bool false "Include the total count"
+// @Success 200 {object} models.ListResponse{items=[]models.PublicCategory}
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/public/v1/categories [get]
+func GetPublicCategoriesHandler(c *gin.Context) {
+	lang := c.DefaultQuery("lang", services.DefaultLanguage())
+
+	opts, ok := helpers.BindListOptions(c, "id")
+	if !ok {
+		return
+	}
+	opts.Fields = nil
+
+	categories, info, err := services.GetPublicCategories(c.Request.Context(), lang, opts)
+	if err != nil {
+		helpers.RespondListError(c, "Failed to retrieve categories", err)
+		return
+	}
+
+	helpers.RespondList(c, categories, info, opts)
+}
+
+// GetPublicCategoryHandler retrieves a category by language and slug
+// @Summary Get a category
+// @Description Retrieve a category by language and slug with its resolved SEO metadata (seo); follows the language fallback chain when the translation is missing
+// @Tags Public
+// @Produce json
+// @Param lang path string true "Language code (e.g., 'en', 'tr')"
+// @Param slug path string true "Category slug"
+// @Success 200 {object} map[string]interface{}
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/public/v1/categories/{lang}/{slug} [get]
+func GetPublicCategoryHandler(c *gin.Context) {
+	lang := c.Param("lang")
+
+	category, err := services.GetCategoryByLangAndSlug(c.Request.Context(), lang, c.Param("slug"))
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Category not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch category", "details": err.Error()})
+		return
+	}
+
+	seo, err := services.ResolveCategorySEO(c.Request.Context(), category, lang, publicSiteURL(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to resolve SEO metadata", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": services.PublicCategory(category, lang), "seo": seo})
+}
+
+// GetPublicTagsHandler lists tags
+// @Summary List tags
+// @Description Retrieve all tags
+// @Tags Public
+// @Produce json
+// @Param limit query int false "Page size (1-100, default 20)"
+// @Param cursor query string false "Cursor returned as next_cursor by the previous page"
+// @Param sort query string false "Sort field, '-' prefix for descending (e.g., 'name')"
+// @Param total query bool false "Include the total count"
+// @Success 200 {object} models.ListResponse{items=[]models.Tag}
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/public/v1/tags [get]
+func GetPublicTagsHandler(c *gin.Context) {
+	opts, ok := helpers.BindListOptions(c, "name")
+	if !ok {
+		return
+	}
+
+	tags, info, err := services.GetAllTags(opts)
+	if err != nil {
+		helpers.RespondListError(c, "Failed to retrieve tags", err)
+		return
+	}
+
+	helpers.RespondList(c, tags, info, opts)
+}
+
+// GetPublicMenusHandler lists the frontend menus
+// @Summary List frontend menus
+// @Description Retrieve visible menus of type "frontend" available to all visitors
+// @Tags Public
+// @Produce json
+// @Success 200 {array} models.PublicMenuItem
+// @Failure 500 {object} map[string]string
+// @Router /api/public/v1/menus [get]
+func GetPublicMenusHandler(c *gin.Context) {
+	menus, err := services.GetPublicMenus(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch menus", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": menus})
+}
+
+// GetPublicSlidersHandler lists the active sliders
+// @Summary List active sliders
+// @Description Retrieve the active sliders
+// @Tags Public
+// @Produce json
+// @Success 200 {array} models.PublicSlider
+// @Failure 500 {object} map[string]string
+// @Router /api/public/v1/sliders [get]
+func GetPublicSlidersHandler(c *gin.Context) {
+	sliders, err := services.GetActiveSliders(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve sliders", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": sliders})
+}
+
+// GetPublicSettingsHandler retrieves the public site settings
+// @Summary Get public settings
+// @Description Retrieve the visitor-safe site settings resolved to a language
+// @Tags Public
+// @Produce json
+// @Param lang query string false "Language code (defaults to the site default language)"
+// @Success 200 {object} models.PublicSettings
+// @Failure 500 {object} map[string]string
+// @Router /api/public/v1/settings [get]
+func GetPublicSettingsHandler(c *gin.Context) {
+	settings, err := services.GetPublicSettings(c.Query("lang"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve settings", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": settings})
+}
@@ -0,0 +1,287 @@
+package controllers
+
+import (
+	"admin-panel/helpers"
+	"admin-panel/models"
+	"admin-panel/services"
+	"admin-panel/utils"
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// CSV içe aktarımında kabul edilen azami dosya boyutu
+const maxRedirectImportSize = 5 << 20
+
+// CreateRedirectRuleHandler creates a redirect rule
+// @Summary Create a redirect rule
+// @Description Add an exact, prefix or regex redirect with a 301, 302 or 410 response, optionally limited to one language
+// @Tags Redirects
+// @Accept json
+// @Produce json
+// @Param rule body models.RedirectRule true "Redirect rule"
+// @Success 201 {object} models.RedirectRule
+// @Failure 400 {object} map[string]interface{}
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /admin/redirects [post]
+func CreateRedirectRuleHandler(c *gin.Context) {
+	var rule models.RedirectRule
+	if err := c.ShouldBindJSON(&rule); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	_, username := helpers.CurrentUser(c)
+	rule.CreatedBy = username
+	rule.UpdatedBy = username
+	if err := services.CreateRedirectRule(c.Request.Context(), &rule); err != nil {
+		respondRedirectError(c, "Failed to create redirect rule", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, rule)
+}
+
+// GetRedirectRulesHandler lists redirect rules
+// @Summary List redirect rules
+// @Description List the managed redirect rules with their hit counts
+// @Tags Redirects
+// @Produce json
+// @Param match_type query string false "exact, prefix or regex"
+// @Param lang query string false "Language code"
+// @Param status_code query int false "301, 302 or 410"
+// @Param active query bool false "Active rules only (true) or inactive rules only (false)"
+// @Param limit query int false "Page size (1-100, default 20)"
+// @Param cursor query string false "Cursor returned as next_cursor by the previous page"
+// @Param sort query string false "Sort field, '-' prefix for descending (e.g., '-hits')"
+// @Param total query bool false "Include the total count"
+// @Success 200 {object} models.ListResponse{items=[]models.RedirectRule}
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /admin/redirects [get]
+func GetRedirectRulesHandler(c *gin.Context) {
+	opts, ok := helpers.BindListOptions(c, "-created_at")
+	if !ok {
+		return
+	}
+
+	filter := bson.M{}
+	for _, param := range []string{"match_type", "lang"} {
+		if value := c.Query(param); value != "" {
+			filter[param] = value
+		}
+	}
+	if value := c.Query("status_code"); value != "" {
+		code, err := strconv.Atoi(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid status_code"})
+			return
+		}
+		filter["status_code"] = code
+	}
+	if value := c.Query("active"); value != "" {
+		active, err := strconv.ParseBool(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid active value"})
+			return
+		}
+		filter["active"] = active
+	}
+
+	rules, info, err := services.GetRedirectRules(c.Request.Context(), filter, opts)
+	if err != nil {
+		helpers.RespondListError(c, "Failed to retrieve redirect rules", err)
+		return
+	}
+	helpers.RespondList(c, rules, info, opts)
+}
+
+// GetRedirectRuleHandler retrieves a redirect rule
+// @Summary Get a redirect rule
+// @Description Retrieve a redirect rule with its hit count and last hit time
+// @Tags Redirects
+// @Produce json
+// @Param id path string true "Rule ID"
+// @Success 200 {object} models.RedirectRule
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /admin/redirects/{id} [get]
+func GetRedirectRuleHandler(c *gin.Context) {
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid rule ID"})
+		return
+	}
+
+	rule, err := services.GetRedirectRule(c.Request.Context(), id)
+	if err != nil {
+		respondRedirectError(c, "Failed to retrieve redirect rule", err)
+		return
+	}
+	c.JSON(http.StatusOK, rule)
+}
+
+// UpdateRedirectRuleHandler updates a redirect rule
+// @Summary Update a redirect rule
+// @Description Replace the source, match type, target, status, language, active flag and note of a rule; hit counters are kept
+// @Tags Redirects
+// @Accept json
+// @Produce json
+// @Param id path string true "Rule ID"
+// @Param rule body models.RedirectRule true "Redirect rule"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /admin/redirects/{id} [put]
+func UpdateRedirectRuleHandler(c *gin.Context) {
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid rule ID"})
+		return
+	}
+
+	var rule models.RedirectRule
+	if err := c.ShouldBindJSON(&rule); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	_, rule.UpdatedBy = helpers.CurrentUser(c)
+	if err := services.UpdateRedirectRule(c.Request.Context(), id, &rule); err != nil {
+		respondRedirectError(c, "Failed to update redirect rule", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Redirect rule updated successfully"})
+}
+
+// DeleteRedirectRuleHandler deletes a redirect rule
+// @Summary Delete a redirect rule
+// @Description Permanently delete a redirect rule
+// @Tags Redirects
+// @Produce json
+// @Param id path string true "Rule ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /admin/redirects/{id} [delete]
+func DeleteRedirectRuleHandler(c *gin.Context) {
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid rule ID"})
+		return
+	}
+
+	if err := services.DeleteRedirectRule(c.Request.Context(), id); err != nil {
+		respondRedirectError(c, "Failed to delete redirect rule", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Redirect rule deleted successfully"})
+}
+
+// ImportRedirectRulesHandler imports redirect rules from CSV
+// @Summary Import redirect rules
+// @Description Create or update rules from CSV (columns: source, match_type, target, status_code, lang, active, note). Rules are matched by source, match type and language; nothing is imported when a row is invalid
+// @Tags Redirects
+// @Accept multipart/form-data
+// @Accept text/csv
+// @Produce json
+// @Param file formData file false "CSV file (or send the CSV as the request body)"
+// @Success 200 {object} models.RedirectImportResult
+// @Failure 400 {object} map[string]interface{}
+// @Failure 500 {object} map[string]string
+// @Router /admin/redirects/import [post]
+func ImportRedirectRulesHandler(c *gin.Context) {
+	var reader io.Reader
+	if file, err := c.FormFile("file"); err == nil {
+		if file.Size > maxRedirectImportSize {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "CSV file is too large"})
+			return
+		}
+		opened, err := file.Open()
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read CSV file", "details": err.Error()})
+			return
+		}
+		defer opened.Close()
+		reader = opened
+	} else {
+		body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxRedirectImportSize+1))
+		if err != nil || len(body) > maxRedirectImportSize {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "CSV body is missing or too large"})
+			return
+		}
+		reader = bytes.NewReader(body)
+	}
+
+	rules, err := utils.ParseRedirectCSV(reader)
+	if err != nil {
+		var validationErrors utils.ValidationErrors
+		if errors.As(err, &validationErrors) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Validation failed", "errors": validationErrors})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid CSV", "details": err.Error()})
+		return
+	}
+
+	_, username := helpers.CurrentUser(c)
+	result, err := services.ImportRedirectRules(c.Request.Context(), rules, username)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to import redirect rules", "details": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// ExportRedirectRulesHandler exports all redirect rules as CSV
+// @Summary Export redirect rules
+// @Description Download all redirect rules as CSV, including hit counts and last hit times
+// @Tags Redirects
+// @Produce text/csv
+// @Success 200 {string} string
+// @Failure 500 {object} map[string]string
+// @Router /admin/redirects/export [get]
+func ExportRedirectRulesHandler(c *gin.Context) {
+	rules, err := services.ExportRedirectRules(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to export redirect rules", "details": err.Error()})
+		return
+	}
+
+	var body bytes.Buffer
+	if err := utils.WriteRedirectCSV(&body, rules); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to export redirect rules", "details": err.Error()})
+		return
+	}
+	filename := "redirects-" + time.Now().Format("20060102") + ".csv"
+	c.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+	c.Data(http.StatusOK, "text/csv; charset=utf-8", body.Bytes())
+}
+
+// respondRedirectError maps redirect rule errors to HTTP responses
+func respondRedirectError(c *gin.Context, message string, err error) {
+	var validationErrors utils.ValidationErrors
+	switch {
+	case errors.As(err, &validationErrors):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Validation failed", "errors": validationErrors})
+	case errors.Is(err, services.ErrRedirectRuleExists):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case err == mongo.ErrNoDocuments:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Redirect rule not found"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message, "details": err.Error()})
+	}
+}
@@ -0,0 +1,169 @@
+package controllers
+
+import (
+	"admin-panel/helpers"
+	"admin-panel/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// revisionTarget validates the entity type and document ID path parameters
+func revisionTarget(c *gin.Context) (string, primitive.ObjectID, bool) {
+	entityType := c.Param("entity")
+	if entityType != "posts" && entityType != "pages" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Unsupported entity type"})
+		return "", primitive.NilObjectID, false
+	}
+
+	entityID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid document ID"})
+		return "", primitive.NilObjectID, false
+	}
+
+	return entityType, entityID, true
+}
+
+// GetRevisionsHandler lists the revisions of a post or page
+// @Summary List revisions
+// @Description Retrieve the revision history of a post or page, newest first
+// @Tags Revisions
+// @Produce json
+// @Param entity path string true "Entity type (posts, pages)"
+// @Param id path string true "Document ID"
+// @Success 200 {array} models.Revision
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /admin/revisions/{entity}/{id} [get]
+func GetRevisionsHandler(c *gin.Context) {
+	entityType, entityID, ok := revisionTarget(c)
+	if !ok {
+		return
+	}
+
+	revisions, err := services.GetRevisions(c.Request.Context(), entityType, entityID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve revisions", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, revisions)
+}
+
+// GetRevisionHandler retrieves a single revision with its snapshot
+// @Summary Get a revision
+// @Description Retrieve a single revision including the full document snapshot
+// @Tags Revisions
+// @Produce json
+// @Param entity path string true "Entity type (posts, pages)"
+// @Param id path string true "Document ID"
+// @Param revisionID path string true "Revision ID"
+// @Success 200 {object} models.Revision
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /admin/revisions/{entity}/{id}/{revisionID} [get]
+func GetRevisionHandler(c *gin.Context) {
+	entityType, entityID, ok := revisionTarget(c)
+	if !ok {
+		return
+	}
+
+	revisionID, err := primitive.ObjectIDFromHex(c.Param("revisionID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid revision ID"})
+		return
+	}
+
+	revision, err := services.GetRevisionByID(c.Request.Context(), entityType, entityID, revisionID)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Revision not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch revision", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, revision)
+}
+
+// DiffRevisionsHandler compares two revisions field by field
+// @Summary Diff two revisions
+// @Description Show field-level differences between two revisions of the same document
+// @Tags Revisions
+// @Produce json
+// @Param entity path string true "Entity type (posts, pages)"
+// @Param id path string true "Document ID"
+// @Param from query string true "Older revision ID"
+// @Param to query string true "Newer revision ID"
+// @Success 200 {array} models.FieldChange
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /admin/revisions/{entity}/{id}/diff [get]
+func DiffRevisionsHandler(c *gin.Context) {
+	entityType, entityID, ok := revisionTarget(c)
+	if !ok {
+		return
+	}
+
+	fromID, err1 := primitive.ObjectIDFromHex(c.Query("from"))
+	toID, err2 := primitive.ObjectIDFromHex(c.Query("to"))
+	if err1 != nil || err2 != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Both 'from' and 'to' revision IDs are required"})
+		return
+	}
+
+	changes, err := services.DiffRevisions(c.Request.Context(), entityType, entityID, fromID, toID)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Revision not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to compare revisions", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"from": fromID, "to": toID, "changes": changes})
+}
+
+// RestoreRevisionHandler restores a document to the state of a revision
+// @Summary Restore a revision
+// @Description Restore a post or page to the chosen revision; the restore is recorded as a new revision. The status, publish and unpublish dates and review fields keep their current values and only change through the workflow
+// @Tags Revisions
+// @Produce json
+// @Param entity path string true "Entity type (posts, pages)"
+// @Param id path string true "Document ID"
+// @Param revisionID path string true "Revision ID"
+// @Success 200 {object} models.Revision
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /admin/revisions/{entity}/{id}/{revisionID}/restore [post]
+func RestoreRevisionHandler(c *gin.Context) {
+	entityType, entityID, ok := revisionTarget(c)
+	if !ok {
+		return
+	}
+
+	revisionID, err := primitive.ObjectIDFromHex(c.Param("revisionID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid revision ID"})
+		return
+	}
+
+	userID, username := helpers.CurrentUser(c)
+	revision, err := services.RestoreRevision(c.Request.Context(), entityType, entityID, revisionID, userID, username)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Revision not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to restore revision", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Revision restored successfully", "revision": revision})
+}
@@ -1,6 +1,7 @@
 package controllers
 
 import (
+	"admin-panel/helpers"
 	"admin-panel/models"
 	"admin-panel/services"
 	"fmt"
@@ -56,18 +57,28 @@ func CreateRoleHandler(c *gin.Context) {
 // @Description Retrieve all roles with their permissions and details
 // @Tags Roles
 // @Produce json
-// @Success 200 {array} models.Role "List of roles"
+// @Param limit query int false "Page size (1-100, default 20)"
+// @Param cursor query string false "Cursor returned as next_cursor by the previous page"
+// @Param sort query string false "Sort field, '-' prefix for descending (e.g., '-created_at')"
+// @Param fields query string false "Comma-separated fields to return (e.g., 'id,title')"
+// @Param total query bool false "Include the total count"
+// @Success 200 {object} models.ListResponse{items=[]models.Role} "List of roles"
 // @Failure 500 {object} map[string]interface{} "Failed to retrieve roles"
 // @Router /roles [get]
 func GetAllRolesHandler(c *gin.Context) {
-	roles, err := services.GetAllRoles(c.Request.Context())
+	opts, ok := helpers.BindListOptions(c, "id")
+	if !ok {
+		return
+	}
+
+	roles, info, err := services.GetAllRoles(c.Request.Context(), opts)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve roles"})
 		fmt.Println("Database error during role retrieval:", err)
+		helpers.RespondListError(c, "Failed to retrieve roles", err)
 		return
 	}
 
-	c.JSON(http.StatusOK, roles)
+	helpers.RespondList(c, roles, info, opts)
 }
 
 // UpdateRoleHandler updates an existing role
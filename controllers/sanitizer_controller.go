@@ -0,0 +1,124 @@
+package controllers
+
+import (
+	"admin-panel/helpers"
+	"admin-panel/models"
+	"admin-panel/services"
+	"admin-panel/utils"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetSanitizerPoliciesHandler lists the effective sanitizer policies
+// @Summary List sanitizer policies
+// @Description List the allow-list policies applied to rich_text, comment and plain_text fields on write
+// @Tags Sanitizer
+// @Produce json
+// @Success 200 {object} map[string]models.SanitizerPolicy
+// @Failure 500 {object} map[string]string
+// @Router /admin/sanitizer/policies [get]
+func GetSanitizerPoliciesHandler(c *gin.Context) {
+	policies, err := services.GetSanitizerPolicies(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve sanitizer policies", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": policies})
+}
+
+// UpdateSanitizerPolicyHandler customizes a sanitizer policy
+// @Summary Update a sanitizer policy
+// @Description Replace the allowed elements, attributes and URL schemes of a policy (rich_text, comment, plain_text)
+// @Tags Sanitizer
+// @Accept json
+// @Produce json
+// @Param name path string true "Policy name"
+// @Param policy body models.SanitizerPolicy true "Allow-list"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /admin/sanitizer/policies/{name} [put]
+func UpdateSanitizerPolicyHandler(c *gin.Context) {
+	var policy models.SanitizerPolicy
+	if err := c.ShouldBindJSON(&policy); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	_, username := helpers.CurrentUser(c)
+	policy.UpdatedBy = username
+	if err := services.UpdateSanitizerPolicy(c.Request.Context(), c.Param("name"), &policy); err != nil {
+		respondSanitizerError(c, "Failed to update sanitizer policy", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Sanitizer policy updated successfully"})
+}
+
+// ResetSanitizerPolicyHandler restores the built-in default of a sanitizer policy
+// @Summary Reset a sanitizer policy
+// @Description Remove the customization of a policy so the built-in allow-list applies again
+// @Tags Sanitizer
+// @Produce json
+// @Param name path string true "Policy name"
+// @Success 200 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /admin/sanitizer/policies/{name} [delete]
+func ResetSanitizerPolicyHandler(c *gin.Context) {
+	if err := services.ResetSanitizerPolicy(c.Request.Context(), c.Param("name")); err != nil {
+		respondSanitizerError(c, "Failed to reset sanitizer policy", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Sanitizer policy reset to default"})
+}
+
+// PreviewSanitizerHandler shows what a policy would remove from some HTML
+// @Summary Preview sanitization
+// @Description Sanitize HTML with a policy without saving anything
+// @Tags Sanitizer
+// @Accept json
+// @Produce json
+// @Param name path string true "Policy name"
+// @Param input body map[string]string true "{\"html\": \"...\"}"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /admin/sanitizer/policies/{name}/preview [post]
+func PreviewSanitizerHandler(c *gin.Context) {
+	var input struct {
+		HTML string `json:"html" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	policies, err := services.GetSanitizerPolicies(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve sanitizer policies", "details": err.Error()})
+		return
+	}
+	policy, ok := policies[c.Param("name")]
+	if !ok {
+		respondSanitizerError(c, "", services.ErrUnknownSanitizerPolicy)
+		return
+	}
+
+	cleaned, removed := utils.SanitizeHTML(input.HTML, policy)
+	c.JSON(http.StatusOK, gin.H{"html": cleaned, "removed": removed})
+}
+
+func respondSanitizerError(c *gin.Context, message string, err error) {
+	if errors.Is(err, services.ErrUnknownSanitizerPolicy) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": message, "details": err.Error()})
+}
@@ -0,0 +1,118 @@
+package controllers
+
+import (
+	"admin-panel/models"
+	"admin-panel/services"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// SearchHandler performs a full-text search across posts, pages and categories
+// @Summary Full-text search
+// @Description Language-aware full-text search over titles and contents with relevance ranking, highlighted snippets and facets
+// @Tags Search
+// @Produce json
+// @Param q query string true "Search query"
+// @Param lang query string false "Language code (default: en)"
+// @Param type query string false "Comma separated types: posts, pages, categories"
+// @Param status query string false "Status filter (e.g., 'published')"
+// @Param category query string false "Category ID filter"
+// @Param date_from query string false "Publish date start (YYYY-MM-DD)"
+// @Param date_to query string false "Publish date end (YYYY-MM-DD)"
+// @Param page query int false "Page number (default: 1)"
+// @Param limit query int false "Results per page (default: 20, max: 100)"
+// @Success 200 {object} models.SearchResponse
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /search [get]
+func SearchHandler(c *gin.Context) {
+	query := models.SearchQuery{
+		Query:  strings.TrimSpace(c.Query("q")),
+		Lang:   c.DefaultQuery("lang", services.DefaultLanguage()),
+		Status: c.Query("status"),
+		Page:   1,
+		Limit:  20,
+	}
+	if query.Query == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Search query 'q' is required"})
+		return
+	}
+
+	if types := c.Query("type"); types != "" {
+		for _, t := range strings.Split(types, ",") {
+			t = strings.TrimSpace(t)
+			if t != "posts" && t != "pages" && t != "categories" {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid type: " + t})
+				return
+			}
+			query.Types = append(query.Types, t)
+		}
+	}
+
+	if category := c.Query("category"); category != "" {
+		categoryID, err := primitive.ObjectIDFromHex(category)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid category ID"})
+			return
+		}
+		query.CategoryID = &categoryID
+	}
+
+	if dateFrom := c.Query("date_from"); dateFrom != "" {
+		from, err := time.Parse("2006-01-02", dateFrom)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid date_from, expected YYYY-MM-DD"})
+			return
+		}
+		query.DateFrom = &from
+	}
+	if dateTo := c.Query("date_to"); dateTo != "" {
+		to, err := time.Parse("2006-01-02", dateTo)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid date_to, expected YYYY-MM-DD"})
+			return
+		}
+		to = to.Add(24*time.Hour - time.Nanosecond) // Gün sonuna kadar
+		query.DateTo = &to
+	}
+
+	if page, err := strconv.Atoi(c.Query("page")); err == nil && page > 0 {
+		query.Page = page
+	}
+	if limit, err := strconv.Atoi(c.Query("limit")); err == nil && limit > 0 {
+		query.Limit = limit
+	}
+	if query.Limit > 100 {
+		query.Limit = 100
+	}
+
+	response, err := services.Search(c.Request.Context(), query)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Search failed", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// RebuildSearchIndexHandler re-indexes all searchable content
+// @Summary Rebuild search index
+// @Description Re-index every post, page and category
+// @Tags Search
+// @Produce json
+// @Success 200 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /search/reindex [post]
+func RebuildSearchIndexHandler(c *gin.Context) {
+	if err := services.RebuildSearchIndex(c.Request.Context()); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to rebuild search index", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Search index rebuilt successfully"})
+}
@@ -0,0 +1,54 @@
+package controllers
+
+import (
+	"admin-panel/services"
+	"admin-panel/utils"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// AuditSEOHandler analyzes a post or page and stores the result on the document
+// @Summary Run an SEO audit
+// @Description Check title and description length, missing meta, duplicate titles and slugs, heading structure, image alt texts, links, focus keyword usage and readability; the result is stored under seo_audit.<lang>
+// @Tags SEO
+// @Produce json
+// @Param type path string true "Content type (posts, pages)"
+// @Param id path string true "Document ID"
+// @Param lang query string false "Language code; all languages of the document when empty"
+// @Param keyword query string false "Focus keyword (defaults to the first meta keyword)"
+// @Success 200 {array} models.SEOAudit
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /admin/seo/audit/{type}/{id} [post]
+func AuditSEOHandler(c *gin.Context) {
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid document ID"})
+		return
+	}
+	lang := c.Query("lang")
+	if lang != "" && !utils.IsValidLanguageCode(lang) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid language code"})
+		return
+	}
+
+	audits, err := services.AuditContentSEO(c.Request.Context(), c.Param("type"), id, lang, c.Query("keyword"), publicSiteURL(c))
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrUnsupportedSEOAudit), errors.Is(err, services.ErrSEOAuditLanguage):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case err == mongo.ErrNoDocuments:
+			c.JSON(http.StatusNotFound, gin.H{"error": "Document not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to run SEO audit", "details": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": audits})
+}
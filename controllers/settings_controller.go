@@ -3,6 +3,7 @@ package controllers
 import (
 	"admin-panel/models"
 	"admin-panel/services"
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -45,6 +46,10 @@ func UpdateSettingsHandler(c *gin.Context) {
 
 	updatedBy := c.GetString("username") // Kullanıcı bilgisi JWT'den alınabilir
 	if err := services.UpdateSettings(update, updatedBy); err != nil {
+		if errors.Is(err, services.ErrUnknownLanguage) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid default language", "details": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update settings"})
 		return
 	}
@@ -0,0 +1,106 @@
+package controllers
+
+import (
+	"admin-panel/helpers"
+	"admin-panel/services"
+	"admin-panel/utils"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Site haritası yanıtlarının içerik tipi
+const sitemapContentType = "application/xml; charset=utf-8"
+
+// GetSitemapIndexHandler serves the sitemap index
+// @Summary Get the sitemap index
+// @Description Sitemap index listing the child sitemaps of posts, pages, categories and tags (50.000 URL per file)
+// @Tags Sitemaps
+// @Produce xml
+// @Success 200 {string} string
+// @Success 304 {string} string "Not modified"
+// @Failure 500 {object} map[string]string
+// @Router /sitemap.xml [get]
+func GetSitemapIndexHandler(c *gin.Context) {
+	refs, err := services.GetSitemapIndex(c.Request.Context(), publicSiteURL(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to build sitemap index", "details": err.Error()})
+		return
+	}
+
+	body, err := utils.RenderSitemapIndex(refs)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to render sitemap index", "details": err.Error()})
+		return
+	}
+
+	var lastMod time.Time
+	for _, ref := range refs {
+		if ref.LastMod.After(lastMod) {
+			lastMod = ref.LastMod
+		}
+	}
+	helpers.RespondCached(c, sitemapContentType, body, lastMod, services.SitemapCacheMaxAge)
+}
+
+// GetSitemapHandler serves a child sitemap
+// @Summary Get a child sitemap
+// @Description URLs of one content type with lastmod and hreflang alternates; name is <type>-<n>.xml (e.g., posts-1.xml)
+// @Tags Sitemaps
+// @Produce xml
+// @Param name path string true "Sitemap name (posts-1.xml, pages-1.xml, categories-1.xml, tags-1.xml)"
+// @Success 200 {string} string
+// @Success 304 {string} string "Not modified"
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /sitemaps/{name} [get]
+func GetSitemapHandler(c *gin.Context) {
+	name, ok := strings.CutSuffix(c.Param("name"), ".xml")
+	separator := strings.LastIndex(name, "-")
+	if !ok || separator < 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Sitemap not found"})
+		return
+	}
+	part, err := strconv.Atoi(name[separator+1:])
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Sitemap not found"})
+		return
+	}
+
+	urls, lastMod, err := services.GetSitemapURLs(c.Request.Context(), name[:separator], part, publicSiteURL(c))
+	if err != nil {
+		if err == services.ErrUnknownSitemap {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Sitemap not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to build sitemap", "details": err.Error()})
+		return
+	}
+
+	body, err := utils.RenderSitemap(urls)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to render sitemap", "details": err.Error()})
+		return
+	}
+	helpers.RespondCached(c, sitemapContentType, body, lastMod, services.SitemapCacheMaxAge)
+}
+
+// RebuildSitemapHandler regenerates all sitemap entries
+// @Summary Rebuild sitemaps
+// @Description Regenerate the sitemap entries of every post, page, category and tag; normalde içerik değiştikçe güncellenir
+// @Tags Sitemaps
+// @Produce json
+// @Success 200 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /admin/sitemaps/rebuild [post]
+func RebuildSitemapHandler(c *gin.Context) {
+	if err := services.RebuildSitemap(c.Request.Context()); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to rebuild sitemap", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Sitemap rebuilt successfully"})
+}
@@ -1,6 +1,7 @@
 package controllers
 
 import (
+	"admin-panel/helpers"
 	"admin-panel/models"
 	"admin-panel/services"
 	"net/http"
@@ -40,17 +41,27 @@ func CreateSliderHandler(c *gin.Context) {
 // @Description Retrieve all sliders with their details
 // @Tags Sliders
 // @Produce json
-// @Success 200 {array} models.Slider "List of sliders"
+// @Param limit query int false "Page size (1-100, default 20)"
+// @Param cursor query string false "Cursor returned as next_cursor by the previous page"
+// @Param sort query string false "Sort field, '-' prefix for descending (e.g., '-created_at')"
+// @Param fields query string false "Comma-separated fields to return (e.g., 'id,title')"
+// @Param total query bool false "Include the total count"
+// @Success 200 {object} models.ListResponse{items=[]models.Slider} "List of sliders"
 // @Failure 500 {object} map[string]interface{} "Failed to retrieve sliders"
 // @Router /sliders [get]
 func GetSlidersHandler(c *gin.Context) {
-	sliders, err := services.GetSliders()
+	opts, ok := helpers.BindListOptions(c, "-id")
+	if !ok {
+		return
+	}
+
+	sliders, info, err := services.GetSliders(opts)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve sliders"})
+		helpers.RespondListError(c, "Failed to retrieve sliders", err)
 		return
 	}
 
-	c.JSON(http.StatusOK, sliders)
+	helpers.RespondList(c, sliders, info, opts)
 }
 
 // UpdateSliderHandler updates an existing slider
@@ -1,6 +1,7 @@
 package controllers
 
 import (
+	"admin-panel/helpers"
 	"admin-panel/models"
 	"admin-panel/services"
 	"net/http"
@@ -42,17 +43,27 @@ func CreateTagHandler(c *gin.Context) {
 // @Description Retrieve all tags with their details
 // @Tags Tags
 // @Produce json
-// @Success 200 {array} models.Tag "List of tags"
+// @Param limit query int false "Page size (1-100, default 20)"
+// @Param cursor query string false "Cursor returned as next_cursor by the previous page"
+// @Param sort query string false "Sort field, '-' prefix for descending (e.g., '-created_at')"
+// @Param fields query string false "Comma-separated fields to return (e.g., 'id,title')"
+// @Param total query bool false "Include the total count"
+// @Success 200 {object} models.ListResponse{items=[]models.Tag} "List of tags"
 // @Failure 500 {object} map[string]interface{} "Failed to retrieve tags"
 // @Router /tags [get]
 func GetAllTagsHandler(c *gin.Context) {
-	tags, err := services.GetAllTags()
+	opts, ok := helpers.BindListOptions(c, "-id")
+	if !ok {
+		return
+	}
+
+	tags, info, err := services.GetAllTags(opts)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve tags"})
+		helpers.RespondListError(c, "Failed to retrieve tags", err)
 		return
 	}
 
-	c.JSON(http.StatusOK, tags)
+	helpers.RespondList(c, tags, info, opts)
 }
 
 // GetTagByIDHandler retrieves a tag by ID
@@ -136,7 +147,7 @@ func UpdateTagHandler(c *gin.Context) {
 
 // DeleteTagHandler deletes a tag by ID
 // @Summary Delete a tag
-// @Description Remove a tag by its unique identifier
+// @Description Move a tag to the trash; it can be restored from /admin/trash/tags
 // @Tags Tags
 // @Param id path string true "Tag ID"
 // @Success 204 "No Content"
@@ -154,8 +165,9 @@ func DeleteTagHandler(c *gin.Context) {
 		return
 	}
 
-	// Silme işlemini servise gönder
-	err = services.DeleteTag(c.Request.Context(), objectID)
+	// Çöp kutusuna taşı
+	_, username := helpers.CurrentUser(c)
+	err = services.DeleteTag(c.Request.Context(), objectID, username)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Tag not found"})
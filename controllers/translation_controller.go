@@ -0,0 +1,96 @@
+package controllers
+
+import (
+	"admin-panel/helpers"
+	"admin-panel/models"
+	"admin-panel/services"
+	"admin-panel/utils"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// GetTranslationStatusHandler returns the per-language translation status of a document
+// @Summary Get translation status
+// @Description Retrieve the translation status (missing, outdated, up_to_date) of a document for every enabled language
+// @Tags Translations
+// @Produce json
+// @Param type path string true "Content type (posts, pages, categories, content)"
+// @Param id path string true "Document ID"
+// @Success 200 {array} models.TranslationStatus
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /admin/translations/status/{type}/{id} [get]
+func GetTranslationStatusHandler(c *gin.Context) {
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid document ID"})
+		return
+	}
+
+	statuses, err := services.GetTranslationStatuses(c.Request.Context(), c.Param("type"), id)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrUnsupportedTranslationType):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case err == mongo.ErrNoDocuments:
+			c.JSON(http.StatusNotFound, gin.H{"error": "Document not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve translation status", "details": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": statuses})
+}
+
+// GetOutdatedTranslationsHandler lists the documents whose translation needs work in a language
+// @Summary List outdated translations
+// @Description List documents whose translation in a language is outdated (source changed after the translation) or missing
+// @Tags Translations
+// @Produce json
+// @Param lang query string true "Language code (e.g., 'de', 'de-AT')"
+// @Param type query string false "Content type (posts, pages, categories, content), default posts"
+// @Param status query string false "outdated (default) or missing"
+// @Param limit query int false "Page size (1-100, default 20)"
+// @Param cursor query string false "Cursor returned as next_cursor by the previous page"
+// @Param total query bool false "Include the total count"
+// @Success 200 {object} models.ListResponse{items=[]models.TranslationStatus}
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /admin/translations/outdated [get]
+func GetOutdatedTranslationsHandler(c *gin.Context) {
+	lang := c.Query("lang")
+	if !utils.IsValidLanguageCode(lang) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Valid language code 'lang' is required"})
+		return
+	}
+	status := c.DefaultQuery("status", models.TranslationOutdated)
+	if status != models.TranslationOutdated && status != models.TranslationMissing {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Status must be 'outdated' or 'missing'"})
+		return
+	}
+
+	opts, ok := helpers.BindListOptions(c, "id")
+	if !ok {
+		return
+	}
+	opts.Sort = "id"
+	opts.Fields = nil
+
+	items, info, err := services.GetTranslationsByStatus(c.Request.Context(), c.DefaultQuery("type", "posts"), lang, status, opts)
+	if err != nil {
+		if errors.Is(err, services.ErrUnsupportedTranslationType) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		helpers.RespondListError(c, "Failed to retrieve translations", err)
+		return
+	}
+
+	helpers.RespondList(c, items, info, opts)
+}
@@ -0,0 +1,111 @@
+package controllers
+
+import (
+	"admin-panel/helpers"
+	"admin-panel/services"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// respondTrashError maps trash service errors to HTTP responses
+func respondTrashError(c *gin.Context, message string, err error) {
+	switch {
+	case errors.Is(err, services.ErrUnsupportedTrashModule):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case err == mongo.ErrNoDocuments:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Item not found in trash"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message, "details": err.Error()})
+	}
+}
+
+// GetTrashHandler lists the trashed items of a module
+// @Summary List trash
+// @Description List soft-deleted items of a module, most recently deleted first
+// @Tags Trash
+// @Produce json
+// @Param module path string true "Module (posts, pages, categories, tags, media, comments)"
+// @Param limit query int false "Page size (1-100, default 20)"
+// @Param cursor query string false "Cursor returned as next_cursor by the previous page"
+// @Param sort query string false "Sort field, '-' prefix for descending (default '-deleted_at')"
+// @Param fields query string false "Comma-separated fields to return (e.g., 'id,deleted_at')"
+// @Param total query bool false "Include the total count"
+// @Success 200 {object} models.ListResponse
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /admin/trash/{module} [get]
+func GetTrashHandler(c *gin.Context) {
+	opts, ok := helpers.BindListOptions(c, "-deleted_at")
+	if !ok {
+		return
+	}
+
+	items, info, err := services.GetTrash(c.Request.Context(), c.Param("module"), opts)
+	if err != nil {
+		if errors.Is(err, services.ErrUnsupportedTrashModule) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		helpers.RespondListError(c, "Failed to retrieve trash", err)
+		return
+	}
+
+	helpers.RespondList(c, items, info, opts)
+}
+
+// RestoreTrashHandler restores a trashed item
+// @Summary Restore from trash
+// @Description Restore a soft-deleted item so that it appears in the default queries again
+// @Tags Trash
+// @Produce json
+// @Param module path string true "Module (posts, pages, categories, tags, media, comments)"
+// @Param id path string true "Item ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /admin/trash/{module}/{id}/restore [post]
+func RestoreTrashHandler(c *gin.Context) {
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid item ID"})
+		return
+	}
+
+	if err := services.RestoreFromTrash(c.Request.Context(), c.Param("module"), id); err != nil {
+		respondTrashError(c, "Failed to restore item", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Item restored successfully"})
+}
+
+// PurgeTrashHandler permanently deletes a trashed item
+// @Summary Purge from trash
+// @Description Permanently delete a soft-deleted item together with its dependent data (search entries, revisions, files)
+// @Tags Trash
+// @Param module path string true "Module (posts, pages, categories, tags, media, comments)"
+// @Param id path string true "Item ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /admin/trash/{module}/{id} [delete]
+func PurgeTrashHandler(c *gin.Context) {
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid item ID"})
+		return
+	}
+
+	if err := services.PurgeFromTrash(c.Request.Context(), c.Param("module"), id); err != nil {
+		respondTrashError(c, "Failed to purge item", err)
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
@@ -0,0 +1,91 @@
+package controllers
+
+import (
+	"admin-panel/helpers"
+	"admin-panel/models"
+	"admin-panel/services"
+	"admin-panel/utils"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetUploadPoliciesHandler lists the effective upload policies
+// @Summary List upload policies
+// @Description Return the allowed MIME types and size limits of every role, including the built-in defaults. Roles without a policy use the "default" policy
+// @Tags Upload Policies
+// @Produce json
+// @Success 200 {object} map[string]models.UploadPolicy
+// @Failure 500 {object} map[string]string
+// @Router /admin/upload-policies [get]
+func GetUploadPoliciesHandler(c *gin.Context) {
+	policies, err := services.GetUploadPolicies(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve upload policies", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, policies)
+}
+
+// UpdateUploadPolicyHandler sets the upload policy of a role
+// @Summary Update an upload policy
+// @Description Replace the allowed MIME types ("image/png" or groups such as "image/*") and their size limits in bytes for a role. SVG is only allowed when listed as image/svg+xml
+// @Tags Upload Policies
+// @Accept json
+// @Produce json
+// @Param role path string true "Role name"
+// @Param policy body models.UploadPolicy true "Allow-list"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /admin/upload-policies/{role} [put]
+func UpdateUploadPolicyHandler(c *gin.Context) {
+	var policy models.UploadPolicy
+	if err := c.ShouldBindJSON(&policy); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	_, username := helpers.CurrentUser(c)
+	policy.UpdatedBy = username
+	if err := services.UpdateUploadPolicy(c.Request.Context(), c.Param("role"), &policy); err != nil {
+		respondUploadPolicyError(c, "Failed to update upload policy", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Upload policy updated successfully"})
+}
+
+// ResetUploadPolicyHandler removes the stored upload policy of a role
+// @Summary Reset an upload policy
+// @Description Remove the stored policy of a role so the built-in default (or the "default" policy) applies again
+// @Tags Upload Policies
+// @Produce json
+// @Param role path string true "Role name"
+// @Success 200 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /admin/upload-policies/{role} [delete]
+func ResetUploadPolicyHandler(c *gin.Context) {
+	if err := services.ResetUploadPolicy(c.Request.Context(), c.Param("role")); err != nil {
+		respondUploadPolicyError(c, "Failed to reset upload policy", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Upload policy reset to default"})
+}
+
+func respondUploadPolicyError(c *gin.Context, message string, err error) {
+	var validationErrors utils.ValidationErrors
+	switch {
+	case errors.As(err, &validationErrors):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Validation failed", "errors": validationErrors})
+	case errors.Is(err, services.ErrUnknownUploadPolicy):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message, "details": err.Error()})
+	}
+}
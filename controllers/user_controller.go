@@ -1,6 +1,7 @@
 package controllers
 
 import (
+	"admin-panel/helpers"
 	"admin-panel/models"
 	"admin-panel/services"
 	"fmt"
@@ -82,22 +83,28 @@ func CreateUserHandler(c *gin.Context) {
 // @Description Retrieve all users with their details
 // @Tags Users
 // @Produce json
-// @Success 200 {array} models.User "List of users"
+// @Param limit query int false "Page size (1-100, default 20)"
+// @Param cursor query string false "Cursor returned as next_cursor by the previous page"
+// @Param sort query string false "Sort field, '-' prefix for descending (e.g., '-created_at')"
+// @Param fields query string false "Comma-separated fields to return (e.g., 'id,title')"
+// @Param total query bool false "Include the total count"
+// @Success 200 {object} models.ListResponse{items=[]models.User} "List of users"
 // @Failure 500 {object} map[string]interface{} "Failed to retrieve users"
 // @Router /users [get]
 func GetAllUsersHandler(c *gin.Context) {
-	users, err := services.GetAllUsers()
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve users"})
+	opts, ok := helpers.BindListOptions(c, "-id")
+	if !ok {
 		return
 	}
 
-	// Her kullanıcının şifresini temizle
-	for i := range users {
-		users[i].Password = ""
+	// Şifreler servis katmanında temizlenir
+	users, info, err := services.GetAllUsers(opts)
+	if err != nil {
+		helpers.RespondListError(c, "Failed to retrieve users", err)
+		return
 	}
 
-	c.JSON(http.StatusOK, users)
+	helpers.RespondList(c, users, info, opts)
 }
 
 // UpdateUserHandler updates an existing user
@@ -164,6 +171,7 @@ func UpdateUserHandler(c *gin.Context) {
 		"password":   true,
 		"roles":      true, // already guarded above
 		"phone":      true,
+		"avatar_url": true,
 		"updated_at": true,
 	}
 	filtered := map[string]interface{}{}
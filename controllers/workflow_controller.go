@@ -0,0 +1,158 @@
+package controllers
+
+import (
+	"admin-panel/helpers"
+	"admin-panel/models"
+	"admin-panel/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// PostWorkflowHandler applies a workflow action to a post
+// @Summary Apply a workflow action to a post
+// @Description Move a post through the editorial workflow (submit, approve, reject, publish, schedule, unpublish, revert)
+// @Tags Workflow
+// @Accept json
+// @Produce json
+// @Param id path string true "Post ID"
+// @Param action body models.WorkflowActionRequest true "Workflow action"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /admin/posts/{id}/workflow [post]
+func PostWorkflowHandler(c *gin.Context) {
+	applyWorkflowAction(c, "posts")
+}
+
+// PageWorkflowHandler applies a workflow action to a page
+// @Summary Apply a workflow action to a page
+// @Description Move a page through the editorial workflow (submit, approve, reject, publish, schedule, unpublish, revert)
+// @Tags Workflow
+// @Accept json
+// @Produce json
+// @Param id path string true "Page ID"
+// @Param action body models.WorkflowActionRequest true "Workflow action"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /admin/pages/{id}/workflow [post]
+func PageWorkflowHandler(c *gin.Context) {
+	applyWorkflowAction(c, "pages")
+}
+
+// canSetStatus reports whether the user may change the status from one value to another in a plain create or update.
+// Yalnızca iş akışında tanımlı ve kullanıcının iznine sahip olduğu geçişler kabul edilir.
+func canSetStatus(c *gin.Context, module, from, to string) (bool, error) {
+	roles, _ := c.Get("roles")
+	userRoles, _ := roles.([]string)
+	return services.CanSetStatus(c.Request.Context(), module, from, to, userRoles)
+}
+
+func applyWorkflowAction(c *gin.Context, module string) {
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid document ID"})
+		return
+	}
+
+	var request models.WorkflowActionRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	roles, _ := c.Get("roles")
+	userRoles, _ := roles.([]string)
+	userID, username := helpers.CurrentUser(c)
+
+	status, err := services.ApplyWorkflowAction(c.Request.Context(), module, id, request, userRoles, userID, username)
+	if err != nil {
+		switch err {
+		case services.ErrUnknownWorkflowAction, services.ErrCommentRequired:
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case services.ErrWorkflowForbidden:
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case services.ErrInvalidTransition:
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		case mongo.ErrNoDocuments:
+			c.JSON(http.StatusNotFound, gin.H{"error": "Document not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to apply workflow action", "details": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Workflow action applied successfully", "status": status})
+}
+
+// GetWorkflowHandler retrieves the workflow configuration of a module
+// @Summary Get workflow configuration
+// @Description Retrieve the allowed status transitions of posts or pages
+// @Tags Workflow
+// @Produce json
+// @Param module path string true "Module (posts, pages)"
+// @Success 200 {object} models.Workflow
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /admin/workflows/{module} [get]
+func GetWorkflowHandler(c *gin.Context) {
+	module := c.Param("module")
+	if module != "posts" && module != "pages" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Unsupported module"})
+		return
+	}
+
+	workflow, err := services.GetWorkflow(c.Request.Context(), module)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve workflow", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, workflow)
+}
+
+// UpdateWorkflowHandler replaces the workflow configuration of a module
+// @Summary Update workflow configuration
+// @Description Replace the allowed status transitions of posts or pages
+// @Tags Workflow
+// @Accept json
+// @Produce json
+// @Param module path string true "Module (posts, pages)"
+// @Param workflow body models.Workflow true "Workflow transitions"
+// @Success 200 {object} models.Workflow
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /admin/workflows/{module} [put]
+func UpdateWorkflowHandler(c *gin.Context) {
+	module := c.Param("module")
+	if module != "posts" && module != "pages" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Unsupported module"})
+		return
+	}
+
+	var workflow models.Workflow
+	if err := c.ShouldBindJSON(&workflow); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if len(workflow.Transitions) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "At least one transition is required"})
+		return
+	}
+
+	workflow.ID = module
+	workflow.UpdatedBy = c.GetString("username")
+	if err := services.SaveWorkflow(c.Request.Context(), workflow); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update workflow", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Workflow updated successfully", "workflow": workflow})
+}
@@ -51,7 +51,8 @@ var RootQuery = gql.NewObject(gql.ObjectConfig{
 			Type: gql.NewList(RoleType),
 			Resolve: func(p gql.ResolveParams) (interface{}, error) {
 				ctx := context.Background()
-				return services.GetAllRoles(ctx)
+				roles, _, err := services.GetAllRoles(ctx, services.ListOptions{})
+				return roles, err
 			},
 		},
 		"role": &gql.Field{
@@ -0,0 +1,51 @@
+package helpers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RespondCached writes a publicly cacheable response with ETag and Last-Modified headers.
+// İstemcinin kopyası güncelse (If-None-Match / If-Modified-Since) gövdesiz 304 döner.
+func RespondCached(c *gin.Context, contentType string, body []byte, modified time.Time, maxAge time.Duration) {
+	sum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+
+	header := c.Writer.Header()
+	header.Set("Cache-Control", "public, max-age="+strconv.Itoa(int(maxAge.Seconds())))
+	header.Set("ETag", etag)
+	if !modified.IsZero() {
+		header.Set("Last-Modified", modified.UTC().Format(http.TimeFormat))
+	}
+
+	if notModified(c.Request, etag, modified) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	c.Data(http.StatusOK, contentType, body)
+}
+
+func notModified(request *http.Request, etag string, modified time.Time) bool {
+	// If-None-Match varsa If-Modified-Since yok sayılır (RFC 9110)
+	if match := request.Header.Get("If-None-Match"); match != "" {
+		for _, candidate := range strings.Split(match, ",") {
+			candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+			if candidate == etag || candidate == "*" {
+				return true
+			}
+		}
+		return false
+	}
+	if since := request.Header.Get("If-Modified-Since"); since != "" && !modified.IsZero() {
+		if t, err := http.ParseTime(since); err == nil {
+			return !modified.Truncate(time.Second).After(t)
+		}
+	}
+	return false
+}
@@ -0,0 +1,15 @@
+package helpers
+
+import (
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// CurrentUser returns the authenticated user's ID and username set by AuthMiddleware
+func CurrentUser(c *gin.Context) (primitive.ObjectID, string) {
+	userID, err := primitive.ObjectIDFromHex(c.GetString("userID"))
+	if err != nil {
+		userID = primitive.NilObjectID
+	}
+	return userID, c.GetString("username")
+}
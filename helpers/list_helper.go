@@ -0,0 +1,96 @@
+package helpers
+
+import (
+	"admin-panel/models"
+	"admin-panel/services"
+	"admin-panel/utils"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BindListOptions reads limit, cursor, sort, fields and total query parameters.
+// Hatalı parametrelerde 400 yanıtı yazılır ve false döner.
+func BindListOptions(c *gin.Context, defaultSort string) (services.ListOptions, bool) {
+	opts := services.ListOptions{
+		Limit:     services.DefaultListLimit,
+		Cursor:    c.Query("cursor"),
+		Sort:      c.DefaultQuery("sort", defaultSort),
+		WithTotal: c.Query("total") == "true",
+	}
+
+	if limit := c.Query("limit"); limit != "" {
+		value, err := strconv.Atoi(limit)
+		if err != nil || value < 1 || value > services.MaxListLimit {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit", "details": "limit must be between 1 and " + strconv.Itoa(services.MaxListLimit)})
+			return opts, false
+		}
+		opts.Limit = value
+	}
+
+	if fields := c.Query("fields"); fields != "" {
+		for _, field := range strings.Split(fields, ",") {
+			if field = strings.TrimSpace(field); field != "" {
+				opts.Fields = append(opts.Fields, field)
+			}
+		}
+	}
+
+	return opts, true
+}
+
+// RespondList writes a paginated list envelope; fields= verildiyse yalnızca istenen alanlar (ve id) döner
+func RespondList(c *gin.Context, items interface{}, info *services.PageInfo, opts services.ListOptions) {
+	response := models.ListResponse{Items: items, Limit: opts.Limit}
+	if info != nil {
+		response.NextCursor = info.NextCursor
+		response.Total = info.Total
+	}
+
+	if len(opts.Fields) > 0 {
+		projected, err := projectFields(items, opts.Fields)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to project fields", "details": err.Error()})
+			return
+		}
+		response.Items = projected
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// RespondListError maps pagination errors to 400 and everything else to 500
+func RespondListError(c *gin.Context, message string, err error) {
+	if errors.Is(err, utils.ErrInvalidCursor) || errors.Is(err, services.ErrUnknownField) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid list parameters", "details": err.Error()})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": message, "details": err.Error()})
+}
+
+func projectFields(items interface{}, fields []string) ([]map[string]interface{}, error) {
+	data, err := json.Marshal(items)
+	if err != nil {
+		return nil, err
+	}
+	var documents []map[string]interface{}
+	if err := json.Unmarshal(data, &documents); err != nil {
+		return nil, err
+	}
+
+	projected := make([]map[string]interface{}, 0, len(documents))
+	for _, document := range documents {
+		item := map[string]interface{}{"id": document["id"]}
+		for _, field := range fields {
+			if value, ok := document[field]; ok {
+				item[field] = value
+			}
+		}
+		projected = append(projected, item)
+	}
+	return projected, nil
+}
@@ -79,6 +79,9 @@ func main() {
 	services.InitPageService(configs.DB)
 	services.InitCategoryService(configs.DB)
 	services.InitTagService(configs.DB)
+	if err := services.InitStorage(); err != nil {
+		log.Fatalf("Depolama sürücüsü başlatılamadı: %v", err)
+	}
 	services.InitMediaService(configs.DB)
 	services.InitCommentService(configs.DB)
 	services.InitNotificationService(configs.DB)
@@ -93,9 +96,35 @@ func main() {
 	services.InitSettingsService(configs.DB)
 	services.InitSliderService(configs.DB)
 	services.InitAuthService(configs.DB)
+	services.InitRevisionService(configs.DB)
+	services.InitSchedulerService(configs.DB)
+	services.InitWorkflowService(configs.DB)
+	services.InitSearchService(configs.DB)
+	services.InitRedirectService(configs.DB)
+	services.InitPreviewService(configs.DB)
+	services.InitContentTypeService(configs.DB)
+	services.InitContentEntryService(configs.DB)
+	services.InitSanitizerService(configs.DB)
+	services.InitUploadPolicyService(configs.DB)
+	services.InitMediaReferenceService(configs.DB)
+	services.InitMediaFolderService(configs.DB)
+	services.InitSitemapService(configs.DB)
 
 	log.Println("Tüm servisler başarıyla başlatıldı.")
 
+	// Arama indekslerini oluştur (ilk çalıştırmada içerikleri indeksler)
+	indexCtx, indexCancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	if err := services.EnsureSearchIndexes(indexCtx); err != nil {
+		log.Printf("Arama indeksleri oluşturulamadı: %v", err)
+	}
+	if err := services.EnsureSitemap(indexCtx); err != nil {
+		log.Printf("Site haritası oluşturulamadı: %v", err)
+	}
+	indexCancel()
+
+	// Zamanlanmış içerik yayınlama / yayından kaldırma
+	services.StartScheduler()
+
 	// Gin başlat
 	// Gin: daha kontrollü middleware yönetimi için gin.New kullan
 	r := gin.New()
@@ -117,22 +146,6 @@ func main() {
 	// Swagger route
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
-	// Dil ve SEO dostu rotalar
-	r.GET("/:lang/:slug", func(c *gin.Context) {
-		lang := c.Param("lang")
-		slug := c.Param("slug")
-
-		// Burada dil ve slug'a göre içeriği getirme işlemi yapılacak
-		// Örneğin:
-		// content := getContentByLangAndSlug(lang, slug)
-
-		c.JSON(http.StatusOK, gin.H{
-			"language": lang,
-			"slug":     slug,
-			// "content":  content,
-		})
-	})
-
 	// Rotaları yükle
 	routes.AuthRoutes(r)
 	routes.UserRoutes(r)
@@ -152,11 +165,30 @@ func main() {
 	routes.SettingsRoutes(r)
 	routes.MaintenanceRoutes(r)
 	routes.SliderRoutes(r)
+	routes.RevisionRoutes(r)
+	routes.WorkflowRoutes(r)
+	routes.SearchRoutes(r)
+	routes.TranslationRoutes(r)
+	routes.TrashRoutes(r)
+	routes.PreviewRoutes(r)
+	routes.ContentTypeRoutes(r)
+	routes.BlockRoutes(r)
+	routes.SanitizerRoutes(r)
+	routes.SEORoutes(r)
+	routes.RedirectRoutes(r)
+	routes.UploadPolicyRoutes(r)
+	routes.PublicRoutes(r)  // Herkese açık içerik API'si
+	routes.FeedRoutes(r)    // RSS, Atom ve JSON Feed akışları
+	routes.SitemapRoutes(r) // XML site haritaları
+
+	// Yönetilen yönlendirmeler; yalnızca bundan sonra eklenen rotalara ve bulunamayan adreslere uygulanır
+	r.Use(middlewares.RedirectMiddleware())
+	routes.ContentRoutes(r) // Dil ve SEO dostu rotalar (/:lang/:slug)
 
 	// GraphQL rotası
 	routes.GraphQLRoutes(r)
 
-	r.Static("/uploads", "./uploads")
+	r.Static(services.LocalStorageURL, services.LocalStorageRoot) // Yerel depolama sürücüsü
 	r.Static("/docs", "./docs")
 
 	// Sunucuyu başlat
@@ -189,8 +221,10 @@ func main() {
 
 	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 15*time.Second)
 	defer shutdownCancel()
+	// Zamanlayıcı önce durdurulur; sunucu kapanışı uzasa da kilit bırakılır
+	services.StopScheduler(shutdownCtx)
 	if err := srv.Shutdown(shutdownCtx); err != nil {
-		log.Fatalf("Server forced to shutdown: %v", err)
+		log.Printf("Server forced to shutdown: %v", err)
 	}
 	log.Println("Server exiting")
 }
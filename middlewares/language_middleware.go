@@ -1,16 +1,20 @@
 package middlewares
 
 import (
+	"admin-panel/services"
+
 	"github.com/gin-gonic/gin"
 )
 
+// LanguageMiddleware sets the requested language ("lang") and its fallback chain ("lang_chain")
 func LanguageMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		lang := c.Query("lang")
 		if lang == "" {
-			lang = "en" // Varsayılan dil
+			lang = services.DefaultLanguage() // Varsayılan dil
 		}
 		c.Set("lang", lang)
+		c.Set("lang_chain", services.LanguageChain(lang))
 		c.Next()
 	}
 }
@@ -18,13 +18,10 @@ func MaintenanceMiddleware() gin.HandlerFunc {
 		}
 
 		// Dil parametresini kontrol et
-		lang := c.DefaultQuery("lang", settings.DefaultLang)
+		lang := c.DefaultQuery("lang", services.DefaultLanguage())
 
-		// Mesajı belirtilen dile göre döndür
-		message := settings.MaintenanceMsg[lang]
-		if message == "" {
-			message = settings.MaintenanceMsg[settings.DefaultLang] // Varsayılan dil
-		}
+		// Mesajı belirtilen dile göre, yoksa dil zincirindeki ilk çeviriyle döndür
+		message, _, _ := services.ResolveTranslation(settings.MaintenanceMsg, lang)
 
 		c.JSON(http.StatusServiceUnavailable, gin.H{
 			"message": message,
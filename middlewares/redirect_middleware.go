@@ -0,0 +1,50 @@
+package middlewares
+
+import (
+	"admin-panel/services"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RedirectMiddleware applies the managed redirect rules (exact, prefix, regex) before content is resolved.
+// İsteğin dili yolun ilk parçasından (/tr/...) veya lang sorgu parametresinden belirlenir.
+func RedirectMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead {
+			c.Next()
+			return
+		}
+
+		path := c.Request.URL.Path
+		rule, target := services.MatchRedirectRule(path, requestLanguage(path, c.Query("lang")))
+		if rule == nil {
+			c.Next()
+			return
+		}
+		services.RecordRedirectHit(rule.ID)
+
+		if rule.StatusCode == http.StatusGone {
+			c.AbortWithStatusJSON(http.StatusGone, gin.H{"error": "Content has been removed"})
+			return
+		}
+
+		// Hedefte sorgu yoksa isteğin sorgusu korunur (örn: utm parametreleri)
+		if query := c.Request.URL.RawQuery; query != "" && !strings.Contains(target, "?") {
+			target += "?" + query
+		}
+		c.Redirect(rule.StatusCode, target)
+		c.Abort()
+	}
+}
+
+func requestLanguage(path, queryLang string) string {
+	segment, _, _ := strings.Cut(strings.TrimPrefix(path, "/"), "/")
+	for _, lang := range services.ActiveLanguages() {
+		if lang == segment {
+			return lang
+		}
+	}
+	return queryLang
+}
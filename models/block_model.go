@@ -0,0 +1,46 @@
+package models
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+// Blok tipleri
+const (
+	BlockParagraph = "paragraph"
+	BlockHeading   = "heading"
+	BlockImage     = "image"
+	BlockGallery   = "gallery"
+	BlockQuote     = "quote"
+	BlockEmbed     = "embed"
+	BlockCode      = "code"
+	BlockCTA       = "cta"
+	BlockSlider    = "slider"
+)
+
+// ContentBlock is a typed piece of a structured post or page body
+type ContentBlock struct {
+	Type     string               `bson:"type" json:"type"`
+	Text     string               `bson:"text,omitempty" json:"text,omitempty"`           // paragraph, heading, quote: satır içi HTML; code: ham kod; cta: açıklama
+	Level    int                  `bson:"level,omitempty" json:"level,omitempty"`         // heading: 1-6
+	MediaID  *primitive.ObjectID  `bson:"media_id,omitempty" json:"media_id,omitempty"`   // image
+	MediaIDs []primitive.ObjectID `bson:"media_ids,omitempty" json:"media_ids,omitempty"` // gallery
+	Alt      string               `bson:"alt,omitempty" json:"alt,omitempty"`             // image
+	Caption  string               `bson:"caption,omitempty" json:"caption,omitempty"`     // image, gallery, embed
+	Citation string               `bson:"citation,omitempty" json:"citation,omitempty"`   // quote
+	URL      string               `bson:"url,omitempty" json:"url,omitempty"`             // embed, cta; image: medya kütüphanesinde aranacak adres
+	Language string               `bson:"language,omitempty" json:"language,omitempty"`   // code: örn "go"
+	Label    string               `bson:"label,omitempty" json:"label,omitempty"`         // cta: buton metni
+	SliderID *primitive.ObjectID  `bson:"slider_id,omitempty" json:"slider_id,omitempty"` // slider
+}
+
+// BlockConversionRequest converts between HTML and blocks; yalnızca biri doldurulur
+type BlockConversionRequest struct {
+	HTML   string         `json:"html,omitempty"`
+	Blocks []ContentBlock `json:"blocks,omitempty"`
+}
+
+// BlockConversion is the result of a conversion between HTML and blocks
+type BlockConversion struct {
+	Blocks   []ContentBlock `json:"blocks"`
+	HTML     string         `json:"html"`
+	Text     string         `json:"text"`
+	Warnings []string       `json:"warnings,omitempty"` // Bloklara dönüştürülemeyen içerik
+}
@@ -3,11 +3,16 @@ package models
 import "go.mongodb.org/mongo-driver/bson/primitive"
 
 type Category struct {
-	ID            primitive.ObjectID        `bson:"_id,omitempty" json:"id"`
-	Localizations map[string]LocalizedField `bson:"localizations" json:"localizations"` // Dil koduna göre içerik
-	Slug          map[string]string         `bson:"slug" json:"slug"`                   // Dil kodu ve slug
-	CreatedAt     primitive.DateTime        `bson:"created_at" json:"created_at"`
-	UpdatedAt     primitive.DateTime        `bson:"updated_at" json:"updated_at"`
-	CreatedBy     string                    `bson:"created_by" json:"created_by"`
-	UpdatedBy     string                    `bson:"updated_by" json:"updated_by"`
+	ID              primitive.ObjectID         `bson:"_id,omitempty" json:"id"`
+	Localizations   map[string]LocalizedField  `bson:"localizations" json:"localizations"`                           // Dil koduna göre içerik
+	TranslationMeta map[string]TranslationMeta `bson:"translation_meta,omitempty" json:"translation_meta,omitempty"` // Dil bazında çeviri takip bilgisi
+	Slug            map[string]string          `bson:"slug" json:"slug"`                                             // Dil kodu ve slug
+	CreatedAt       primitive.DateTime         `bson:"created_at" json:"created_at"`
+	UpdatedAt       primitive.DateTime         `bson:"updated_at" json:"updated_at"`
+	CreatedBy       string                     `bson:"created_by" json:"created_by"`
+	UpdatedBy       string                     `bson:"updated_by" json:"updated_by"`
+
+	// Çöp kutusu (soft delete); dolu ise içerik varsayılan sorgulardan hariç tutulur
+	DeletedAt *primitive.DateTime `bson:"deleted_at,omitempty" json:"deleted_at,omitempty"`
+	DeletedBy string              `bson:"deleted_by,omitempty" json:"deleted_by,omitempty"`
 }
@@ -17,4 +17,8 @@ type Comment struct {
 	Replies   []primitive.ObjectID `bson:"replies,omitempty" json:"replies,omitempty"`
 	CreatedAt time.Time            `bson:"created_at,omitempty" json:"created_at,omitempty"`
 	UpdatedAt time.Time            `bson:"updated_at,omitempty" json:"updated_at,omitempty"`
+
+	// Çöp kutusu (soft delete); dolu ise içerik varsayılan sorgulardan hariç tutulur
+	DeletedAt *time.Time `bson:"deleted_at,omitempty" json:"deleted_at,omitempty"`
+	DeletedBy string     `bson:"deleted_by,omitempty" json:"deleted_by,omitempty"`
 }
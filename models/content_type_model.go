@@ -0,0 +1,89 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Alan tipleri
+const (
+	FieldText     = "text"
+	FieldRichText = "rich_text"
+	FieldNumber   = "number"
+	FieldDate     = "date"
+	FieldBoolean  = "boolean"
+	FieldMedia    = "media"
+	FieldRelation = "relation"
+	FieldRepeater = "repeater"
+)
+
+// ContentType is a runtime-defined content schema (örn: events, team_members, faqs)
+type ContentType struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Key         string             `bson:"key" json:"key" binding:"required"` // API adı, örn: "events"; oluşturulduktan sonra değişmez
+	Names       map[string]string  `bson:"names" json:"names"`                // Dil koduna göre görünen ad
+	Description string             `bson:"description,omitempty" json:"description,omitempty"`
+	Fields      []ContentField     `bson:"fields" json:"fields"`
+	Public      bool               `bson:"public" json:"public"` // Yayınlanan kayıtlar herkese açık API'de sunulur
+	CreatedAt   time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt   time.Time          `bson:"updated_at" json:"updated_at"`
+	CreatedBy   string             `bson:"created_by" json:"created_by"`
+	UpdatedBy   string             `bson:"updated_by" json:"updated_by"`
+}
+
+// ContentField describes a single field of a content type
+type ContentField struct {
+	Key        string            `bson:"key" json:"key"`   // Örn: "start_date"
+	Type       string            `bson:"type" json:"type"` // text, rich_text, number, date, boolean, media, relation, repeater
+	Labels     map[string]string `bson:"labels,omitempty" json:"labels,omitempty"`
+	Localized  bool              `bson:"localized" json:"localized"` // Değer dil bazında saklanır (tekrarlayıcı alt alanlarında yok sayılır)
+	Required   bool              `bson:"required" json:"required"`
+	Multiple   bool              `bson:"multiple,omitempty" json:"multiple,omitempty"` // media/relation için birden fazla referans
+	Relation   string            `bson:"relation,omitempty" json:"relation,omitempty"` // relation: hedef içerik tipi anahtarı
+	Fields     []ContentField    `bson:"fields,omitempty" json:"fields,omitempty"`     // repeater: her öğenin alanları
+	Validation FieldValidation   `bson:"validation,omitempty" json:"validation,omitempty"`
+}
+
+// FieldValidation holds the optional validation rules of a field
+type FieldValidation struct {
+	MinLength *int     `bson:"min_length,omitempty" json:"min_length,omitempty"` // text, rich_text
+	MaxLength *int     `bson:"max_length,omitempty" json:"max_length,omitempty"`
+	Pattern   string   `bson:"pattern,omitempty" json:"pattern,omitempty"` // text; düzenli ifade
+	Options   []string `bson:"options,omitempty" json:"options,omitempty"` // text; izin verilen değerler
+	Min       *float64 `bson:"min,omitempty" json:"min,omitempty"`         // number
+	Max       *float64 `bson:"max,omitempty" json:"max,omitempty"`
+	MinItems  *int     `bson:"min_items,omitempty" json:"min_items,omitempty"` // repeater, çoklu media/relation
+	MaxItems  *int     `bson:"max_items,omitempty" json:"max_items,omitempty"`
+}
+
+// ContentEntry is a generically stored entry of a content type
+type ContentEntry struct {
+	ID            primitive.ObjectID                `bson:"_id,omitempty" json:"id"`
+	Type          string                            `bson:"type" json:"type"`
+	Status        string                            `bson:"status" json:"status"`               // draft, published
+	Data          map[string]interface{}            `bson:"data" json:"data"`                   // Dile bağlı olmayan alanlar
+	Localizations map[string]map[string]interface{} `bson:"localizations" json:"localizations"` // Dil kodu -> dile bağlı alanlar
+	PublishDate   *time.Time                        `bson:"publish_date,omitempty" json:"publish_date,omitempty"`
+	CreatedAt     time.Time                         `bson:"created_at" json:"created_at"`
+	UpdatedAt     time.Time                         `bson:"updated_at" json:"updated_at"`
+	CreatedBy     string                            `bson:"created_by" json:"created_by"`
+	UpdatedBy     string                            `bson:"updated_by" json:"updated_by"`
+}
+
+// FieldError describes why a field value or schema definition was rejected
+type FieldError struct {
+	Field   string `json:"field"` // Örn: "localizations.en.title", "data.speakers[1].name"
+	Message string `json:"message"`
+}
+
+// PublicEntry is a published content entry resolved to a single language
+type PublicEntry struct {
+	ID            primitive.ObjectID     `json:"id"`
+	Type          string                 `json:"type"`
+	Lang          string                 `json:"lang"`
+	RequestedLang string                 `json:"requested_lang"`
+	Fallback      bool                   `json:"fallback"`
+	Fields        map[string]interface{} `json:"fields"` // Dile bağlı ve bağlı olmayan alanlar birlikte
+	PublishDate   *time.Time             `json:"publish_date,omitempty"`
+}
@@ -0,0 +1,47 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Feed formats (URL'deki dosya adı)
+const (
+	FeedRSS  = "rss.xml"
+	FeedAtom = "atom.xml"
+	FeedJSON = "feed.json"
+)
+
+// FeedQuery selects the posts of a feed
+type FeedQuery struct {
+	Lang       string              // Dil kodu; yalnızca bu dilde çevirisi olan yazılar listelenir
+	CategoryID *primitive.ObjectID // Kategori akışı
+	TagID      *primitive.ObjectID // Etiket akışı
+	Limit      int                 // Öğe sayısı
+	SiteURL    string              // Mutlak bağlantılar için sitenin kök adresi
+	FeedURL    string              // Akışın kendi adresi
+}
+
+// Feed is a syndication feed independent of the output format
+type Feed struct {
+	Title       string
+	Description string
+	Lang        string
+	SiteURL     string
+	FeedURL     string
+	Updated     time.Time // En son güncellenen öğenin zamanı
+	Items       []FeedItem
+}
+
+// FeedItem is a published post in a feed
+type FeedItem struct {
+	ID         string // Kalıcı kimlik (tag URI)
+	Title      string
+	URL        string
+	Summary    string // Düz metin özet
+	Author     string
+	Categories []string
+	Published  time.Time
+	Updated    time.Time
+}
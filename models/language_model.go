@@ -8,7 +8,14 @@ type Language struct {
 	Code           string             `bson:"code" json:"code"`                       // Örnek: "en", "tr"
 	LocalizedNames map[string]string  `bson:"localized_names" json:"localized_names"` // Dil koduna göre adlar
 	IsDefault      bool               `bson:"is_default" json:"is_default"`           // Varsayılan dil mi?
+	Fallbacks      []string           `bson:"fallbacks" json:"fallbacks"`             // Çeviri yoksa sırayla denenecek diller (örn: de-AT için ["de"])
 	Enabled        bool               `bson:"enabled" json:"enabled"`                 // Aktif mi?
-	CreatedAt      primitive.DateTime `bson:"created_at" json:"created_at"`
-	UpdatedAt      primitive.DateTime `bson:"updated_at" json:"updated_at"`
+
+	// Slug üretimi
+	SlugReplacements map[string]string `bson:"slug_replacements,omitempty" json:"slug_replacements,omitempty"` // Özel değiştirmeler (örn: "&": "und")
+	SlugStopWords    []string          `bson:"slug_stop_words,omitempty" json:"slug_stop_words,omitempty"`     // Slug'dan çıkarılacak kelimeler
+	SlugMaxLength    int               `bson:"slug_max_length,omitempty" json:"slug_max_length,omitempty"`     // 0 ise varsayılan uzunluk
+
+	CreatedAt primitive.DateTime `bson:"created_at" json:"created_at"`
+	UpdatedAt primitive.DateTime `bson:"updated_at" json:"updated_at"`
 }
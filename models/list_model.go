@@ -0,0 +1,9 @@
+package models
+
+// ListResponse is the envelope returned by paginated list endpoints
+type ListResponse struct {
+	Items      interface{} `json:"items"`
+	NextCursor string      `json:"next_cursor,omitempty"` // Sonraki sayfa için opak imleç; son sayfada boş
+	Total      *int64      `json:"total,omitempty"`       // Yalnızca total=true istendiğinde
+	Limit      int         `json:"limit"`
+}
@@ -1,15 +1,20 @@
 package models
 
-import "go.mongodb.org/mongo-driver/bson/primitive"
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
 
 // LocalizedContent represents a content structure with translations
 type LocalizedContent struct {
-	ID           primitive.ObjectID        `bson:"_id,omitempty" json:"id"`
-	Translations map[string]LocalizedField `bson:"translations" json:"translations"` // Dil kodu ve içeriği
-	CreatedAt    primitive.DateTime        `bson:"created_at" json:"created_at"`
-	UpdatedAt    primitive.DateTime        `bson:"updated_at" json:"updated_at"`
-	CreatedBy    string                    `bson:"created_by" json:"created_by"`
-	UpdatedBy    string                    `bson:"updated_by" json:"updated_by"`
+	ID              primitive.ObjectID         `bson:"_id,omitempty" json:"id"`
+	Translations    map[string]LocalizedField  `bson:"translations" json:"translations"`                             // Dil kodu ve içeriği
+	TranslationMeta map[string]TranslationMeta `bson:"translation_meta,omitempty" json:"translation_meta,omitempty"` // Çeviri takip bilgisi
+	CreatedAt       primitive.DateTime         `bson:"created_at" json:"created_at"`
+	UpdatedAt       primitive.DateTime         `bson:"updated_at" json:"updated_at"`
+	CreatedBy       string                     `bson:"created_by" json:"created_by"`
+	UpdatedBy       string                     `bson:"updated_by" json:"updated_by"`
 }
 
 // LocalizedField represents a single translation field
@@ -17,11 +22,57 @@ type LocalizedField struct {
 	Title   string `bson:"title" json:"title"`
 	Content string `bson:"content" json:"content"`
 	Slug    string `bson:"slug" json:"slug"`
+
+	// Yapılandırılmış gövde; dolu ise Content kaydederken bloklardan üretilir
+	Blocks []ContentBlock `bson:"blocks,omitempty" json:"blocks,omitempty"`
+
+	// Gövde biçimi: html (varsayılan) veya markdown; markdown ise Content kaydederken Markdown alanından üretilir
+	Format   string `bson:"format,omitempty" json:"format,omitempty"`
+	Markdown string `bson:"markdown,omitempty" json:"markdown,omitempty"`
+
+	// Kaydederken düz metinden üretilir
+	Excerpt     string `bson:"excerpt,omitempty" json:"excerpt,omitempty"`
+	ReadingTime int    `bson:"reading_time,omitempty" json:"reading_time,omitempty"` // Dakika
 }
 
+// Content formats
+const (
+	FormatHTML     = "html"
+	FormatMarkdown = "markdown"
+)
+
 // MetaTag represents SEO-related metadata
 type MetaTag struct {
 	Title       string   `bson:"title" json:"title"`
 	Description string   `bson:"description" json:"description"`
 	Keywords    []string `bson:"keywords" json:"keywords"` // Dizi olarak tanımlandı
+
+	// Paylaşım görseli (medya kütüphanesi); boşsa içerikteki ilk görsel veya site logosu kullanılır
+	ImageID *primitive.ObjectID `bson:"image_id,omitempty" json:"image_id,omitempty"`
+}
+
+// Translation statuses
+const (
+	TranslationMissing  = "missing"
+	TranslationOutdated = "outdated"
+	TranslationUpToDate = "up_to_date"
+)
+
+// TranslationMeta tracks the version of a translation and the source version it was made from
+type TranslationMeta struct {
+	Hash       string    `bson:"hash" json:"hash"`               // Çevirinin içerik özeti
+	SourceHash string    `bson:"source_hash" json:"source_hash"` // Çeviri yapılırken kaynak dilin içerik özeti
+	UpdatedAt  time.Time `bson:"updated_at" json:"updated_at"`
+}
+
+// TranslationStatus is the translation state of a document in one language
+type TranslationStatus struct {
+	Type            string             `json:"type"`
+	ID              primitive.ObjectID `json:"id"`
+	Lang            string             `json:"lang"`
+	SourceLang      string             `json:"source_lang"`
+	Status          string             `json:"status"` // missing, outdated, up_to_date
+	Title           string             `json:"title"`  // Kaynak dildeki başlık
+	UpdatedAt       *time.Time         `json:"updated_at,omitempty"`
+	SourceUpdatedAt *time.Time         `json:"source_updated_at,omitempty"`
 }
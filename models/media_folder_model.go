@@ -0,0 +1,36 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MediaFolder is a virtual folder of the media library; dosyalar depolamada taşınmaz
+type MediaFolder struct {
+	ID        primitive.ObjectID   `bson:"_id,omitempty" json:"id"`
+	Name      string               `bson:"name" json:"name" binding:"required" example:"Kampanyalar"`
+	ParentID  *primitive.ObjectID  `bson:"parent_id" json:"parent_id"`                   // Boşsa kök dizindedir
+	Ancestors []primitive.ObjectID `bson:"ancestors" json:"ancestors"`                   // Kökten üst klasöre kadar
+	Path      string               `bson:"path" json:"path" example:"/Kampanyalar/2024"` // Klasör adlarından oluşan tam yol
+	CreatedAt time.Time            `bson:"created_at" json:"created_at"`
+	UpdatedAt time.Time            `bson:"updated_at" json:"updated_at"`
+	CreatedBy string               `bson:"created_by" json:"created_by"`
+	UpdatedBy string               `bson:"updated_by" json:"updated_by"`
+}
+
+// MediaFolderRename renames a folder
+type MediaFolderRename struct {
+	Name string `json:"name" binding:"required" example:"Kampanyalar 2025"`
+}
+
+// MediaFolderMove moves a folder under another folder
+type MediaFolderMove struct {
+	ParentID *primitive.ObjectID `json:"parent_id"` // null: kök dizin
+}
+
+// MediaMove moves media items into a folder
+type MediaMove struct {
+	IDs      []primitive.ObjectID `json:"ids" binding:"required"`
+	FolderID *primitive.ObjectID  `json:"folder_id"` // null: kök dizin
+}
@@ -1,13 +1,145 @@
 package models
 
-import "go.mongodb.org/mongo-driver/bson/primitive"
+import (
+	"fmt"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
 
 type Media struct {
 	ID         primitive.ObjectID `bson:"_id,omitempty" json:"id"`
 	FileName   string             `bson:"file_name" json:"file_name"`
-	FilePath   string             `bson:"file_path" json:"file_path"`
-	FileType   string             `bson:"file_type" json:"file_type"`
-	FileSize   int64              `json:"file_size" example:"102400"`
+	FilePath   string             `bson:"file_path" json:"file_path"`                                         // Yerel depolamadaki dosya yolu; diğer sürücülerde boş
+	FileType   string             `bson:"file_type" json:"file_type"`                                         // Uzantı, örn: ".png"
+	MimeType   string             `bson:"mime_type,omitempty" json:"mime_type,omitempty" example:"image/png"` // İçerikten tespit edilen tip
+	FileSize   int64              `bson:"filesize" json:"file_size" example:"102400"`
 	UploadedAt int64              `bson:"uploaded_at" json:"uploaded_at"`
-	UploadedBy string             `json:"uploaded_by" example:"admin"`
+	UploadedBy string             `bson:"uploadedby" json:"uploaded_by" example:"admin"`
+
+	// Dosyanın bulunduğu depolama sürücüsü ve anahtarı; eski kayıtlarda boştur (local, file_path)
+	Storage    string `bson:"storage,omitempty" json:"storage,omitempty" example:"s3"`
+	StorageKey string `bson:"storage_key,omitempty" json:"storage_key,omitempty" example:"logo.png"`
+	URL        string `bson:"url,omitempty" json:"url,omitempty" example:"/uploads/logo.png"`
+
+	// İçeriğin SHA-256 özeti (hex); aynı dosyanın tekrar yüklenmesini tespit eder. Bağlı kopyalar aynı dosyayı paylaşır
+	ContentHash string `bson:"content_hash,omitempty" json:"content_hash,omitempty"`
+	// Yalnızca dosyayı yazan özgün kayıtta bulunan, benzersiz indeksli özet; eşzamanlı aynı yüklemelerde tek kayıt kalır
+	DedupeKey string `bson:"dedupe_key,omitempty" json:"-"`
+
+	// Düzenleme bilgileri; klasör boşsa medya kök dizindedir
+	FolderID      *primitive.ObjectID          `bson:"folder_id,omitempty" json:"folder_id,omitempty"`
+	Localizations map[string]MediaLocalization `bson:"localizations,omitempty" json:"localizations,omitempty"` // Dil koduna göre alt metin, açıklama ve başlık
+	Tags          []string                     `bson:"tags,omitempty" json:"tags,omitempty" example:"kapak,kampanya"`
+	FocalPoint    *MediaFocalPoint             `bson:"focal_point,omitempty" json:"focal_point,omitempty"` // Kırpmada korunacak nokta
+	UpdatedAt     int64                        `bson:"updated_at,omitempty" json:"updated_at,omitempty"`
+	UpdatedBy     string                       `bson:"updated_by,omitempty" json:"updated_by,omitempty"`
+
+	// Görsel bilgileri; görsel olmayan dosyalarda boştur
+	Width         int              `bson:"width,omitempty" json:"width,omitempty" example:"1920"`
+	Height        int              `bson:"height,omitempty" json:"height,omitempty" example:"1080"`
+	DominantColor string           `bson:"dominant_color,omitempty" json:"dominant_color,omitempty" example:"#3a6ea5"`
+	Renditions    []MediaRendition `bson:"renditions,omitempty" json:"renditions,omitempty"`
+
+	// Çöp kutusu (soft delete); dolu ise içerik varsayılan sorgulardan hariç tutulur
+	DeletedAt *primitive.DateTime `bson:"deleted_at,omitempty" json:"deleted_at,omitempty"`
+	DeletedBy string              `bson:"deleted_by,omitempty" json:"deleted_by,omitempty"`
+}
+
+// Aynı içerik tekrar yüklendiğinde yapılacak işlem
+const (
+	MediaDedupeReuse = "reuse" // Mevcut medya döner (varsayılan)
+	MediaDedupeLink  = "link"  // Aynı dosyayı kullanan yeni bir medya kaydı oluşturulur
+)
+
+// MediaLocalization is the accessibility text of a media item in one language
+type MediaLocalization struct {
+	Alt     string `bson:"alt,omitempty" json:"alt,omitempty" example:"Gün batımında sahil"`
+	Caption string `bson:"caption,omitempty" json:"caption,omitempty"`
+	Title   string `bson:"title,omitempty" json:"title,omitempty"`
+}
+
+// MediaFocalPoint is the point of an image kept visible when it is cropped; (0,0) sol üst, (1,1) sağ alt köşedir
+type MediaFocalPoint struct {
+	X float64 `bson:"x" json:"x" example:"0.5"`
+	Y float64 `bson:"y" json:"y" example:"0.35"`
+}
+
+// MediaMetadataUpdate changes the metadata of a media item; gönderilmeyen alanlar değişmez
+type MediaMetadataUpdate struct {
+	Localizations   *map[string]MediaLocalization `json:"localizations,omitempty"` // Gönderilirse tüm dillerin yerine geçer
+	Tags            *[]string                     `json:"tags,omitempty"`
+	FocalPoint      *MediaFocalPoint              `json:"focal_point,omitempty"`
+	ClearFocalPoint bool                          `json:"clear_focal_point,omitempty"`
+}
+
+// MediaRendition is a resized copy of an uploaded image
+type MediaRendition struct {
+	Name       string `bson:"name" json:"name" example:"medium"`
+	Format     string `bson:"format" json:"format" example:"webp"` // jpeg, png veya webp
+	Width      int    `bson:"width" json:"width" example:"800"`
+	Height     int    `bson:"height" json:"height" example:"450"`
+	StorageKey string `bson:"storage_key" json:"storage_key"` // Özgün dosyayla aynı sürücüde
+	URL        string `bson:"url" json:"url"`
+	FileSize   int64  `bson:"file_size" json:"file_size"`
+}
+
+// MediaMigrationResult is the outcome of moving media files between storage backends
+type MediaMigrationResult struct {
+	From    string                  `json:"from"`
+	To      string                  `json:"to"`
+	Moved   int                     `json:"moved"`
+	Skipped int                     `json:"skipped"` // Dosyası kaynakta bulunamayanlar
+	Failed  []MediaMigrationFailure `json:"failed,omitempty"`
+}
+
+// MediaMigrationFailure is a media item that could not be moved
+type MediaMigrationFailure struct {
+	ID    primitive.ObjectID `json:"id"`
+	Error string             `json:"error"`
+}
+
+// Görsel sığdırma kipleri
+const (
+	ImageFitContain = "contain" // Oran korunur, kutunun içine sığar
+	ImageFitCover   = "cover"   // Kutuyu doldurur, taşan kısım ortadan kırpılır
+	ImageFitFill    = "fill"    // Oran korunmadan kutuya gerilir
+)
+
+// ImageRenditionSpec describes a rendition generated for every uploaded image
+type ImageRenditionSpec struct {
+	Name   string
+	Width  int // 0: yüksekliğe göre orantılı
+	Height int // 0: genişliğe göre orantılı
+	Fit    string
+}
+
+// ImageTransform is the parameter set of an on-demand resize (örn: "w_800,h_600,fit_cover")
+type ImageTransform struct {
+	Width   int    `json:"width,omitempty"`
+	Height  int    `json:"height,omitempty"`
+	Fit     string `json:"fit,omitempty"`
+	Format  string `json:"format,omitempty"`  // Boşsa özgün biçim
+	Quality int    `json:"quality,omitempty"` // JPEG kalitesi (1-100)
+}
+
+// String returns the canonical form of the transform; imza ve önbellek anahtarı bu biçimden üretilir
+func (t ImageTransform) String() string {
+	var parts []string
+	if t.Width > 0 {
+		parts = append(parts, fmt.Sprintf("w_%d", t.Width))
+	}
+	if t.Height > 0 {
+		parts = append(parts, fmt.Sprintf("h_%d", t.Height))
+	}
+	if t.Fit != "" {
+		parts = append(parts, "fit_"+t.Fit)
+	}
+	if t.Format != "" {
+		parts = append(parts, "f_"+t.Format)
+	}
+	if t.Quality > 0 {
+		parts = append(parts, fmt.Sprintf("q_%d", t.Quality))
+	}
+	return strings.Join(parts, ",")
 }
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Medya kullanan kaynak tipleri
+const (
+	MediaSourcePosts    = "posts"
+	MediaSourcePages    = "pages"
+	MediaSourceSliders  = "sliders"
+	MediaSourceSettings = "settings"
+	MediaSourceUsers    = "users"
+)
+
+// MediaReference records one place a media item is used
+type MediaReference struct {
+	MediaID    primitive.ObjectID `bson:"media_id" json:"media_id"`
+	SourceType string             `bson:"source_type" json:"source_type" example:"posts"`
+	SourceID   primitive.ObjectID `bson:"source_id" json:"source_id"`
+	Field      string             `bson:"field" json:"field" example:"localizations.tr.content"`       // Örn: images[2], logo_url, avatar_url
+	Label      string             `bson:"label,omitempty" json:"label,omitempty" example:"Hakkımızda"` // Kaynağın başlığı veya adı
+	UpdatedAt  time.Time          `bson:"updated_at" json:"updated_at"`
+}
+
+// MediaReferenceRebuildResult summarizes a full rebuild of the reference index
+type MediaReferenceRebuildResult struct {
+	Sources    int `json:"sources"`    // Taranan kaynak sayısı
+	References int `json:"references"` // Kaydedilen kullanım sayısı
+	Hashed     int `json:"hashed"`     // İçerik özeti eklenen eski medya sayısı
+}
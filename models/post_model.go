@@ -8,16 +8,27 @@ import (
 
 // Post represents a blog post or article
 type Post struct {
-	ID            primitive.ObjectID        `bson:"_id,omitempty" json:"id"`
-	Localizations map[string]LocalizedField `bson:"localizations" json:"localizations"` // Dil koduna göre içerik
-	Status        string                    `bson:"status" json:"status"`               // draft, published, scheduled
-	CategoryIDs   []primitive.ObjectID      `bson:"category_ids" json:"category_ids"`
-	TagIDs        []primitive.ObjectID      `bson:"tag_ids" json:"tag_ids"`
-	PublishDate   *time.Time                `bson:"publish_date,omitempty" json:"publish_date,omitempty"` // Yayınlanma tarihi
-	AuthorID      primitive.ObjectID        `bson:"author_id" json:"author_id"`
-	MetaTags      map[string]MetaTag        `bson:"meta_tags" json:"meta_tags"` // Dil kodu ve SEO bilgileri
-	CreatedAt     time.Time                 `bson:"created_at" json:"created_at"`
-	UpdatedAt     time.Time                 `bson:"updated_at" json:"updated_at"`
-	CreatedBy     string                    `bson:"created_by" json:"created_by"`
-	UpdatedBy     string                    `bson:"updated_by" json:"updated_by"`
+	ID              primitive.ObjectID         `bson:"_id,omitempty" json:"id"`
+	Localizations   map[string]LocalizedField  `bson:"localizations" json:"localizations"`                           // Dil koduna göre içerik
+	TranslationMeta map[string]TranslationMeta `bson:"translation_meta,omitempty" json:"translation_meta,omitempty"` // Dil bazında çeviri takip bilgisi
+	Status          string                     `bson:"status" json:"status"`                                         // draft, published, scheduled, unpublished
+	CategoryIDs     []primitive.ObjectID       `bson:"category_ids" json:"category_ids"`
+	TagIDs          []primitive.ObjectID       `bson:"tag_ids" json:"tag_ids"`
+	PublishDate     *time.Time                 `bson:"publish_date,omitempty" json:"publish_date,omitempty"`     // Yayınlanma tarihi
+	UnpublishDate   *time.Time                 `bson:"unpublish_date,omitempty" json:"unpublish_date,omitempty"` // Yayından kaldırılma tarihi (opsiyonel)
+	AuthorID        primitive.ObjectID         `bson:"author_id" json:"author_id"`
+	MetaTags        map[string]MetaTag         `bson:"meta_tags" json:"meta_tags"`                               // Dil kodu ve SEO bilgileri
+	ReviewComment   string                     `bson:"review_comment,omitempty" json:"review_comment,omitempty"` // Son iş akışı yorumu (örn: red gerekçesi)
+	ReviewedBy      string                     `bson:"reviewed_by,omitempty" json:"reviewed_by,omitempty"`       // Son iş akışı adımını yapan kullanıcı
+	CreatedAt       time.Time                  `bson:"created_at" json:"created_at"`
+	UpdatedAt       time.Time                  `bson:"updated_at" json:"updated_at"`
+	CreatedBy       string                     `bson:"created_by" json:"created_by"`
+	UpdatedBy       string                     `bson:"updated_by" json:"updated_by"`
+
+	// Dil bazında son SEO denetimi sonucu (liste görünümleri için)
+	SEOAudit map[string]SEOAudit `bson:"seo_audit,omitempty" json:"seo_audit,omitempty"`
+
+	// Çöp kutusu (soft delete); dolu ise içerik varsayılan sorgulardan hariç tutulur
+	DeletedAt *time.Time `bson:"deleted_at,omitempty" json:"deleted_at,omitempty"`
+	DeletedBy string     `bson:"deleted_by,omitempty" json:"deleted_by,omitempty"`
 }
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// PreviewToken records a signed preview link so that it can be listed and revoked
+type PreviewToken struct {
+	ID         primitive.ObjectID  `bson:"_id,omitempty" json:"id"`                            // JWT "jti" değeri
+	EntityType string              `bson:"entity_type" json:"entity_type"`                     // posts, pages
+	EntityID   primitive.ObjectID  `bson:"entity_id" json:"entity_id"`                         // Önizlenen doküman
+	Lang       string              `bson:"lang" json:"lang"`                                   // Önizlenen dil
+	RevisionID *primitive.ObjectID `bson:"revision_id,omitempty" json:"revision_id,omitempty"` // Boşsa dokümanın güncel hali
+	CreatedBy  string              `bson:"created_by" json:"created_by"`
+	CreatedAt  time.Time           `bson:"created_at" json:"created_at"`
+	ExpiresAt  time.Time           `bson:"expires_at" json:"expires_at"`
+	RevokedAt  *time.Time          `bson:"revoked_at,omitempty" json:"revoked_at,omitempty"`
+	RevokedBy  string              `bson:"revoked_by,omitempty" json:"revoked_by,omitempty"`
+}
+
+// PreviewRequest is the payload for creating a preview link
+type PreviewRequest struct {
+	Type       string              `json:"type" binding:"required"` // posts, pages
+	ID         primitive.ObjectID  `json:"id" binding:"required"`
+	Lang       string              `json:"lang" binding:"required"`
+	RevisionID *primitive.ObjectID `json:"revision_id,omitempty"`
+	ExpiresIn  int64               `json:"expires_in,omitempty"` // Saniye; boşsa PREVIEW_TOKEN_TTL
+}
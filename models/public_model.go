@@ -0,0 +1,66 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// PublicContent is a published post or page resolved to a single language
+type PublicContent struct {
+	ID             primitive.ObjectID   `json:"id"`
+	Lang           string               `json:"lang"`           // İçeriğin döndürüldüğü dil
+	RequestedLang  string               `json:"requested_lang"` // İstenen dil
+	Fallback       bool                 `json:"fallback"`       // Çeviri eksik, dil zincirindeki yedek dil kullanıldı
+	Slug           string               `json:"slug"`
+	Title          string               `json:"title"`
+	Content        string               `json:"content"`
+	Blocks         []ContentBlock       `json:"blocks,omitempty"` // Yapılandırılmış gövde (varsa)
+	Excerpt        string               `json:"excerpt,omitempty"`
+	ReadingTime    int                  `json:"reading_time,omitempty"` // Dakika
+	MetaTags       MetaTag              `json:"meta_tags"`
+	CategoryIDs    []primitive.ObjectID `json:"category_ids,omitempty"`
+	TagIDs         []primitive.ObjectID `json:"tag_ids,omitempty"`
+	PublishDate    *time.Time           `json:"publish_date,omitempty"`
+	AvailableLangs map[string]string    `json:"available_langs"` // Dil kodu -> slug (hreflang için)
+}
+
+// PublicCategory is a category resolved to a single language
+type PublicCategory struct {
+	ID          primitive.ObjectID `json:"id"`
+	Lang        string             `json:"lang"`
+	Slug        string             `json:"slug"`
+	Title       string             `json:"title"`
+	Description string             `json:"description"`
+}
+
+// PublicMenuItem is a visible frontend menu entry
+type PublicMenuItem struct {
+	ID       primitive.ObjectID  `json:"id"`
+	Title    string              `json:"title"`
+	URL      string              `json:"url"`
+	ParentID *primitive.ObjectID `json:"parent_id,omitempty"`
+	Order    int                 `json:"order"`
+}
+
+// PublicSlider is an active slider
+type PublicSlider struct {
+	ID     primitive.ObjectID `json:"id"`
+	Name   string             `json:"name"`
+	Images []string           `json:"images"`
+}
+
+// PublicSettings contains the settings that are safe to expose to visitors
+type PublicSettings struct {
+	Lang           string                 `json:"lang"`
+	Title          string                 `json:"title"`
+	Description    string                 `json:"description"`
+	MetaTags       MetaTag                `json:"meta_tags"`
+	SocialMedia    map[string]SocialMedia `json:"social_media"` // Yalnızca aktif bağlantılar
+	ContactInfo    map[string]string      `json:"contact_info"`
+	SupportedLangs []string               `json:"supported_langs"`
+	DefaultLang    string                 `json:"default_lang"`
+	AnalyticsCode  string                 `json:"analytics_code"`
+	LogoURL        string                 `json:"logo_url"`
+	FaviconURL     string                 `json:"favicon_url"`
+}
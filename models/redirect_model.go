@@ -0,0 +1,50 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Redirect points an old content slug to the post or page that used it
+type Redirect struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Lang       string             `bson:"lang" json:"lang"`
+	FromSlug   string             `bson:"from_slug" json:"from_slug"`
+	ToSlug     string             `bson:"to_slug" json:"to_slug"`         // Hedefin en güncel slug'ı
+	EntityType string             `bson:"entity_type" json:"entity_type"` // posts, pages
+	EntityID   primitive.ObjectID `bson:"entity_id" json:"entity_id"`
+	StatusCode int                `bson:"status_code" json:"status_code"` // 301
+	CreatedAt  time.Time          `bson:"created_at" json:"created_at"`
+}
+
+// Yönlendirme kuralı eşleşme tipleri
+const (
+	RedirectMatchExact  = "exact"
+	RedirectMatchPrefix = "prefix"
+	RedirectMatchRegex  = "regex"
+)
+
+// RedirectRule is a manually managed redirect, örn: eski bir siteden taşınan adresler
+type RedirectRule struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Source     string             `bson:"source" json:"source"`                     // exact/prefix: yol (örn: "/blog/eski"); regex: düzenli ifade (örn: "^/haber/(\\d+)$")
+	MatchType  string             `bson:"match_type" json:"match_type"`             // exact, prefix, regex
+	Target     string             `bson:"target,omitempty" json:"target,omitempty"` // Site yolu veya mutlak adres; prefix'te kalan yol eklenir, regex'te $1 kullanılabilir
+	StatusCode int                `bson:"status_code" json:"status_code"`           // 301 (varsayılan), 302, 410
+	Lang       string             `bson:"lang" json:"lang,omitempty"`               // Doluysa yalnızca bu dildeki isteklere uygulanır
+	Active     bool               `bson:"active" json:"active"`
+	Note       string             `bson:"note,omitempty" json:"note,omitempty"`
+	Hits       int64              `bson:"hits" json:"hits"`
+	LastHitAt  *time.Time         `bson:"last_hit_at,omitempty" json:"last_hit_at,omitempty"`
+	CreatedAt  time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt  time.Time          `bson:"updated_at" json:"updated_at"`
+	CreatedBy  string             `bson:"created_by" json:"created_by"`
+	UpdatedBy  string             `bson:"updated_by" json:"updated_by"`
+}
+
+// RedirectImportResult summarizes a CSV import of redirect rules
+type RedirectImportResult struct {
+	Created int `json:"created"`
+	Updated int `json:"updated"`
+}
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Revision represents an immutable snapshot of a post or page
+type Revision struct {
+	ID           primitive.ObjectID  `bson:"_id,omitempty" json:"id"`
+	EntityType   string              `bson:"entity_type" json:"entity_type"`                         // posts, pages
+	EntityID     primitive.ObjectID  `bson:"entity_id" json:"entity_id"`                             // Revizyonun ait olduğu doküman
+	Number       int                 `bson:"number" json:"number"`                                   // Doküman bazında artan revizyon numarası
+	Snapshot     bson.M              `bson:"snapshot" json:"snapshot"`                               // Dokümanın o anki tam hali
+	ChangedLangs []string            `bson:"changed_langs" json:"changed_langs"`                     // Değişen dil kodları
+	Note         string              `bson:"note,omitempty" json:"note,omitempty"`                   // Örnek: initial, update, restore
+	RestoredFrom *primitive.ObjectID `bson:"restored_from,omitempty" json:"restored_from,omitempty"` // Geri yüklenen revizyon
+	UserID       primitive.ObjectID  `bson:"user_id" json:"user_id"`                                 // Değişikliği yapan kullanıcı
+	Username     string              `bson:"username" json:"username"`
+	CreatedAt    time.Time           `bson:"created_at" json:"created_at"`
+}
+
+// FieldChange represents a single field-level difference between two revisions
+type FieldChange struct {
+	Path string      `json:"path"`           // Örnek: localizations.en.title
+	Kind string      `json:"kind"`           // added, removed, changed
+	From interface{} `json:"from,omitempty"` // Eski değer
+	To   interface{} `json:"to,omitempty"`   // Yeni değer
+}
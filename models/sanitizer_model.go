@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+// Temizleme politikaları (alan tipine göre)
+const (
+	PolicyRichText  = "rich_text"  // Yazı, sayfa ve çeviri içerikleri (zengin editör)
+	PolicyComment   = "comment"    // Yorumlar
+	PolicyPlainText = "plain_text" // İletişim mesajları; tüm etiketler kaldırılır, metin kaçışlanmadan saklanır
+)
+
+// SanitizerPolicy is an allow-list of the HTML a field type may contain
+type SanitizerPolicy struct {
+	Name              string              `bson:"name" json:"name"`
+	Elements          map[string][]string `bson:"elements" json:"elements"`                   // İzin verilen etiket -> izin verilen öznitelikler
+	GlobalAttributes  []string            `bson:"global_attributes" json:"global_attributes"` // Tüm izinli etiketlerde geçerli öznitelikler
+	URLSchemes        []string            `bson:"url_schemes" json:"url_schemes"`             // href/src için izinli şemalar, örn: https, mailto
+	AllowRelativeURLs bool                `bson:"allow_relative_urls" json:"allow_relative_urls"`
+	LinkRel           string              `bson:"link_rel,omitempty" json:"link_rel,omitempty"` // Bağlantılara zorla eklenen rel, örn: "nofollow ugc"
+	UpdatedAt         time.Time           `bson:"updated_at,omitempty" json:"updated_at,omitempty"`
+	UpdatedBy         string              `bson:"updated_by,omitempty" json:"updated_by,omitempty"`
+}
+
+// SanitizeReport lists the markup removed from one field while saving
+type SanitizeReport struct {
+	Field   string   `json:"field"`   // Örn: "localizations.tr.content"
+	Policy  string   `json:"policy"`  // Uygulanan politika
+	Removed []string `json:"removed"` // Örn: "<script> element", "onclick attribute on <a>"
+}
@@ -0,0 +1,65 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// SearchQuery represents the parameters of a full-text search
+type SearchQuery struct {
+	Query      string              // Aranan ifade
+	Lang       string              // Dil kodu (örn: "en", "tr")
+	Types      []string            // posts, pages, categories
+	Status     string              // Durum filtresi
+	CategoryID *primitive.ObjectID // Kategori filtresi (yalnızca posts)
+	DateFrom   *time.Time          // Yayın tarihi başlangıcı
+	DateTo     *time.Time          // Yayın tarihi bitişi
+	Page       int
+	Limit      int
+}
+
+// SearchResult represents a single ranked search hit
+type SearchResult struct {
+	Type        string             `json:"type"` // posts, pages, categories
+	ID          primitive.ObjectID `json:"id"`
+	Title       string             `json:"title"`
+	Slug        string             `json:"slug"`
+	Snippet     string             `json:"snippet"` // <mark> ile vurgulanmış özet
+	Score       float64            `json:"score"`
+	Status      string             `json:"status,omitempty"`
+	PublishDate *time.Time         `json:"publish_date,omitempty"`
+}
+
+// FacetCount represents the number of hits for a facet value
+type FacetCount struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
+}
+
+// SearchResponse represents a page of search results with facets
+type SearchResponse struct {
+	Query   string                  `json:"query"`
+	Lang    string                  `json:"lang"`
+	Total   int                     `json:"total"`
+	Page    int                     `json:"page"`
+	Limit   int                     `json:"limit"`
+	Results []SearchResult          `json:"results"`
+	Facets  map[string][]FacetCount `json:"facets"` // type, status, category, date (YYYY-MM)
+}
+
+// SearchDocument represents one language of a post, page or category in the search index
+type SearchDocument struct {
+	ID          string               `bson:"_id" json:"id"` // type:entity_id:lang
+	Type        string               `bson:"type" json:"type"`
+	EntityID    primitive.ObjectID   `bson:"entity_id" json:"entity_id"`
+	Lang        string               `bson:"lang" json:"lang"`
+	Language    string               `bson:"language" json:"language"` // MongoDB metin dili (örn: "english", "turkish", "none")
+	Title       string               `bson:"title" json:"title"`
+	Content     string               `bson:"content" json:"content"` // HTML'den arındırılmış düz metin
+	Slug        string               `bson:"slug" json:"slug"`
+	Status      string               `bson:"status,omitempty" json:"status,omitempty"`
+	CategoryIDs []primitive.ObjectID `bson:"category_ids,omitempty" json:"category_ids,omitempty"`
+	PublishDate *time.Time           `bson:"publish_date,omitempty" json:"publish_date,omitempty"`
+	UpdatedAt   time.Time            `bson:"updated_at" json:"updated_at"`
+}
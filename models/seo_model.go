@@ -0,0 +1,126 @@
+package models
+
+import "time"
+
+// SEO kaynak tipleri
+const (
+	SEOTypePost     = "post"
+	SEOTypePage     = "page"
+	SEOTypeCategory = "category"
+)
+
+// SEOSource carries the resolved values the head metadata of a post, page or category is built from
+type SEOSource struct {
+	Type        string
+	Lang        string
+	SiteURL     string
+	SiteName    string
+	Title       string
+	Description string
+	Keywords    []string
+	Path        string            // Örn: "/tr/hakkimizda"
+	Alternates  map[string]string // hreflang -> yol (x-default dahil)
+	Image       *SEOImage
+	Logo        string   // Site logosu (mutlak adres veya site yolu)
+	SameAs      []string // Organizasyonun sosyal medya adresleri
+	TwitterSite string   // Örn: "@kwbsite"
+	Author      string
+	Section     string   // Yazının ilk kategorisi
+	Tags        []string // Etiket adları
+	Breadcrumbs []SEOBreadcrumb
+	Published   *time.Time
+	Modified    time.Time
+}
+
+// SEOBreadcrumb is a step of the breadcrumb trail; son adım içeriğin kendisidir
+type SEOBreadcrumb struct {
+	Name string
+	Path string
+}
+
+// SEOImage is the sharing image of the content
+type SEOImage struct {
+	URL  string `json:"url"`
+	Alt  string `json:"alt,omitempty"`
+	Type string `json:"type,omitempty"` // MIME tipi
+}
+
+// SEOAlternate is a hreflang link of the content
+type SEOAlternate struct {
+	HrefLang string `json:"hreflang"`
+	Href     string `json:"href"`
+}
+
+// SEOProperty is an OpenGraph (<meta property>) or Twitter Card (<meta name>) tag
+type SEOProperty struct {
+	Property string `json:"property"`
+	Content  string `json:"content"`
+}
+
+// SEOMetadata is the final head metadata of a post, page or category in one language
+type SEOMetadata struct {
+	Lang        string                   `json:"lang"`
+	Title       string                   `json:"title"`
+	Description string                   `json:"description"`
+	Keywords    []string                 `json:"keywords,omitempty"`
+	Canonical   string                   `json:"canonical"`
+	Alternates  []SEOAlternate           `json:"alternates"`
+	Image       *SEOImage                `json:"image,omitempty"`
+	OpenGraph   []SEOProperty            `json:"open_graph"`
+	Twitter     []SEOProperty            `json:"twitter"`
+	JSONLD      []map[string]interface{} `json:"json_ld"` // schema.org nesneleri
+}
+
+// SEO denetim sonuçları
+const (
+	AuditGood    = "good"
+	AuditWarning = "warning"
+	AuditError   = "error"
+)
+
+// SEOAuditInput is a localized post or page prepared for the SEO audit
+type SEOAuditInput struct {
+	Lang            string
+	SiteURL         string // İç ve dış bağlantıları ayırmak için
+	Title           string // Çözümlenmiş başlık (meta başlık veya içerik başlığı)
+	Description     string // Çözümlenmiş açıklama
+	MetaTitle       string // Öğenin kendi meta başlığı
+	MetaDescription string
+	Slug            string
+	Content         string   // HTML gövde
+	Keyword         string   // Odak anahtar kelime
+	KeywordSlug     string   // Anahtar kelimenin dile göre slug hali
+	DuplicateTitles []string // Aynı başlığı kullanan içerikler (örn: "posts:<id>")
+	DuplicateSlugs  []string
+}
+
+// SEOFinding is the result of one audit check with an actionable message
+type SEOFinding struct {
+	Check   string `bson:"check" json:"check"`
+	Status  string `bson:"status" json:"status"` // good, warning, error
+	Message string `bson:"message" json:"message"`
+}
+
+// SEOAuditStats are the measurements the audit is based on
+type SEOAuditStats struct {
+	TitleLength         int     `bson:"title_length" json:"title_length"`
+	DescriptionLength   int     `bson:"description_length" json:"description_length"`
+	WordCount           int     `bson:"word_count" json:"word_count"`
+	Headings            int     `bson:"headings" json:"headings"`
+	Images              int     `bson:"images" json:"images"`
+	ImagesWithoutAlt    int     `bson:"images_without_alt" json:"images_without_alt"`
+	InternalLinks       int     `bson:"internal_links" json:"internal_links"`
+	ExternalLinks       int     `bson:"external_links" json:"external_links"`
+	AvgSentenceLength   float64 `bson:"avg_sentence_length" json:"avg_sentence_length"`     // Kelime
+	LongSentencePercent float64 `bson:"long_sentence_percent" json:"long_sentence_percent"` // 25 kelimeden uzun cümleler
+}
+
+// SEOAudit is the last SEO analysis of a post or page in one language
+type SEOAudit struct {
+	Lang      string        `bson:"lang" json:"lang"`
+	Score     int           `bson:"score" json:"score"` // 0-100
+	Keyword   string        `bson:"keyword,omitempty" json:"keyword,omitempty"`
+	Findings  []SEOFinding  `bson:"findings" json:"findings"`
+	Stats     SEOAuditStats `bson:"stats" json:"stats"`
+	AuditedAt time.Time     `bson:"audited_at" json:"audited_at"`
+}
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Bir site haritası dosyasındaki azami URL sayısı (sitemaps.org sınırı)
+const SitemapMaxURLs = 50000
+
+// SitemapEntry is one URL of the sitemaps; içerik değiştikçe ilgili kayıtlar yeniden üretilir
+type SitemapEntry struct {
+	ID            string             `bson:"_id" json:"id"` // <type>:<entity_id>:<lang>
+	Type          string             `bson:"type" json:"type"`
+	EntityID      primitive.ObjectID `bson:"entity_id" json:"entity_id"`
+	Lang          string             `bson:"lang" json:"lang"`
+	Path          string             `bson:"path" json:"path"`             // Örn: "/tr/hakkimizda"
+	Alternates    map[string]string  `bson:"alternates" json:"alternates"` // hreflang -> yol (x-default dahil)
+	LastMod       time.Time          `bson:"lastmod" json:"lastmod"`
+	PublishDate   *time.Time         `bson:"publish_date,omitempty" json:"publish_date,omitempty"`
+	UnpublishDate *time.Time         `bson:"unpublish_date,omitempty" json:"unpublish_date,omitempty"`
+	GeneratedAt   time.Time          `bson:"generated_at" json:"generated_at"`
+}
+
+// SitemapURL is a <url> element of a sitemap with absolute addresses
+type SitemapURL struct {
+	Loc        string
+	LastMod    time.Time
+	Alternates map[string]string // hreflang -> mutlak adres
+}
+
+// SitemapRef is a child sitemap listed in the sitemap index
+type SitemapRef struct {
+	Loc     string
+	LastMod time.Time
+}
@@ -6,4 +6,8 @@ type Tag struct {
 	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
 	Name      string             `bson:"name" json:"name" binding:"required"`
 	CreatedAt int64              `bson:"created_at" json:"created_at"`
+
+	// Çöp kutusu (soft delete); dolu ise içerik varsayılan sorgulardan hariç tutulur
+	DeletedAt *primitive.DateTime `bson:"deleted_at,omitempty" json:"deleted_at,omitempty"`
+	DeletedBy string              `bson:"deleted_by,omitempty" json:"deleted_by,omitempty"`
 }
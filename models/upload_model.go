@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// UploadRule allows one MIME type ("image/png") or group ("image/*") up to a size
+type UploadRule struct {
+	MimeType string `bson:"mime_type" json:"mime_type"`
+	MaxSize  int64  `bson:"max_size" json:"max_size"` // Bayt
+}
+
+// UploadPolicy is the allow-list of the files a role may upload; kullanıcının tüm rollerinin kuralları birleştirilir
+type UploadPolicy struct {
+	Role      string       `bson:"role" json:"role"`
+	Rules     []UploadRule `bson:"rules" json:"rules"`
+	UpdatedAt time.Time    `bson:"updated_at,omitempty" json:"updated_at,omitempty"`
+	UpdatedBy string       `bson:"updated_by,omitempty" json:"updated_by,omitempty"`
+}
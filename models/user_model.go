@@ -12,7 +12,8 @@ type User struct {
 	FullName          string             `bson:"full_name" json:"full_name"`                // Otomatik oluşturulan tam ad
 	Email             string             `bson:"email" json:"email" binding:"required,email"`
 	PhoneNumber       string             `bson:"phone_number" json:"phone_number" binding:"omitempty,e164"`
-	PreferredLanguage string             `bson:"preferred_language" json:"preferred_language"` // Kullanıcı tercihi
+	PreferredLanguage string             `bson:"preferred_language" json:"preferred_language"`     // Kullanıcı tercihi
+	AvatarURL         string             `bson:"avatar_url,omitempty" json:"avatar_url,omitempty"` // Profil görseli (medya kütüphanesi adresi)
 	Username          string             `bson:"username" json:"username" binding:"required"`
 	Password          string             `bson:"password" json:"password" binding:"required"`
 	Roles             []string           `bson:"roles" json:"roles" binding:"required"` // ["admin", "editor", "user"]
@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// Workflow represents the editorial workflow configuration of a module (posts, pages)
+type Workflow struct {
+	ID          string               `bson:"_id" json:"id"`                                 // Modül adı (örn: "posts")
+	Transitions []WorkflowTransition `bson:"transitions" json:"transitions" binding:"dive"` // İzin verilen durum geçişleri
+	UpdatedAt   time.Time            `bson:"updated_at" json:"updated_at"`
+	UpdatedBy   string               `bson:"updated_by" json:"updated_by"`
+}
+
+// WorkflowTransition represents a named status change guarded by a role permission
+type WorkflowTransition struct {
+	Action         string   `bson:"action" json:"action" binding:"required"`         // Örnek: submit, approve, reject, publish
+	From           []string `bson:"from" json:"from" binding:"required"`             // Geçişin yapılabileceği durumlar
+	To             string   `bson:"to" json:"to" binding:"required"`                 // Hedef durum
+	Permission     string   `bson:"permission" json:"permission" binding:"required"` // Role.Permissions[modül] içinde aranacak izin
+	RequireComment bool     `bson:"require_comment" json:"require_comment"`          // Örnek: reddetme için yorum zorunlu
+}
+
+// WorkflowActionRequest represents a request to apply a workflow action to a post or page
+type WorkflowActionRequest struct {
+	Action  string `json:"action" binding:"required" example:"approve"`
+	Comment string `json:"comment" example:"Looks good"`
+}
@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"admin-panel/controllers"
+	"admin-panel/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+func BlockRoutes(router *gin.Engine) {
+	blocks := router.Group("/admin/blocks")
+	blocks.Use(middlewares.MaintenanceMiddleware())                     // Bakım modu kontrolü
+	blocks.Use(middlewares.AuthMiddleware())                            // JWT kontrolü
+	blocks.Use(middlewares.AuthorizeRolesMiddleware("admin", "editor")) // Roller
+	{
+		blocks.POST("/convert", middlewares.CSRFMiddleware(), controllers.ConvertBlocksHandler)
+	}
+}
@@ -0,0 +1,13 @@
+package routes
+
+import (
+	"admin-panel/controllers"
+	"admin-panel/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ContentRoutes registers the SEO-friendly /:lang/:slug resolver
+func ContentRoutes(router *gin.Engine) {
+	router.GET("/:lang/:slug", middlewares.MaintenanceMiddleware(), controllers.ResolveContentHandler)
+}
@@ -0,0 +1,29 @@
+package routes
+
+import (
+	"admin-panel/controllers"
+	"admin-panel/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+func ContentTypeRoutes(router *gin.Engine) {
+	contentTypes := router.Group("/admin/content-types")
+	contentTypes.Use(middlewares.MaintenanceMiddleware())                     // Bakım modu kontrolü
+	contentTypes.Use(middlewares.AuthMiddleware())                            // JWT kontrolü
+	contentTypes.Use(middlewares.AuthorizeRolesMiddleware("admin", "editor")) // Roller
+	{
+		// Şema tanımları yalnızca yöneticiler tarafından değiştirilebilir
+		contentTypes.POST("", middlewares.AuthorizeRolesMiddleware("admin"), middlewares.CSRFMiddleware(), middlewares.ActivityLogMiddleware("content_types", "create"), controllers.CreateContentTypeHandler)
+		contentTypes.GET("", controllers.GetContentTypesHandler)
+		contentTypes.GET("/:type", controllers.GetContentTypeHandler)
+		contentTypes.PUT("/:type", middlewares.AuthorizeRolesMiddleware("admin"), middlewares.CSRFMiddleware(), middlewares.ActivityLogMiddleware("content_types", "update"), controllers.UpdateContentTypeHandler)
+		contentTypes.DELETE("/:type", middlewares.AuthorizeRolesMiddleware("admin"), middlewares.CSRFMiddleware(), middlewares.ActivityLogMiddleware("content_types", "delete"), controllers.DeleteContentTypeHandler)
+
+		contentTypes.POST("/:type/entries", middlewares.CSRFMiddleware(), middlewares.ActivityLogMiddleware("content_entries", "create"), controllers.CreateEntryHandler)
+		contentTypes.GET("/:type/entries", controllers.GetEntriesHandler)
+		contentTypes.GET("/:type/entries/:id", controllers.GetEntryHandler)
+		contentTypes.PUT("/:type/entries/:id", middlewares.CSRFMiddleware(), middlewares.ActivityLogMiddleware("content_entries", "update"), controllers.UpdateEntryHandler)
+		contentTypes.DELETE("/:type/entries/:id", middlewares.CSRFMiddleware(), middlewares.ActivityLogMiddleware("content_entries", "delete"), controllers.DeleteEntryHandler)
+	}
+}
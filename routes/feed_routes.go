@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"admin-panel/controllers"
+	"admin-panel/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FeedRoutes registers the RSS, Atom and JSON Feed endpoints
+func FeedRoutes(router *gin.Engine) {
+	feeds := router.Group("/feeds")
+	feeds.Use(middlewares.MaintenanceMiddleware()) // Bakım modu kontrolü
+	{
+		feeds.GET("/:lang/:format", controllers.GetFeedHandler) // rss.xml, atom.xml, feed.json
+		feeds.GET("/:lang/categories/:id/:format", controllers.GetCategoryFeedHandler)
+		feeds.GET("/:lang/tags/:id/:format", controllers.GetTagFeedHandler)
+	}
+}
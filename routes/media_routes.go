@@ -17,10 +17,32 @@ func MediaRoutes(router *gin.Engine) {
 		// Hassas işlemler için CSRF koruması
 		media.POST("/upload", middlewares.CSRFMiddleware(), controllers.UploadMediaHandler)
 		media.DELETE("/:id", middlewares.CSRFMiddleware(), controllers.DeleteMediaHandler)
+		media.PUT("/:id", middlewares.CSRFMiddleware(), controllers.UpdateMediaMetadataHandler)
+		media.POST("/move", middlewares.CSRFMiddleware(), controllers.MoveMediaHandler)
 
 		// GET işlemleri için sadece JWT doğrulama ve yetkilendirme
 		media.GET("/", controllers.GetAllMediaHandler)
 		media.GET("/:id", controllers.GetMediaDetailHandler)
 		media.GET("/filter", controllers.GetFilteredMediaHandler)
+
+		// Görsel boyutları
+		media.POST("/:id/renditions", middlewares.CSRFMiddleware(), controllers.RegenerateMediaRenditionsHandler)
+		media.GET("/:id/transform-url", controllers.GetMediaTransformURLHandler)
+		media.GET("/:id/signed-url", controllers.GetSignedMediaURLHandler)
+
+		// Sanal klasörler
+		media.GET("/folders", controllers.GetMediaFoldersHandler)
+		media.POST("/folders", middlewares.CSRFMiddleware(), controllers.CreateMediaFolderHandler)
+		media.PUT("/folders/:id", middlewares.CSRFMiddleware(), controllers.RenameMediaFolderHandler)
+		media.POST("/folders/:id/move", middlewares.CSRFMiddleware(), controllers.MoveMediaFolderHandler)
+		media.DELETE("/folders/:id", middlewares.CSRFMiddleware(), controllers.DeleteMediaFolderHandler)
+
+		// Kullanım yerleri ve kullanılmayan dosyalar
+		media.GET("/:id/references", controllers.GetMediaReferencesHandler)
+		media.GET("/orphans", controllers.GetOrphanedMediaHandler)
+		media.POST("/references/rebuild", middlewares.AuthorizeRolesMiddleware("admin"), middlewares.CSRFMiddleware(), controllers.RebuildMediaReferencesHandler)
 	}
+
+	// İmzalı isteğe bağlı boyutlandırma; görseller herkese açık olduğundan oturum gerekmez
+	router.GET("/media/:id/:transform", middlewares.MaintenanceMiddleware(), controllers.ServeMediaTransformHandler)
 }
@@ -16,6 +16,7 @@ func PageRoutes(router *gin.Engine) {
 		pages.POST("/create", middlewares.CSRFMiddleware(), controllers.CreatePageHandler)
 		pages.GET("/", controllers.GetAllPagesHandler)
 		pages.PUT("/:id", middlewares.CSRFMiddleware(), controllers.UpdatePageHandler)
+		pages.POST("/:id/workflow", middlewares.CSRFMiddleware(), controllers.PageWorkflowHandler)
 		pages.DELETE("/:id", middlewares.CSRFMiddleware(), controllers.DeletePageHandler)
 	}
 }
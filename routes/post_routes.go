@@ -18,6 +18,8 @@ func PostRoutes(router *gin.Engine) {
 		posts.GET("/", middlewares.ModulePermissionMiddleware("posts", "read"), controllers.GetAllPostsHandler)
 		posts.GET("/filter", controllers.GetFilteredPostsHandler)       // Filtrelenmiş postlar
 		posts.GET("/lang/:lang", controllers.GetPostsByLanguageHandler) // Dil bazlı içerik listeleme
+		posts.POST("/:id/workflow", middlewares.CSRFMiddleware(), controllers.PostWorkflowHandler)
+		posts.PUT("/:id", middlewares.CSRFMiddleware(), middlewares.ModulePermissionMiddleware("posts", "update"), middlewares.ActivityLogMiddleware("posts", "update"), controllers.UpdatePostHandler)
 		// Yeni rota: Dil ve slug üzerinden post getirme
 		posts.GET("/:lang/:slug", controllers.GetPostByLangAndSlugHandler)
 
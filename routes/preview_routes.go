@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"admin-panel/controllers"
+	"admin-panel/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+func PreviewRoutes(router *gin.Engine) {
+	previews := router.Group("/admin/previews")
+	previews.Use(middlewares.MaintenanceMiddleware())                     // Bakım modu kontrolü
+	previews.Use(middlewares.AuthMiddleware())                            // JWT kontrolü
+	previews.Use(middlewares.AuthorizeRolesMiddleware("admin", "editor")) // Roller
+	{
+		previews.POST("", middlewares.CSRFMiddleware(), middlewares.ActivityLogMiddleware("previews", "create"), controllers.CreatePreviewHandler)
+		previews.GET("/:type/:id", controllers.GetPreviewsHandler)
+		previews.DELETE("/:id", middlewares.CSRFMiddleware(), middlewares.ActivityLogMiddleware("previews", "revoke"), controllers.RevokePreviewHandler)
+	}
+}
@@ -0,0 +1,28 @@
+package routes
+
+import (
+	"admin-panel/controllers"
+	"admin-panel/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PublicRoutes registers the unauthenticated, read-only content delivery API
+func PublicRoutes(router *gin.Engine) {
+	public := router.Group("/api/public/v1")
+	public.Use(middlewares.MaintenanceMiddleware()) // Bakım modu kontrolü
+	{
+		public.GET("/posts", controllers.GetPublicPostsHandler)
+		public.GET("/posts/:lang/:slug", controllers.GetPublicPostHandler)
+		public.GET("/pages/:lang/:slug", controllers.GetPublicPageHandler)
+		public.GET("/categories", controllers.GetPublicCategoriesHandler)
+		public.GET("/categories/:lang/:slug", controllers.GetPublicCategoryHandler)
+		public.GET("/tags", controllers.GetPublicTagsHandler)
+		public.GET("/menus", controllers.GetPublicMenusHandler)
+		public.GET("/sliders", controllers.GetPublicSlidersHandler)
+		public.GET("/settings", controllers.GetPublicSettingsHandler)
+		public.GET("/preview", controllers.GetPublicPreviewHandler)     // İmzalı önizleme bağlantıları
+		public.GET("/types/:type", controllers.GetPublicEntriesHandler) // Özel içerik tipleri
+		public.GET("/types/:type/:id", controllers.GetPublicEntryHandler)
+	}
+}
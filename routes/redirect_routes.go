@@ -0,0 +1,25 @@
+package routes
+
+import (
+	"admin-panel/controllers"
+	"admin-panel/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RedirectRoutes registers the admin API of the managed redirect rules
+func RedirectRoutes(router *gin.Engine) {
+	redirects := router.Group("/admin/redirects")
+	redirects.Use(middlewares.MaintenanceMiddleware())                     // Bakım modu kontrolü
+	redirects.Use(middlewares.AuthMiddleware())                            // JWT kontrolü
+	redirects.Use(middlewares.AuthorizeRolesMiddleware("admin", "editor")) // Roller
+	{
+		redirects.POST("", middlewares.CSRFMiddleware(), middlewares.ActivityLogMiddleware("redirects", "create"), controllers.CreateRedirectRuleHandler)
+		redirects.GET("", controllers.GetRedirectRulesHandler)
+		redirects.GET("/export", controllers.ExportRedirectRulesHandler) // CSV
+		redirects.POST("/import", middlewares.CSRFMiddleware(), middlewares.ActivityLogMiddleware("redirects", "import"), controllers.ImportRedirectRulesHandler)
+		redirects.GET("/:id", controllers.GetRedirectRuleHandler)
+		redirects.PUT("/:id", middlewares.CSRFMiddleware(), middlewares.ActivityLogMiddleware("redirects", "update"), controllers.UpdateRedirectRuleHandler)
+		redirects.DELETE("/:id", middlewares.CSRFMiddleware(), middlewares.ActivityLogMiddleware("redirects", "delete"), controllers.DeleteRedirectRuleHandler)
+	}
+}
@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"admin-panel/controllers"
+	"admin-panel/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RevisionRoutes(router *gin.Engine) {
+	revisions := router.Group("/admin/revisions")
+	revisions.Use(middlewares.MaintenanceMiddleware())                     // Bakım modu kontrolü
+	revisions.Use(middlewares.AuthMiddleware())                            // JWT kontrolü
+	revisions.Use(middlewares.AuthorizeRolesMiddleware("admin", "editor")) // Roller
+	{
+		revisions.GET("/:entity/:id", controllers.GetRevisionsHandler)
+		revisions.GET("/:entity/:id/diff", controllers.DiffRevisionsHandler)
+		revisions.GET("/:entity/:id/:revisionID", controllers.GetRevisionHandler)
+		revisions.POST("/:entity/:id/:revisionID/restore", middlewares.CSRFMiddleware(), middlewares.ActivityLogMiddleware("revisions", "restore"), controllers.RestoreRevisionHandler)
+	}
+}
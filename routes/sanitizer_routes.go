@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"admin-panel/controllers"
+	"admin-panel/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SanitizerRoutes(router *gin.Engine) {
+	sanitizer := router.Group("/admin/sanitizer")
+	sanitizer.Use(middlewares.MaintenanceMiddleware())           // Bakım modu kontrolü
+	sanitizer.Use(middlewares.AuthMiddleware())                  // JWT kontrolü
+	sanitizer.Use(middlewares.AuthorizeRolesMiddleware("admin")) // Roller
+	{
+		sanitizer.GET("/policies", controllers.GetSanitizerPoliciesHandler)
+		sanitizer.PUT("/policies/:name", middlewares.CSRFMiddleware(), middlewares.ActivityLogMiddleware("sanitizer", "update"), controllers.UpdateSanitizerPolicyHandler)
+		sanitizer.DELETE("/policies/:name", middlewares.CSRFMiddleware(), middlewares.ActivityLogMiddleware("sanitizer", "reset"), controllers.ResetSanitizerPolicyHandler)
+		sanitizer.POST("/policies/:name/preview", middlewares.CSRFMiddleware(), controllers.PreviewSanitizerHandler)
+	}
+}
@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"admin-panel/controllers"
+	"admin-panel/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SearchRoutes(router *gin.Engine) {
+	search := router.Group("/search")
+	search.Use(middlewares.MaintenanceMiddleware()) // Bakım modu kontrolü
+	search.Use(middlewares.AuthMiddleware())
+	search.Use(middlewares.AuthorizeRolesMiddleware("admin", "editor"))
+	{
+		search.GET("/", controllers.SearchHandler)
+		search.POST("/reindex", middlewares.CSRFMiddleware(), middlewares.AuthorizeRolesMiddleware("admin"), controllers.RebuildSearchIndexHandler)
+	}
+}
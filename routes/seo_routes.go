@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"admin-panel/controllers"
+	"admin-panel/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SEORoutes registers the SEO audit endpoints
+func SEORoutes(router *gin.Engine) {
+	seo := router.Group("/admin/seo")
+	seo.Use(middlewares.MaintenanceMiddleware())                     // Bakım modu kontrolü
+	seo.Use(middlewares.AuthMiddleware())                            // JWT kontrolü
+	seo.Use(middlewares.AuthorizeRolesMiddleware("admin", "editor")) // Roller
+	{
+		seo.POST("/audit/:type/:id", middlewares.CSRFMiddleware(), controllers.AuditSEOHandler)
+	}
+}
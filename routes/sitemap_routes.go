@@ -0,0 +1,22 @@
+package routes
+
+import (
+	"admin-panel/controllers"
+	"admin-panel/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SitemapRoutes registers the XML sitemaps and the admin rebuild endpoint
+func SitemapRoutes(router *gin.Engine) {
+	router.GET("/sitemap.xml", middlewares.MaintenanceMiddleware(), controllers.GetSitemapIndexHandler)
+	router.GET("/sitemaps/:name", middlewares.MaintenanceMiddleware(), controllers.GetSitemapHandler) // posts-1.xml, pages-1.xml, ...
+
+	admin := router.Group("/admin/sitemaps")
+	admin.Use(middlewares.MaintenanceMiddleware())           // Bakım modu kontrolü
+	admin.Use(middlewares.AuthMiddleware())                  // JWT kontrolü
+	admin.Use(middlewares.AuthorizeRolesMiddleware("admin")) // Roller
+	{
+		admin.POST("/rebuild", middlewares.CSRFMiddleware(), controllers.RebuildSitemapHandler)
+	}
+}
@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"admin-panel/controllers"
+	"admin-panel/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TranslationRoutes(router *gin.Engine) {
+	translations := router.Group("/admin/translations")
+	translations.Use(middlewares.MaintenanceMiddleware())                     // Bakım modu kontrolü
+	translations.Use(middlewares.AuthMiddleware())                            // JWT kontrolü
+	translations.Use(middlewares.AuthorizeRolesMiddleware("admin", "editor")) // Roller
+	{
+		translations.GET("/outdated", controllers.GetOutdatedTranslationsHandler)
+		translations.GET("/status/:type/:id", controllers.GetTranslationStatusHandler)
+	}
+}
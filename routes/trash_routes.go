@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"admin-panel/controllers"
+	"admin-panel/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TrashRoutes(router *gin.Engine) {
+	trash := router.Group("/admin/trash")
+	trash.Use(middlewares.MaintenanceMiddleware())                     // Bakım modu kontrolü
+	trash.Use(middlewares.AuthMiddleware())                            // JWT kontrolü
+	trash.Use(middlewares.AuthorizeRolesMiddleware("admin", "editor")) // Roller
+	{
+		trash.GET("/:module", controllers.GetTrashHandler)
+		trash.POST("/:module/:id/restore", middlewares.CSRFMiddleware(), middlewares.ActivityLogMiddleware("trash", "restore"), controllers.RestoreTrashHandler)
+		trash.DELETE("/:module/:id", middlewares.AuthorizeRolesMiddleware("admin"), middlewares.CSRFMiddleware(), middlewares.ActivityLogMiddleware("trash", "purge"), controllers.PurgeTrashHandler)
+	}
+}
@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"admin-panel/controllers"
+	"admin-panel/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+func UploadPolicyRoutes(router *gin.Engine) {
+	policies := router.Group("/admin/upload-policies")
+	policies.Use(middlewares.MaintenanceMiddleware())           // Bakım modu kontrolü
+	policies.Use(middlewares.AuthMiddleware())                  // JWT kontrolü
+	policies.Use(middlewares.AuthorizeRolesMiddleware("admin")) // Roller
+	{
+		policies.GET("", controllers.GetUploadPoliciesHandler)
+		policies.PUT("/:role", middlewares.CSRFMiddleware(), middlewares.ActivityLogMiddleware("upload_policies", "update"), controllers.UpdateUploadPolicyHandler)
+		policies.DELETE("/:role", middlewares.CSRFMiddleware(), middlewares.ActivityLogMiddleware("upload_policies", "reset"), controllers.ResetUploadPolicyHandler)
+	}
+}
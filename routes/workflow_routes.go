@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"admin-panel/controllers"
+	"admin-panel/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+func WorkflowRoutes(router *gin.Engine) {
+	workflows := router.Group("/admin/workflows")
+	workflows.Use(middlewares.MaintenanceMiddleware()) // Bakım modu kontrolü
+	workflows.Use(middlewares.AuthMiddleware())
+	workflows.Use(middlewares.AuthorizeRolesMiddleware("admin")) // İş akışını yalnızca admin yapılandırabilir
+	{
+		workflows.GET("/:module", controllers.GetWorkflowHandler)
+		workflows.PUT("/:module", middlewares.CSRFMiddleware(), controllers.UpdateWorkflowHandler)
+	}
+}
@@ -8,7 +8,6 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
-	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 var activityLogCollection *mongo.Collection
@@ -34,23 +33,6 @@ func LogActivity(userID primitive.ObjectID, username, module, action, details st
 }
 
 // GetActivityLogs retrieves activity logs with optional filters
-func GetActivityLogs(filter bson.M, limit int) ([]models.ActivityLog, error) {
-	var logs []models.ActivityLog
-
-	opts := options.Find().SetLimit(int64(limit)).SetSort(bson.M{"timestamp": -1})
-	cursor, err := activityLogCollection.Find(context.Background(), filter, opts)
-	if err != nil {
-		return nil, err
-	}
-	defer cursor.Close(context.Background())
-
-	for cursor.Next(context.Background()) {
-		var log models.ActivityLog
-		if err := cursor.Decode(&log); err != nil {
-			return nil, err
-		}
-		logs = append(logs, log)
-	}
-
-	return logs, nil
+func GetActivityLogs(filter bson.M, opts ListOptions) ([]models.ActivityLog, *PageInfo, error) {
+	return FindPage[models.ActivityLog](context.Background(), activityLogCollection, filter, opts)
 }
@@ -0,0 +1,174 @@
+package services
+
+import (
+	"admin-panel/models"
+	"admin-panel/utils"
+	"context"
+	"fmt"
+	"net/url"
+	"path"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// prepareBlocks validates the block bodies of the localizations and renders them into Content
+func prepareBlocks(ctx context.Context, localizations map[string]models.LocalizedField) error {
+	var errs utils.ValidationErrors
+	for lang, field := range localizations {
+		if len(field.Blocks) == 0 {
+			continue
+		}
+		prefix := "localizations." + lang + ".blocks"
+
+		resolveBlockImages(ctx, field.Blocks)
+		if blockErrs := utils.ValidateBlocks(field.Blocks, prefix); len(blockErrs) > 0 {
+			errs = append(errs, blockErrs...)
+			continue
+		}
+
+		mediaURLs, referenceErrs, err := checkBlockReferences(ctx, field.Blocks, prefix)
+		if err != nil {
+			return err
+		}
+		if len(referenceErrs) > 0 {
+			errs = append(errs, referenceErrs...)
+			continue
+		}
+
+		field.Content = utils.RenderBlocksHTML(field.Blocks, mediaURLs)
+		localizations[lang] = field
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// ConvertBlocks converts an HTML body to blocks or blocks to HTML, returning both along with the plain text
+func ConvertBlocks(ctx context.Context, request models.BlockConversionRequest) (*models.BlockConversion, error) {
+	conversion := &models.BlockConversion{Blocks: request.Blocks}
+	if request.HTML != "" {
+		conversion.Blocks, conversion.Warnings = utils.HTMLToBlocks(request.HTML)
+		resolveBlockImages(ctx, conversion.Blocks)
+		// Medya kütüphanesinde bulunamayan görseller bloklara alınmaz
+		blocks := conversion.Blocks[:0]
+		for _, block := range conversion.Blocks {
+			if block.Type == models.BlockImage && block.MediaID == nil {
+				conversion.Warnings = append(conversion.Warnings, "image "+block.URL+" is not in the media library")
+				continue
+			}
+			blocks = append(blocks, block)
+		}
+		conversion.Blocks = blocks
+	}
+	if conversion.Blocks == nil {
+		conversion.Blocks = []models.ContentBlock{}
+	}
+
+	if errs := utils.ValidateBlocks(conversion.Blocks, "blocks"); len(errs) > 0 {
+		return nil, errs
+	}
+	mediaURLs, errs, err := checkBlockReferences(ctx, conversion.Blocks, "blocks")
+	if err != nil {
+		return nil, err
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	conversion.HTML = utils.RenderBlocksHTML(conversion.Blocks, mediaURLs)
+	conversion.Text = utils.BlocksToText(conversion.Blocks)
+	return conversion, nil
+}
+
+// resolveBlockImages fills the media ID of image blocks that only carry an upload URL
+func resolveBlockImages(ctx context.Context, blocks []models.ContentBlock) {
+	for i, block := range blocks {
+		if block.Type != models.BlockImage || block.MediaID != nil || block.URL == "" {
+			continue
+		}
+		parsed, err := url.Parse(block.URL)
+		if err != nil {
+			continue
+		}
+
+		address := block.URL
+		if parsed.Host == "" {
+			address = path.Clean(parsed.Path)
+		}
+		var media models.Media
+		err = mediaCollection.FindOne(ctx, notTrashed(mediaURLFilter(address))).Decode(&media)
+		if err != nil {
+			continue // Bulunamayan görsel doğrulamada raporlanır
+		}
+		blocks[i].MediaID = &media.ID
+		blocks[i].URL = ""
+	}
+}
+
+// checkBlockReferences verifies that referenced media and sliders exist and returns the media URLs
+func checkBlockReferences(ctx context.Context, blocks []models.ContentBlock, prefix string) (map[primitive.ObjectID]string, utils.ValidationErrors, error) {
+	var mediaIDs, sliderIDs []primitive.ObjectID
+	for _, block := range blocks {
+		if block.MediaID != nil {
+			mediaIDs = append(mediaIDs, *block.MediaID)
+		}
+		mediaIDs = append(mediaIDs, block.MediaIDs...)
+		if block.SliderID != nil {
+			sliderIDs = append(sliderIDs, *block.SliderID)
+		}
+	}
+
+	mediaURLs := map[primitive.ObjectID]string{}
+	if len(mediaIDs) > 0 {
+		cursor, err := mediaCollection.Find(ctx, notTrashed(bson.M{"_id": bson.M{"$in": mediaIDs}}),
+			options.Find().SetProjection(bson.M{"file_path": 1, "url": 1}))
+		if err != nil {
+			return nil, nil, err
+		}
+		var media []models.Media
+		if err := cursor.All(ctx, &media); err != nil {
+			return nil, nil, err
+		}
+		for _, item := range media {
+			mediaURLs[item.ID] = MediaURL(item)
+		}
+	}
+
+	sliders := map[primitive.ObjectID]bool{}
+	if len(sliderIDs) > 0 {
+		cursor, err := sliderCollection.Find(ctx, bson.M{"_id": bson.M{"$in": sliderIDs}}, options.Find().SetProjection(bson.M{"_id": 1}))
+		if err != nil {
+			return nil, nil, err
+		}
+		var found []models.Slider
+		if err := cursor.All(ctx, &found); err != nil {
+			return nil, nil, err
+		}
+		for _, slider := range found {
+			sliders[slider.ID] = true
+		}
+	}
+
+	var errs utils.ValidationErrors
+	for i, block := range blocks {
+		blockPath := fmt.Sprintf("%s[%d]", prefix, i)
+		if block.MediaID != nil {
+			if _, ok := mediaURLs[*block.MediaID]; !ok {
+				errs = append(errs, models.FieldError{Field: blockPath + ".media_id", Message: "referenced media not found"})
+			}
+		}
+		for j, id := range block.MediaIDs {
+			if _, ok := mediaURLs[id]; !ok {
+				errs = append(errs, models.FieldError{Field: fmt.Sprintf("%s.media_ids[%d]", blockPath, j), Message: "referenced media not found"})
+			}
+		}
+		if block.SliderID != nil && !sliders[*block.SliderID] {
+			errs = append(errs, models.FieldError{Field: blockPath + ".slider_id", Message: "referenced slider not found"})
+		}
+	}
+	return mediaURLs, errs, nil
+}
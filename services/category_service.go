@@ -2,7 +2,6 @@ package services
 
 import (
 	"admin-panel/models"
-	"admin-panel/utils"
 	"context"
 	"errors"
 	"time"
@@ -22,6 +21,8 @@ func CreateCategory(category models.Category) (*mongo.InsertOneResult, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
+	category.DeletedAt, category.DeletedBy = nil, ""
+
 	// Slug oluşturma
 	if category.Slug == nil {
 		category.Slug = make(map[string]string)
@@ -29,40 +30,34 @@ func CreateCategory(category models.Category) (*mongo.InsertOneResult, error) {
 
 	for lang, localization := range category.Localizations {
 		if localization.Title != "" {
-			category.Slug[lang] = utils.GenerateSlug(localization.Title)
-		} else if lang == "en" {
-			return nil, errors.New("English name is required for slug generation")
+			category.Slug[lang] = GenerateSlug(lang, localization.Title)
+		} else if lang == DefaultLanguage() {
+			return nil, errors.New("default language name is required for slug generation")
 		}
 	}
 
 	category.CreatedAt = primitive.NewDateTimeFromTime(time.Now())
-	return categoryCollection.InsertOne(ctx, category)
+	result, err := categoryCollection.InsertOne(ctx, category)
+	if err == nil {
+		if id, ok := result.InsertedID.(primitive.ObjectID); ok {
+			refreshTranslationMeta(ctx, "categories", id)
+			reindexSearch(ctx, "categories", id)
+			refreshSitemap(ctx, "categories", id)
+		}
+	}
+	return result, err
 }
 
-func GetAllCategories() ([]models.Category, error) {
-	var categories []models.Category
+func GetAllCategories(opts ListOptions) ([]models.Category, *PageInfo, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	cursor, err := categoryCollection.Find(ctx, bson.M{})
-	if err != nil {
-		return nil, err
-	}
-	defer cursor.Close(ctx)
-
-	for cursor.Next(ctx) {
-		var category models.Category
-		if err := cursor.Decode(&category); err != nil {
-			return nil, err
-		}
-		categories = append(categories, category)
-	}
-	return categories, nil
+	return FindPage[models.Category](ctx, categoryCollection, notTrashed(nil), opts)
 }
 
 func GetCategoryByID(ctx context.Context, categoryID primitive.ObjectID) (*models.Category, error) {
 	var category models.Category
-	err := categoryCollection.FindOne(ctx, bson.M{"_id": categoryID}).Decode(&category)
+	err := categoryCollection.FindOne(ctx, notTrashed(bson.M{"_id": categoryID})).Decode(&category)
 	if err != nil {
 		return nil, err
 	}
@@ -70,15 +65,21 @@ func GetCategoryByID(ctx context.Context, categoryID primitive.ObjectID) (*model
 }
 
 func UpdateCategory(ctx context.Context, categoryID primitive.ObjectID, updatedCategory *models.Category) error {
+	updatedCategory.DeletedAt, updatedCategory.DeletedBy = nil, ""
 	_, err := categoryCollection.UpdateOne(
 		ctx,
-		bson.M{"_id": categoryID},
+		notTrashed(bson.M{"_id": categoryID}),
 		bson.M{"$set": updatedCategory},
 	)
+	if err == nil {
+		refreshTranslationMeta(ctx, "categories", categoryID)
+		reindexSearch(ctx, "categories", categoryID)
+		refreshSitemap(ctx, "categories", categoryID)
+	}
 	return err
 }
 
-func DeleteCategory(ctx context.Context, categoryID primitive.ObjectID) error {
-	_, err := categoryCollection.DeleteOne(ctx, bson.M{"_id": categoryID})
-	return err
+// DeleteCategory moves a category to the trash
+func DeleteCategory(ctx context.Context, categoryID primitive.ObjectID, deletedBy string) error {
+	return moveToTrash(ctx, "categories", categoryID, deletedBy)
 }
@@ -19,15 +19,22 @@ func InitCommentService(client *mongo.Client) {
 	log.Println("Comment service initialized with collection:", commentCollection.Name())
 }
 
-func CreateComment(ctx context.Context, comment *models.Comment) (*mongo.InsertOneResult, error) {
+// CreateComment stores a comment and reports the markup removed from it
+func CreateComment(ctx context.Context, comment *models.Comment) (*mongo.InsertOneResult, []models.SanitizeReport, error) {
 	comment.ID = primitive.NewObjectID()
 	comment.CreatedAt = time.Now()
 	comment.UpdatedAt = time.Now()
-	return commentCollection.InsertOne(ctx, comment)
+	comment.DeletedAt, comment.DeletedBy = nil, ""
+
+	sanitizer := newContentSanitizer()
+	comment.Content = sanitizer.clean("content", models.PolicyComment, comment.Content)
+
+	result, err := commentCollection.InsertOne(ctx, comment)
+	return result, sanitizer.report, err
 }
 
 func GetCommentsByPostID(ctx context.Context, postID primitive.ObjectID) ([]models.Comment, error) {
-	filter := bson.M{"post_id": postID}
+	filter := notTrashed(bson.M{"post_id": postID})
 	cursor, err := commentCollection.Find(ctx, filter)
 	if err != nil {
 		return nil, err
@@ -53,44 +60,58 @@ func AddReply(ctx context.Context, commentID, replyID primitive.ObjectID) error
 }
 
 func AddReaction(ctx context.Context, commentID primitive.ObjectID, reaction string) error {
-	filter := bson.M{"_id": commentID}
+	filter := notTrashed(bson.M{"_id": commentID})
 	update := bson.M{
 		"$inc": bson.M{"reactions." + reaction: 1}, // Belirli bir ifadeyi artır
 		"$set": bson.M{"updated_at": time.Now()},
 	}
-	_, err := commentCollection.UpdateOne(ctx, filter, update)
-	return err
+	return updateComment(ctx, filter, update)
 }
 
+// LikeComment increments the likes of a comment; çöp kutusundaki yorumlar için mongo.ErrNoDocuments döner
 func LikeComment(ctx context.Context, commentID primitive.ObjectID) error {
-	filter := bson.M{"_id": commentID}
+	filter := notTrashed(bson.M{"_id": commentID})
 	update := bson.M{"$inc": bson.M{"likes": 1}, "$set": bson.M{"updated_at": time.Now()}}
-	_, err := commentCollection.UpdateOne(ctx, filter, update)
-	return err
+	return updateComment(ctx, filter, update)
 }
 
-func DeleteComment(ctx context.Context, commentID primitive.ObjectID) error {
-	filter := bson.M{"_id": commentID}
+// updateComment applies an update and returns mongo.ErrNoDocuments when no comment matches
+func updateComment(ctx context.Context, filter, update bson.M) error {
+	result, err := commentCollection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
 
-	_, err := commentCollection.DeleteOne(ctx, filter)
-	return err
+// DeleteComment moves a comment to the trash
+func DeleteComment(ctx context.Context, commentID primitive.ObjectID, deletedBy string) error {
+	return moveToTrash(ctx, "comments", commentID, deletedBy)
 }
 
-func UpdateComment(ctx context.Context, commentID primitive.ObjectID, content string) error {
-	filter := bson.M{"_id": commentID}
+// UpdateComment replaces the content of a comment and reports the markup removed from it.
+// Çöp kutusundaki yorumlar güncellenmez; mongo.ErrNoDocuments döner.
+func UpdateComment(ctx context.Context, commentID primitive.ObjectID, content string) ([]models.SanitizeReport, error) {
+	sanitizer := newContentSanitizer()
+	filter := notTrashed(bson.M{"_id": commentID})
 	update := bson.M{
 		"$set": bson.M{
-			"content":    content,
+			"content":    sanitizer.clean("content", models.PolicyComment, content),
 			"updated_at": time.Now(),
 		},
 	}
 
-	_, err := commentCollection.UpdateOne(ctx, filter, update)
-	return err
+	if err := updateComment(ctx, filter, update); err != nil {
+		return nil, err
+	}
+	return sanitizer.report, nil
 }
 
 func GetCommentsByPostIDWithPagination(ctx context.Context, postID primitive.ObjectID, skip int, limit int) ([]models.Comment, error) {
-	filter := bson.M{"post_id": postID, "parent_id": nil}                                                   // Sadece ana yorumları al
+	filter := notTrashed(bson.M{"post_id": postID, "parent_id": nil})                                       // Sadece ana yorumları al
 	options := options.Find().SetSkip(int64(skip)).SetLimit(int64(limit)).SetSort(bson.M{"created_at": -1}) // Yeni yorumlar önce gelir
 
 	cursor, err := commentCollection.Find(ctx, filter, options)
@@ -111,7 +132,7 @@ func GetCommentsByPostIDWithPagination(ctx context.Context, postID primitive.Obj
 }
 
 func FetchCommentByID(ctx context.Context, commentID primitive.ObjectID) (*models.Comment, error) {
-	filter := bson.M{"_id": commentID}
+	filter := notTrashed(bson.M{"_id": commentID})
 	var comment models.Comment
 	err := commentCollection.FindOne(ctx, filter).Decode(&comment)
 	if err != nil {
@@ -16,35 +16,27 @@ func InitContactService(client *mongo.Client) {
 	contactCollection = client.Database("admin_panel").Collection("contacts")
 }
 
-func CreateContactMessage(ctx context.Context, message *models.ContactMessage) (*models.ContactMessage, error) {
+// CreateContactMessage stores a contact message as plain text and reports the markup removed from it
+func CreateContactMessage(ctx context.Context, message *models.ContactMessage) (*models.ContactMessage, []models.SanitizeReport, error) {
 	message.ID = primitive.NewObjectID()
 	message.CreatedAt = time.Now()
 	message.UpdatedAt = time.Now()
 	message.Status = "new"
 
+	sanitizer := newContentSanitizer()
+	message.Name = sanitizer.clean("name", models.PolicyPlainText, message.Name)
+	message.Subject = sanitizer.clean("subject", models.PolicyPlainText, message.Subject)
+	message.Message = sanitizer.clean("message", models.PolicyPlainText, message.Message)
+
 	_, err := contactCollection.InsertOne(ctx, message)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	return message, nil
+	return message, sanitizer.report, nil
 }
 
-func GetAllContactMessages(ctx context.Context) ([]models.ContactMessage, error) {
-	var messages []models.ContactMessage
-	cursor, err := contactCollection.Find(ctx, bson.M{})
-	if err != nil {
-		return nil, err
-	}
-	defer cursor.Close(ctx)
-
-	for cursor.Next(ctx) {
-		var message models.ContactMessage
-		if err := cursor.Decode(&message); err != nil {
-			return nil, err
-		}
-		messages = append(messages, message)
-	}
-	return messages, nil
+func GetAllContactMessages(ctx context.Context, opts ListOptions) ([]models.ContactMessage, *PageInfo, error) {
+	return FindPage[models.ContactMessage](ctx, contactCollection, bson.M{}, opts)
 }
 
 func UpdateContactMessageStatus(ctx context.Context, id string, status string, resolvedBy string) error {
@@ -0,0 +1,279 @@
+package services
+
+import (
+	"admin-panel/models"
+	"admin-panel/utils"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var ErrInvalidEntryFilter = errors.New("invalid entry filter")
+
+// Kayıt listelerinde desteklenen karşılaştırma operatörleri (filter[alan:op]=değer)
+var entryFilterOperators = map[string]string{"gt": "$gt", "gte": "$gte", "lt": "$lt", "lte": "$lte", "ne": "$ne"}
+
+var contentEntryCollection *mongo.Collection
+
+func InitContentEntryService(client *mongo.Client) {
+	contentEntryCollection = client.Database("admin_panel").Collection("content_entries")
+
+	_, _ = contentEntryCollection.Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+		{Keys: bson.D{{Key: "type", Value: 1}, {Key: "status", Value: 1}, {Key: "publish_date", Value: -1}}},
+		{Keys: bson.D{{Key: "type", Value: 1}, {Key: "_id", Value: 1}}},
+	})
+}
+
+// CreateEntry validates an entry against its content type and stores it; rich text değerlerinden kaldırılanlar raporlanır
+func CreateEntry(ctx context.Context, contentType *models.ContentType, entry *models.ContentEntry) ([]models.SanitizeReport, error) {
+	sanitizer := newContentSanitizer()
+	if err := prepareEntry(ctx, contentType, entry, sanitizer); err != nil {
+		return nil, err
+	}
+
+	entry.ID = primitive.NewObjectID()
+	entry.Type = contentType.Key
+	entry.CreatedAt = time.Now()
+	entry.UpdatedAt = entry.CreatedAt
+	_, err := contentEntryCollection.InsertOne(ctx, entry)
+	return sanitizer.report, err
+}
+
+// GetEntries lists the entries of a content type
+func GetEntries(ctx context.Context, typeKey string, filter bson.M, opts ListOptions) ([]models.ContentEntry, *PageInfo, error) {
+	query := bson.M{"type": typeKey}
+	for key, value := range filter {
+		query[key] = value
+	}
+	return FindPage[models.ContentEntry](ctx, contentEntryCollection, query, opts)
+}
+
+// GetEntry retrieves a single entry of a content type
+func GetEntry(ctx context.Context, typeKey string, id primitive.ObjectID) (*models.ContentEntry, error) {
+	var entry models.ContentEntry
+	if err := contentEntryCollection.FindOne(ctx, bson.M{"_id": id, "type": typeKey}).Decode(&entry); err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// UpdateEntry validates and replaces the values of an entry
+func UpdateEntry(ctx context.Context, contentType *models.ContentType, id primitive.ObjectID, entry *models.ContentEntry) ([]models.SanitizeReport, error) {
+	sanitizer := newContentSanitizer()
+	if err := prepareEntry(ctx, contentType, entry, sanitizer); err != nil {
+		return nil, err
+	}
+
+	entry.UpdatedAt = time.Now()
+	result, err := contentEntryCollection.UpdateOne(ctx, bson.M{"_id": id, "type": contentType.Key}, bson.M{"$set": bson.M{
+		"status":        entry.Status,
+		"data":          entry.Data,
+		"localizations": entry.Localizations,
+		"publish_date":  entry.PublishDate,
+		"updated_at":    entry.UpdatedAt,
+		"updated_by":    entry.UpdatedBy,
+	}})
+	if err != nil {
+		return nil, err
+	}
+	if result.MatchedCount == 0 {
+		return nil, mongo.ErrNoDocuments
+	}
+	return sanitizer.report, nil
+}
+
+// DeleteEntry removes an entry of a content type
+func DeleteEntry(ctx context.Context, typeKey string, id primitive.ObjectID) error {
+	result, err := contentEntryCollection.DeleteOne(ctx, bson.M{"_id": id, "type": typeKey})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// BuildEntryFilter converts filter[field] / filter[field:op] query values to a MongoDB filter
+// using the field types of the schema. Dile bağlı alanlar lang dilinde aranır.
+func BuildEntryFilter(contentType *models.ContentType, raw map[string]string, lang string) (bson.M, error) {
+	fields := map[string]models.ContentField{}
+	for _, field := range contentType.Fields {
+		fields[field.Key] = field
+	}
+
+	filter := bson.M{}
+	for key, rawValue := range raw {
+		name, op, _ := strings.Cut(key, ":")
+		field, ok := fields[name]
+		if !ok || field.Type == models.FieldRepeater {
+			return nil, fmt.Errorf("%w: unknown or unsupported field %q", ErrInvalidEntryFilter, name)
+		}
+		value, ok := utils.ParseContentFilterValue(field, rawValue)
+		if !ok {
+			return nil, fmt.Errorf("%w: invalid value for %q", ErrInvalidEntryFilter, name)
+		}
+
+		path := "data." + name
+		if field.Localized {
+			// Dil kodu alan yoluna yazıldığından doğrulanır (örn: "tr.x" veya "$" içeremez)
+			if !utils.IsValidLanguageCode(lang) {
+				return nil, fmt.Errorf("%w: invalid language code %q", ErrInvalidEntryFilter, lang)
+			}
+			path = "localizations." + lang + "." + name
+		}
+
+		if op == "" {
+			filter[path] = value
+			continue
+		}
+		operator, ok := entryFilterOperators[op]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown operator %q", ErrInvalidEntryFilter, op)
+		}
+		condition, _ := filter[path].(bson.M)
+		if condition == nil {
+			condition = bson.M{}
+		}
+		condition[operator] = value
+		filter[path] = condition
+	}
+	return filter, nil
+}
+
+// GetPublishedEntries lists the published entries of a public content type resolved to lang
+func GetPublishedEntries(ctx context.Context, contentType *models.ContentType, lang string, filter bson.M, opts ListOptions) ([]models.PublicEntry, *PageInfo, error) {
+	query := publishedEntryFilter(time.Now())
+	for key, value := range filter {
+		query[key] = value
+	}
+
+	entries, info, err := GetEntries(ctx, contentType.Key, query, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	items := make([]models.PublicEntry, 0, len(entries))
+	for i := range entries {
+		items = append(items, PublicEntry(&entries[i], lang))
+	}
+	return items, info, nil
+}
+
+// GetPublishedEntry retrieves a published entry of a content type
+func GetPublishedEntry(ctx context.Context, typeKey string, id primitive.ObjectID) (*models.ContentEntry, error) {
+	query := publishedEntryFilter(time.Now())
+	query["_id"] = id
+	query["type"] = typeKey
+
+	var entry models.ContentEntry
+	if err := contentEntryCollection.FindOne(ctx, query).Decode(&entry); err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// PublicEntry merges the shared values with the translation found along the fallback chain of lang
+func PublicEntry(entry *models.ContentEntry, lang string) models.PublicEntry {
+	fields := map[string]interface{}{}
+	for key, value := range entry.Data {
+		fields[key] = value
+	}
+	localized, resolved, _ := ResolveTranslation(entry.Localizations, lang)
+	for key, value := range localized {
+		fields[key] = value
+	}
+	if resolved == "" {
+		resolved = lang
+	}
+
+	return models.PublicEntry{
+		ID:            entry.ID,
+		Type:          entry.Type,
+		Lang:          resolved,
+		RequestedLang: lang,
+		Fallback:      resolved != lang,
+		Fields:        fields,
+		PublishDate:   entry.PublishDate,
+	}
+}
+
+func publishedEntryFilter(now time.Time) bson.M {
+	return bson.M{
+		"status": "published",
+		"$or":    bson.A{bson.M{"publish_date": nil}, bson.M{"publish_date": bson.M{"$lte": now}}},
+	}
+}
+
+// prepareEntry validates the status and the values of an entry, converts them to their stored types
+// and sanitizes the rich text values
+func prepareEntry(ctx context.Context, contentType *models.ContentType, entry *models.ContentEntry, sanitizer *contentSanitizer) error {
+	var errs utils.ValidationErrors
+
+	if entry.Status == "" {
+		entry.Status = "draft"
+	}
+	if entry.Status != "draft" && entry.Status != "published" {
+		errs = append(errs, models.FieldError{Field: "status", Message: "must be draft or published"})
+	}
+	if entry.Status == "published" && entry.PublishDate == nil {
+		now := time.Now()
+		entry.PublishDate = &now
+	}
+
+	shared, localized := utils.SplitContentFields(contentType.Fields)
+
+	data, refs, dataErrs := utils.NormalizeEntryValues(shared, entry.Data, "data")
+	errs = append(errs, dataErrs...)
+	sanitizer.entryValues("data", shared, data)
+	entry.Data = data
+
+	if len(localized) == 0 && len(entry.Localizations) > 0 {
+		errs = append(errs, models.FieldError{Field: "localizations", Message: "content type has no localized fields"})
+	}
+	if len(localized) > 0 && len(entry.Localizations) == 0 {
+		errs = append(errs, models.FieldError{Field: "localizations", Message: "at least one language is required"})
+	}
+	localizations := map[string]map[string]interface{}{}
+	for lang, values := range entry.Localizations {
+		if !utils.IsValidLanguageCode(lang) {
+			errs = append(errs, models.FieldError{Field: "localizations." + lang, Message: "invalid language code"})
+			continue
+		}
+		normalized, langRefs, langErrs := utils.NormalizeEntryValues(localized, values, "localizations."+lang)
+		errs = append(errs, langErrs...)
+		refs = append(refs, langRefs...)
+		sanitizer.entryValues("localizations."+lang, localized, normalized)
+		localizations[lang] = normalized
+	}
+	entry.Localizations = localizations
+
+	// Referans verilen medya ve kayıtlar mevcut olmalı
+	for _, ref := range refs {
+		var count int64
+		var err error
+		if ref.Kind == models.FieldMedia {
+			count, err = mediaCollection.CountDocuments(ctx, notTrashed(bson.M{"_id": ref.ID}), options.Count().SetLimit(1))
+		} else {
+			count, err = contentEntryCollection.CountDocuments(ctx, bson.M{"_id": ref.ID, "type": ref.Type}, options.Count().SetLimit(1))
+		}
+		if err != nil {
+			return err
+		}
+		if count == 0 {
+			errs = append(errs, models.FieldError{Field: ref.Field, Message: "referenced " + ref.Kind + " not found"})
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
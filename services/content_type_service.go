@@ -0,0 +1,143 @@
+package services
+
+import (
+	"admin-panel/models"
+	"admin-panel/utils"
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var (
+	ErrContentTypeExists = errors.New("content type already exists")
+	ErrContentTypeInUse  = errors.New("content type still has entries")
+)
+
+var contentTypeCollection *mongo.Collection
+
+func InitContentTypeService(client *mongo.Client) {
+	contentTypeCollection = client.Database("admin_panel").Collection("content_types")
+
+	_, _ = contentTypeCollection.Indexes().CreateOne(context.Background(), mongo.IndexModel{
+		Keys:    bson.D{{Key: "key", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+}
+
+// CreateContentType validates and stores a new content type
+func CreateContentType(ctx context.Context, contentType *models.ContentType) error {
+	if err := validateContentType(ctx, contentType); err != nil {
+		return err
+	}
+
+	contentType.ID = primitive.NewObjectID()
+	contentType.CreatedAt = time.Now()
+	contentType.UpdatedAt = contentType.CreatedAt
+	if _, err := contentTypeCollection.InsertOne(ctx, contentType); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return ErrContentTypeExists
+		}
+		return err
+	}
+	return nil
+}
+
+// GetContentTypes lists the defined content types
+func GetContentTypes(ctx context.Context, opts ListOptions) ([]models.ContentType, *PageInfo, error) {
+	return FindPage[models.ContentType](ctx, contentTypeCollection, bson.M{}, opts)
+}
+
+// GetContentType finds a content type by its key
+func GetContentType(ctx context.Context, key string) (*models.ContentType, error) {
+	var contentType models.ContentType
+	if err := contentTypeCollection.FindOne(ctx, bson.M{"key": key}).Decode(&contentType); err != nil {
+		return nil, err
+	}
+	return &contentType, nil
+}
+
+// UpdateContentType replaces the definition of a content type; anahtar değiştirilemez.
+// Mevcut kayıtlar taşınmaz, bir sonraki kaydetmede yeni şemaya göre doğrulanır.
+func UpdateContentType(ctx context.Context, key string, contentType *models.ContentType) error {
+	contentType.Key = key
+	if err := validateContentType(ctx, contentType); err != nil {
+		return err
+	}
+
+	contentType.UpdatedAt = time.Now()
+	result, err := contentTypeCollection.UpdateOne(ctx, bson.M{"key": key}, bson.M{"$set": bson.M{
+		"names":       contentType.Names,
+		"description": contentType.Description,
+		"fields":      contentType.Fields,
+		"public":      contentType.Public,
+		"updated_at":  contentType.UpdatedAt,
+		"updated_by":  contentType.UpdatedBy,
+	}})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// DeleteContentType removes a content type that has no entries
+func DeleteContentType(ctx context.Context, key string) error {
+	count, err := contentEntryCollection.CountDocuments(ctx, bson.M{"type": key}, options.Count().SetLimit(1))
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrContentTypeInUse
+	}
+
+	result, err := contentTypeCollection.DeleteOne(ctx, bson.M{"key": key})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// validateContentType checks the schema and that relation fields point to existing content types
+func validateContentType(ctx context.Context, contentType *models.ContentType) error {
+	errs := utils.ValidateContentType(*contentType)
+
+	var checkRelations func(fields []models.ContentField, prefix string) error
+	checkRelations = func(fields []models.ContentField, prefix string) error {
+		for _, field := range fields {
+			path := prefix + "." + field.Key
+			switch {
+			case field.Type == models.FieldRelation && field.Relation != "" && field.Relation != contentType.Key:
+				count, err := contentTypeCollection.CountDocuments(ctx, bson.M{"key": field.Relation}, options.Count().SetLimit(1))
+				if err != nil {
+					return err
+				}
+				if count == 0 {
+					errs = append(errs, models.FieldError{Field: path + ".relation", Message: "unknown content type " + field.Relation})
+				}
+			case field.Type == models.FieldRepeater:
+				if err := checkRelations(field.Fields, path); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	if err := checkRelations(contentType.Fields, "fields"); err != nil {
+		return err
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
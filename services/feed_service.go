@@ -0,0 +1,204 @@
+package services
+
+import (
+	"admin-panel/models"
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Bir akıştaki azami öğe sayısı
+const MaxFeedItems = 100
+
+// Akıştaki varsayılan öğe sayısı (FEED_ITEM_COUNT), varsayılan 20
+var FeedItemCount = func() int {
+	if v := os.Getenv("FEED_ITEM_COUNT"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 && n <= MaxFeedItems {
+			return n
+		}
+	}
+	return 20
+}()
+
+// Akış yanıtlarının önbellekte tutulabileceği süre (FEED_CACHE_MAX_AGE, örn: "30m"), varsayılan 15 dakika
+var FeedCacheMaxAge = func() time.Duration {
+	if v := os.Getenv("FEED_CACHE_MAX_AGE"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d >= 0 {
+			return d
+		}
+	}
+	return 15 * time.Minute
+}()
+
+// SiteURL returns the public address of the site (SITE_URL); boşsa çağıran istek adresini kullanır
+func SiteURL() string {
+	return strings.TrimRight(os.Getenv("SITE_URL"), "/")
+}
+
+// BuildFeed collects the latest published posts of a language, category or tag as a feed
+func BuildFeed(ctx context.Context, query models.FeedQuery) (*models.Feed, error) {
+	feed := &models.Feed{Lang: query.Lang, SiteURL: query.SiteURL, FeedURL: query.FeedURL, Items: []models.FeedItem{}}
+
+	settings, err := GetSettings()
+	if err != nil && err != mongo.ErrNoDocuments {
+		return nil, err
+	}
+	if settings != nil {
+		feed.Title, _, _ = ResolveTranslation(settings.Title, query.Lang)
+		feed.Description, _, _ = ResolveTranslation(settings.Description, query.Lang)
+		feed.Updated = settings.UpdatedAt
+	}
+
+	filter := PublishedFilter(time.Now())
+	filter["localizations."+query.Lang] = bson.M{"$exists": true}
+
+	// Kategori ve etiket akışlarının başlığı alt başlıkla genişletilir
+	var subtitle string
+	if query.CategoryID != nil {
+		category, err := GetCategoryByID(ctx, *query.CategoryID)
+		if err != nil {
+			return nil, err
+		}
+		field, _, _ := ResolveTranslation(category.Localizations, query.Lang)
+		subtitle = field.Title
+		filter["category_ids"] = category.ID
+	}
+	if query.TagID != nil {
+		var tag models.Tag
+		if err := tagCollection.FindOne(ctx, notTrashed(bson.M{"_id": *query.TagID})).Decode(&tag); err != nil {
+			return nil, err
+		}
+		subtitle = tag.Name
+		filter["tag_ids"] = tag.ID
+	}
+	if subtitle != "" {
+		feed.Title = strings.TrimPrefix(feed.Title+" - "+subtitle, " - ")
+	}
+
+	findOpts := options.Find().
+		SetSort(bson.D{{Key: "publish_date", Value: -1}, {Key: "created_at", Value: -1}}).
+		SetLimit(int64(query.Limit))
+	cursor, err := postCollection.Find(ctx, filter, findOpts)
+	if err != nil {
+		return nil, err
+	}
+	var posts []models.Post
+	if err := cursor.All(ctx, &posts); err != nil {
+		return nil, err
+	}
+
+	authors, err := feedAuthorNames(ctx, posts)
+	if err != nil {
+		return nil, err
+	}
+	categories, err := feedCategoryNames(ctx, posts, query.Lang)
+	if err != nil {
+		return nil, err
+	}
+
+	host := query.SiteURL
+	if parsed, err := url.Parse(query.SiteURL); err == nil && parsed.Host != "" {
+		host = parsed.Hostname()
+	}
+
+	for _, post := range posts {
+		field := post.Localizations[query.Lang]
+		published := post.CreatedAt
+		if post.PublishDate != nil {
+			published = *post.PublishDate
+		}
+		updated := post.UpdatedAt
+		if updated.Before(published) {
+			updated = published
+		}
+
+		item := models.FeedItem{
+			// Slug değişse de kimlik sabit kalır (RFC 4151 tag URI)
+			ID:        fmt.Sprintf("tag:%s,%s:posts/%s", host, published.UTC().Format("2006-01-02"), post.ID.Hex()),
+			Title:     field.Title,
+			URL:       query.SiteURL + "/" + query.Lang + "/" + field.Slug,
+			Author:    authors[post.AuthorID],
+			Published: published,
+			Updated:   updated,
+		}
+		item.Summary, _ = ContentSummary(field)
+		for _, id := range post.CategoryIDs {
+			if name := categories[id]; name != "" {
+				item.Categories = append(item.Categories, name)
+			}
+		}
+
+		if updated.After(feed.Updated) {
+			feed.Updated = updated
+		}
+		feed.Items = append(feed.Items, item)
+	}
+	return feed, nil
+}
+
+// feedAuthorNames returns the display names of the post authors
+func feedAuthorNames(ctx context.Context, posts []models.Post) (map[primitive.ObjectID]string, error) {
+	ids := []primitive.ObjectID{}
+	for _, post := range posts {
+		if !post.AuthorID.IsZero() {
+			ids = append(ids, post.AuthorID)
+		}
+	}
+
+	names := map[primitive.ObjectID]string{}
+	if len(ids) == 0 {
+		return names, nil
+	}
+	cursor, err := userCollection.Find(ctx, bson.M{"_id": bson.M{"$in": ids}},
+		options.Find().SetProjection(bson.M{"name": 1, "surname": 1, "full_name": 1}))
+	if err != nil {
+		return nil, err
+	}
+	var users []models.User
+	if err := cursor.All(ctx, &users); err != nil {
+		return nil, err
+	}
+	for _, user := range users {
+		name := user.FullName
+		if name == "" {
+			name = strings.TrimSpace(user.Name + " " + user.Surname)
+		}
+		names[user.ID] = name
+	}
+	return names, nil
+}
+
+// feedCategoryNames returns the category titles of the posts in the feed language
+func feedCategoryNames(ctx context.Context, posts []models.Post, lang string) (map[primitive.ObjectID]string, error) {
+	ids := []primitive.ObjectID{}
+	for _, post := range posts {
+		ids = append(ids, post.CategoryIDs...)
+	}
+
+	names := map[primitive.ObjectID]string{}
+	if len(ids) == 0 {
+		return names, nil
+	}
+	cursor, err := categoryCollection.Find(ctx, notTrashed(bson.M{"_id": bson.M{"$in": ids}}))
+	if err != nil {
+		return nil, err
+	}
+	var categories []models.Category
+	if err := cursor.All(ctx, &categories); err != nil {
+		return nil, err
+	}
+	for _, category := range categories {
+		field, _, _ := ResolveTranslation(category.Localizations, lang)
+		names[category.ID] = field.Title
+	}
+	return names, nil
+}
@@ -3,27 +3,60 @@ package services
 import (
 	"admin-panel/configs"
 	"admin-panel/models"
+	"admin-panel/utils"
 	"context"
+	"errors"
 	"fmt"
+	"log"
+	"strings"
+	"sync"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 var languageCollection *mongo.Collection
 
+var (
+	ErrInvalidLanguageCode = errors.New("invalid language code")
+	ErrUnknownLanguage     = errors.New("language is not defined or not enabled")
+)
+
+// Dil kayıtları her istekte okunmasın diye kısa süreli önbellek
+const languageCacheTTL = 30 * time.Second
+
+var languageCache struct {
+	sync.RWMutex
+	languages []models.Language
+	loadedAt  time.Time
+}
+
 func InitLanguageService(client *mongo.Client) {
 	languageCollection = client.Database("admin_panel").Collection("languages")
 }
 
 func CreateLanguage(language *models.Language) error {
+	if !utils.IsValidLanguageCode(language.Code) {
+		return ErrInvalidLanguageCode
+	}
+	for _, fallback := range language.Fallbacks {
+		if !utils.IsValidLanguageCode(fallback) {
+			return ErrInvalidLanguageCode
+		}
+	}
+
 	language.ID = primitive.NewObjectID()
 	language.CreatedAt = primitive.NewDateTimeFromTime(time.Now())
 	language.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
 
 	_, err := languageCollection.InsertOne(context.Background(), language)
+	invalidateLanguageCache()
+	if err == nil && language.IsDefault {
+		return SetDefaultLanguage(language.Code)
+	}
 	return err
 }
 
@@ -48,20 +81,162 @@ func GetLanguages() ([]models.Language, error) {
 }
 
 func UpdateLanguage(id primitive.ObjectID, update bson.M) error {
+	if code, ok := update["code"].(string); ok && !utils.IsValidLanguageCode(code) {
+		return ErrInvalidLanguageCode
+	}
+
 	update["updated_at"] = primitive.NewDateTimeFromTime(time.Now())
 	_, err := languageCollection.UpdateOne(
 		context.Background(),
 		bson.M{"_id": id},
 		bson.M{"$set": update},
 	)
-	return err
+	invalidateLanguageCache()
+	if err != nil {
+		return err
+	}
+
+	// Varsayılan dil tek olmalı; diğerleri ve ayarlar senkronize edilir
+	if isDefault, ok := update["is_default"].(bool); ok && isDefault {
+		var language models.Language
+		if err := languageCollection.FindOne(context.Background(), bson.M{"_id": id}).Decode(&language); err != nil {
+			return err
+		}
+		return SetDefaultLanguage(language.Code)
+	}
+	return nil
 }
 
 func DeleteLanguage(id primitive.ObjectID) error {
 	_, err := languageCollection.DeleteOne(context.Background(), bson.M{"_id": id})
+	invalidateLanguageCache()
+	return err
+}
+
+// SetDefaultLanguage marks code as the only default language and mirrors it to the application settings
+func SetDefaultLanguage(code string) error {
+	ctx := context.Background()
+	now := primitive.NewDateTimeFromTime(time.Now())
+
+	result, err := languageCollection.UpdateOne(ctx,
+		bson.M{"code": code, "enabled": true},
+		bson.M{"$set": bson.M{"is_default": true, "updated_at": now}},
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return ErrUnknownLanguage
+	}
+
+	_, err = languageCollection.UpdateMany(ctx,
+		bson.M{"code": bson.M{"$ne": code}, "is_default": true},
+		bson.M{"$set": bson.M{"is_default": false, "updated_at": now}},
+	)
+	invalidateLanguageCache()
+	if err != nil {
+		return err
+	}
+
+	_, err = settingsCollection.UpdateOne(ctx, bson.M{}, bson.M{"$set": bson.M{"default_lang": code}}, options.Update().SetUpsert(true))
 	return err
 }
 
+// DefaultLanguage returns the default language of the language registry.
+// Kayıtlı varsayılan dil yoksa configs.LanguageConfig.DefaultLanguage kullanılır.
+func DefaultLanguage() string {
+	for _, language := range cachedLanguages() {
+		if language.IsDefault && language.Enabled {
+			return language.Code
+		}
+	}
+	return configs.LanguageConfig.DefaultLanguage
+}
+
+// ActiveLanguages returns the codes of the enabled languages; dil kaydı yoksa yalnızca varsayılan dil döner
+func ActiveLanguages() []string {
+	codes := []string{}
+	for _, language := range cachedLanguages() {
+		if language.Enabled {
+			codes = append(codes, language.Code)
+		}
+	}
+	if len(codes) == 0 {
+		codes = append(codes, DefaultLanguage())
+	}
+	return codes
+}
+
+// LanguageChain returns the resolution order for lang: the language itself, its configured
+// fallbacks, its base language (de-AT -> de) and finally the default language.
+func LanguageChain(lang string) []string {
+	languages := map[string]models.Language{}
+	for _, language := range cachedLanguages() {
+		languages[language.Code] = language
+	}
+
+	chain := []string{}
+	add := func(code string) {
+		if code != "" && !containsString(chain, code) {
+			chain = append(chain, code)
+		}
+	}
+
+	add(lang)
+	for i := 0; i < len(chain); i++ {
+		for _, fallback := range languages[chain[i]].Fallbacks {
+			add(fallback)
+		}
+		if base, _, found := strings.Cut(chain[i], "-"); found {
+			add(base)
+		}
+	}
+	add(DefaultLanguage())
+	return chain
+}
+
+// ResolveTranslation returns the first translation found along the fallback chain of lang
+// together with the language it was found in.
+func ResolveTranslation[T any](translations map[string]T, lang string) (T, string, bool) {
+	for _, code := range LanguageChain(lang) {
+		if value, ok := translations[code]; ok {
+			return value, code, true
+		}
+	}
+	var zero T
+	return zero, "", false
+}
+
+func cachedLanguages() []models.Language {
+	languageCache.RLock()
+	if time.Since(languageCache.loadedAt) < languageCacheTTL {
+		defer languageCache.RUnlock()
+		return languageCache.languages
+	}
+	languageCache.RUnlock()
+
+	if languageCollection == nil {
+		return nil
+	}
+	languages, err := GetLanguages()
+	if err != nil {
+		log.Printf("Failed to load languages: %v", err)
+		return nil
+	}
+
+	languageCache.Lock()
+	languageCache.languages = languages
+	languageCache.loadedAt = time.Now()
+	languageCache.Unlock()
+	return languages
+}
+
+func invalidateLanguageCache() {
+	languageCache.Lock()
+	languageCache.loadedAt = time.Time{}
+	languageCache.Unlock()
+}
+
 // GetLanguagesWithActiveAndDefault fetches all enabled languages with active and default flags
 func GetLanguagesWithActiveAndDefault(activeLang string) ([]map[string]interface{}, error) {
 	//var languages []models.Language
@@ -79,10 +254,7 @@ func GetLanguagesWithActiveAndDefault(activeLang string) ([]map[string]interface
 			return nil, err
 		}
 
-		name, exists := lang.LocalizedNames[activeLang]
-		if !exists {
-			name = lang.LocalizedNames[configs.LanguageConfig.DefaultLanguage] // Varsayılan dil
-		}
+		name, _, _ := ResolveTranslation(lang.LocalizedNames, activeLang)
 
 		localizedLanguages = append(localizedLanguages, map[string]interface{}{
 			"code":       lang.Code,
@@ -17,14 +17,20 @@ func InitLocalizedContentService(client *mongo.Client) {
 	localizedContentCollection = client.Database("admin_panel").Collection("localized_contents")
 }
 
-// CreateLocalizedContent inserts a new localized content into the database
-func CreateLocalizedContent(ctx context.Context, content *models.LocalizedContent) error {
+// CreateLocalizedContent inserts a new localized content into the database and reports the markup removed from it
+func CreateLocalizedContent(ctx context.Context, content *models.LocalizedContent) ([]models.SanitizeReport, error) {
 	content.ID = primitive.NewObjectID()
 	content.CreatedAt = primitive.NewDateTimeFromTime(time.Now())
 	content.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
 
+	sanitizer := newContentSanitizer()
+	sanitizer.localizations("translations", content.Translations)
+
 	_, err := localizedContentCollection.InsertOne(ctx, content)
-	return err
+	if err == nil {
+		refreshTranslationMeta(ctx, "content", content.ID)
+	}
+	return sanitizer.report, err
 }
 
 // GetLocalizedContent retrieves localized content by ID
@@ -34,8 +40,21 @@ func GetLocalizedContent(ctx context.Context, id primitive.ObjectID) (*models.Lo
 	return &content, err
 }
 
-// UpdateLocalizedContent updates localized content by ID
-func UpdateLocalizedContent(ctx context.Context, id primitive.ObjectID, updates map[string]interface{}) error {
+// UpdateLocalizedContent updates localized content by ID and reports the markup removed from it
+func UpdateLocalizedContent(ctx context.Context, id primitive.ObjectID, updates map[string]interface{}) ([]models.SanitizeReport, error) {
+	sanitizer := newContentSanitizer()
+	if err := mergeDottedLocalizations(ctx, localizedContentCollection, bson.M{"_id": id}, "translations", updates); err != nil {
+		return nil, err
+	}
+	if raw, ok := updates["translations"]; ok {
+		translations, err := decodeLocalizations(raw)
+		if err != nil {
+			return nil, err
+		}
+		sanitizer.localizations("translations", translations)
+		updates["translations"] = translations
+	}
+
 	updates["updated_at"] = primitive.NewDateTimeFromTime(time.Now())
 
 	_, err := localizedContentCollection.UpdateOne(
@@ -43,5 +62,8 @@ func UpdateLocalizedContent(ctx context.Context, id primitive.ObjectID, updates
 		bson.M{"_id": id},
 		bson.M{"$set": updates},
 	)
-	return err
+	if err == nil {
+		refreshTranslationMeta(ctx, "content", id)
+	}
+	return sanitizer.report, err
 }
@@ -0,0 +1,57 @@
+package services
+
+import (
+	"admin-panel/models"
+	"admin-panel/utils"
+)
+
+// Özet için azami karakter sayısı
+const excerptLength = 200
+
+// renderLocalizations validates the body formats, renders Markdown sources into Content and sanitizes every language
+func renderLocalizations(sanitizer *contentSanitizer, prefix string, localizations map[string]models.LocalizedField) error {
+	var errs utils.ValidationErrors
+	for lang, field := range localizations {
+		path := prefix + "." + lang
+		switch field.Format {
+		case "", models.FormatHTML:
+			field.Markdown = "" // HTML gövdede Markdown kaynağı tutulmaz
+		case models.FormatMarkdown:
+			if len(field.Blocks) > 0 {
+				errs = append(errs, models.FieldError{Field: path + ".blocks", Message: "blocks cannot be combined with the markdown format"})
+				continue
+			}
+			field.Content = utils.RenderMarkdown(field.Markdown)
+		default:
+			errs = append(errs, models.FieldError{Field: path + ".format", Message: "format must be html or markdown"})
+			continue
+		}
+		localizations[lang] = field
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+
+	// Markdown'dan üretilen HTML de rich_text politikasıyla temizlenir
+	sanitizer.localizations(prefix, localizations)
+	return nil
+}
+
+// summarizeLocalizations fills the plain-text excerpt and the reading time of every language
+func summarizeLocalizations(localizations map[string]models.LocalizedField) {
+	for lang, field := range localizations {
+		text := utils.PlainText(field)
+		field.Excerpt = utils.Excerpt(text, excerptLength)
+		field.ReadingTime = utils.ReadingTime(text)
+		localizations[lang] = field
+	}
+}
+
+// ContentSummary returns the excerpt and reading time of a localized field; özeti olmayan eski kayıtlar için hesaplanır
+func ContentSummary(field models.LocalizedField) (string, int) {
+	if field.Excerpt != "" || field.ReadingTime > 0 {
+		return field.Excerpt, field.ReadingTime
+	}
+	text := utils.PlainText(field)
+	return utils.Excerpt(text, excerptLength), utils.ReadingTime(text)
+}
@@ -0,0 +1,243 @@
+package services
+
+import (
+	"admin-panel/models"
+	"admin-panel/utils"
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var mediaFolderCollection *mongo.Collection
+
+var (
+	ErrMediaFolderNotFound = errors.New("media folder not found")
+	ErrMediaFolderExists   = errors.New("a folder with the same name already exists in the target folder")
+	ErrMediaFolderNotEmpty = errors.New("media folder contains subfolders or media")
+	ErrMediaFolderCycle    = errors.New("a folder cannot be moved into itself or one of its subfolders")
+)
+
+func InitMediaFolderService(client *mongo.Client) {
+	mediaFolderCollection = client.Database("admin_panel").Collection("media_folders")
+
+	_, _ = mediaFolderCollection.Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+		{Keys: bson.D{{Key: "parent_id", Value: 1}, {Key: "name", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "ancestors", Value: 1}}},
+		{Keys: bson.D{{Key: "path", Value: 1}}},
+	})
+}
+
+// CreateMediaFolder creates a folder under folder.ParentID (nil: kök dizin)
+func CreateMediaFolder(ctx context.Context, folder *models.MediaFolder) error {
+	name, errs := utils.NormalizeMediaFolderName(folder.Name)
+	if len(errs) > 0 {
+		return errs
+	}
+	parent, err := mediaFolderParent(ctx, folder.ParentID)
+	if err != nil {
+		return err
+	}
+
+	folder.ID = primitive.NewObjectID()
+	folder.Name = name
+	folder.Ancestors, folder.Path = mediaFolderLocation(parent, name)
+	folder.CreatedAt = time.Now()
+	folder.UpdatedAt = folder.CreatedAt
+	folder.UpdatedBy = folder.CreatedBy
+	if _, err := mediaFolderCollection.InsertOne(ctx, folder); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return ErrMediaFolderExists
+		}
+		return err
+	}
+	return nil
+}
+
+// GetMediaFolders lists the folders matching the filter; varsayılan sıralama tam yola göredir
+func GetMediaFolders(ctx context.Context, filter bson.M, opts ListOptions) ([]models.MediaFolder, *PageInfo, error) {
+	return FindPage[models.MediaFolder](ctx, mediaFolderCollection, filter, opts)
+}
+
+// GetMediaFolder finds a folder by ID; bulunamazsa ErrMediaFolderNotFound döner
+func GetMediaFolder(ctx context.Context, id primitive.ObjectID) (*models.MediaFolder, error) {
+	var folder models.MediaFolder
+	err := mediaFolderCollection.FindOne(ctx, bson.M{"_id": id}).Decode(&folder)
+	if err == mongo.ErrNoDocuments {
+		return nil, ErrMediaFolderNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &folder, nil
+}
+
+// MediaFolderIDs returns the folder and, when recursive is set, all of its subfolders
+func MediaFolderIDs(ctx context.Context, id primitive.ObjectID, recursive bool) ([]primitive.ObjectID, error) {
+	if _, err := GetMediaFolder(ctx, id); err != nil {
+		return nil, err
+	}
+	ids := []primitive.ObjectID{id}
+	if !recursive {
+		return ids, nil
+	}
+	descendants, err := mediaFolderCollection.Distinct(ctx, "_id", bson.M{"ancestors": id})
+	if err != nil {
+		return nil, err
+	}
+	for _, value := range descendants {
+		if descendant, ok := value.(primitive.ObjectID); ok {
+			ids = append(ids, descendant)
+		}
+	}
+	return ids, nil
+}
+
+// RenameMediaFolder changes the name of a folder; alt klasörlerin yolları da güncellenir
+func RenameMediaFolder(ctx context.Context, id primitive.ObjectID, name, updatedBy string) (*models.MediaFolder, error) {
+	name, errs := utils.NormalizeMediaFolderName(name)
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	folder, err := GetMediaFolder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	parent, err := mediaFolderParent(ctx, folder.ParentID)
+	if err != nil {
+		return nil, err
+	}
+	return relocateMediaFolder(ctx, folder, parent, name, updatedBy)
+}
+
+// MoveMediaFolder moves a folder with its subfolders and media under another folder (nil: kök dizin)
+func MoveMediaFolder(ctx context.Context, id primitive.ObjectID, parentID *primitive.ObjectID, updatedBy string) (*models.MediaFolder, error) {
+	folder, err := GetMediaFolder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	parent, err := mediaFolderParent(ctx, parentID)
+	if err != nil {
+		return nil, err
+	}
+	if parent != nil && (parent.ID == id || containsObjectID(parent.Ancestors, id)) {
+		return nil, ErrMediaFolderCycle
+	}
+	return relocateMediaFolder(ctx, folder, parent, folder.Name, updatedBy)
+}
+
+// DeleteMediaFolder removes an empty folder; çöp kutusundaki medya kök dizine alınır
+func DeleteMediaFolder(ctx context.Context, id primitive.ObjectID) error {
+	if _, err := GetMediaFolder(ctx, id); err != nil {
+		return err
+	}
+	if count, err := mediaFolderCollection.CountDocuments(ctx, bson.M{"parent_id": id}, options.Count().SetLimit(1)); err != nil {
+		return err
+	} else if count > 0 {
+		return ErrMediaFolderNotEmpty
+	}
+	if count, err := mediaCollection.CountDocuments(ctx, notTrashed(bson.M{"folder_id": id}), options.Count().SetLimit(1)); err != nil {
+		return err
+	} else if count > 0 {
+		return ErrMediaFolderNotEmpty
+	}
+
+	if _, err := mediaFolderCollection.DeleteOne(ctx, bson.M{"_id": id}); err != nil {
+		return err
+	}
+	_, err := mediaCollection.UpdateMany(ctx, bson.M{"folder_id": id}, bson.M{"$unset": bson.M{"folder_id": ""}})
+	return err
+}
+
+// mediaFolderParent loads the parent of a new or moved folder; nil kök dizindir
+func mediaFolderParent(ctx context.Context, parentID *primitive.ObjectID) (*models.MediaFolder, error) {
+	if parentID == nil || parentID.IsZero() {
+		return nil, nil
+	}
+	parent, err := GetMediaFolder(ctx, *parentID)
+	if errors.Is(err, ErrMediaFolderNotFound) {
+		return nil, utils.ValidationErrors{{Field: "parent_id", Message: "folder not found"}}
+	}
+	return parent, err
+}
+
+// mediaFolderLocation returns the ancestors and the path of a folder named name under parent
+func mediaFolderLocation(parent *models.MediaFolder, name string) ([]primitive.ObjectID, string) {
+	if parent == nil {
+		return []primitive.ObjectID{}, utils.MediaFolderPath("", name)
+	}
+	ancestors := append(append([]primitive.ObjectID{}, parent.Ancestors...), parent.ID)
+	return ancestors, utils.MediaFolderPath(parent.Path, name)
+}
+
+// relocateMediaFolder stores the new parent and name of a folder and rewrites the ancestors and paths of its subfolders
+func relocateMediaFolder(ctx context.Context, folder, parent *models.MediaFolder, name, updatedBy string) (*models.MediaFolder, error) {
+	ancestors, path := mediaFolderLocation(parent, name)
+	var parentID *primitive.ObjectID
+	if parent != nil {
+		parentID = &parent.ID
+	}
+	now := time.Now()
+	_, err := mediaFolderCollection.UpdateByID(ctx, folder.ID, bson.M{"$set": bson.M{
+		"name":       name,
+		"parent_id":  parentID,
+		"ancestors":  ancestors,
+		"path":       path,
+		"updated_at": now,
+		"updated_by": updatedBy,
+	}})
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, ErrMediaFolderExists
+		}
+		return nil, err
+	}
+
+	// Alt klasörler: taşınan klasöre kadarki üst klasörler ve yol öneki değişir
+	cursor, err := mediaFolderCollection.Find(ctx, bson.M{"ancestors": folder.ID})
+	if err != nil {
+		return nil, err
+	}
+	var descendants []models.MediaFolder
+	if err := cursor.All(ctx, &descendants); err != nil {
+		return nil, err
+	}
+	var writes []mongo.WriteModel
+	for _, descendant := range descendants {
+		index := indexOfObjectID(descendant.Ancestors, folder.ID)
+		descendantAncestors := append(append(append([]primitive.ObjectID{}, ancestors...), folder.ID), descendant.Ancestors[index+1:]...)
+		writes = append(writes, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": descendant.ID}).
+			SetUpdate(bson.M{"$set": bson.M{
+				"ancestors": descendantAncestors,
+				"path":      path + strings.TrimPrefix(descendant.Path, folder.Path),
+			}}))
+	}
+	if len(writes) > 0 {
+		if _, err := mediaFolderCollection.BulkWrite(ctx, writes); err != nil {
+			return nil, err
+		}
+	}
+
+	folder.Name, folder.ParentID, folder.Ancestors, folder.Path = name, parentID, ancestors, path
+	folder.UpdatedAt, folder.UpdatedBy = now, updatedBy
+	return folder, nil
+}
+
+func containsObjectID(ids []primitive.ObjectID, id primitive.ObjectID) bool {
+	return indexOfObjectID(ids, id) >= 0
+}
+
+func indexOfObjectID(ids []primitive.ObjectID, id primitive.ObjectID) int {
+	for i, candidate := range ids {
+		if candidate == id {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,248 @@
+package services
+
+import (
+	"admin-panel/configs"
+	"admin-panel/models"
+	"admin-panel/utils"
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"image"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	ErrNotAnImage    = errors.New("media file is not a supported image")
+	ErrImageTooLarge = errors.New("image is too large to process")
+)
+
+const defaultImageRenditions = "thumbnail:150x150:cover,medium:800x0,large:1600x0"
+
+var (
+	// Yüklenen her görsel için üretilen boyutlar (MEDIA_RENDITIONS, örn: "thumbnail:150x150:cover,medium:800x0")
+	ImageRenditions, _ = utils.ParseImageRenditions(defaultImageRenditions)
+
+	// Her boyutun WebP kopyası da üretilir (MEDIA_WEBP=false ile kapatılır)
+	imageWebPVariants = true
+)
+
+// Aynı anda çalışan isteğe bağlı boyutlandırma sayısı
+var imageTransformSlots = make(chan struct{}, runtime.NumCPU())
+
+// imageSettings reads MEDIA_RENDITIONS and MEDIA_WEBP; geçersiz tanımda varsayılan boyutlar kullanılır
+func imageSettings() ([]models.ImageRenditionSpec, bool) {
+	specs, _ := utils.ParseImageRenditions(defaultImageRenditions)
+	if v := os.Getenv("MEDIA_RENDITIONS"); v != "" {
+		parsed, err := utils.ParseImageRenditions(v)
+		if err == nil {
+			specs = parsed
+		} else {
+			log.Printf("Invalid MEDIA_RENDITIONS, using defaults: %v", err)
+		}
+	}
+	return specs, os.Getenv("MEDIA_WEBP") != "false"
+}
+
+// ProcessImage reads the dimensions and dominant colour of an uploaded image and stores its renditions
+// next to the original (renditions/<id>/<ad>.<uzantı>). Görsel olmayan dosyalarda ErrNotAnImage döner ve kayıt değişmez.
+func ProcessImage(ctx context.Context, media *models.Media, content io.Reader) error {
+	img, format, err := decodeMediaImage(content)
+	if err != nil {
+		return err
+	}
+	storage, err := GetStorage(media.Storage)
+	if err != nil {
+		return err
+	}
+
+	bounds := img.Bounds()
+	media.Width, media.Height = bounds.Dx(), bounds.Dy()
+	media.DominantColor = utils.DominantColor(img)
+	previous := media.Renditions
+	media.Renditions = nil
+
+	formats := []string{utils.ImageOutputFormat(format)}
+	if imageWebPVariants && formats[0] != "webp" {
+		formats = append(formats, "webp")
+	}
+	keys := map[string]bool{}
+	for _, spec := range ImageRenditions {
+		resized := utils.ResizeImage(img, spec.Width, spec.Height, spec.Fit)
+		for _, output := range formats {
+			var buf bytes.Buffer
+			if err := utils.EncodeImage(&buf, resized, output, 0); err != nil {
+				return err
+			}
+			key := "renditions/" + media.ID.Hex() + "/" + spec.Name + utils.ImageExtension(output)
+			size := int64(buf.Len())
+			if err := storage.Put(ctx, key, &buf, size, utils.ImageContentType(output)); err != nil {
+				return err
+			}
+			keys[key] = true
+			media.Renditions = append(media.Renditions, models.MediaRendition{
+				Name:       spec.Name,
+				Format:     output,
+				Width:      resized.Bounds().Dx(),
+				Height:     resized.Bounds().Dy(),
+				StorageKey: key,
+				URL:        storage.URL(key),
+				FileSize:   size,
+			})
+		}
+	}
+
+	// Artık tanımlı olmayan boyutlar silinir; bağlı kayıtlardan gelen (başka kimliğe ait) boyutlar korunur
+	for _, rendition := range previous {
+		if !keys[rendition.StorageKey] && strings.HasPrefix(rendition.StorageKey, "renditions/"+media.ID.Hex()+"/") {
+			if err := storage.Delete(ctx, rendition.StorageKey); err != nil {
+				log.Printf("Failed to delete rendition %s: %v", rendition.StorageKey, err)
+			}
+		}
+	}
+	return nil
+}
+
+// RegenerateMediaRenditions processes a stored image again, örn: MEDIA_RENDITIONS değiştiğinde
+func RegenerateMediaRenditions(ctx context.Context, id primitive.ObjectID) (*models.Media, error) {
+	media, err := GetMediaByID(id)
+	if err != nil {
+		return nil, err
+	}
+	content, err := openMediaFile(ctx, media)
+	if err != nil {
+		return nil, err
+	}
+	defer content.Close()
+	if err := ProcessImage(ctx, media, content); err != nil {
+		return nil, err
+	}
+	if err := removeMediaCache(id); err != nil {
+		log.Printf("Failed to clear image cache of media %s: %v", id.Hex(), err)
+	}
+
+	_, err = mediaCollection.UpdateByID(ctx, id, bson.M{"$set": bson.M{
+		"width":          media.Width,
+		"height":         media.Height,
+		"dominant_color": media.DominantColor,
+		"renditions":     media.Renditions,
+	}})
+	if err != nil {
+		return nil, err
+	}
+	return media, nil
+}
+
+// SignImageTransform returns the signature of a transform of a media file; imza kanonik parametrelerden üretilir
+func SignImageTransform(id primitive.ObjectID, transform models.ImageTransform) string {
+	mac := hmac.New(sha256.New, []byte(configs.GetJWTSecret()))
+	mac.Write([]byte("media-transform:" + id.Hex() + "/" + transform.String()))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil)[:16])
+}
+
+// VerifyImageTransform reports whether a signature belongs to the transform of a media file
+func VerifyImageTransform(id primitive.ObjectID, transform models.ImageTransform, signature string) bool {
+	return hmac.Equal([]byte(signature), []byte(SignImageTransform(id, transform)))
+}
+
+// TransformMediaImage returns the path of a resized copy of a media image, generating and caching it on disk when missing
+func TransformMediaImage(ctx context.Context, id primitive.ObjectID, transform models.ImageTransform) (string, string, error) {
+	media, err := GetMediaByID(id)
+	if err != nil {
+		return "", "", err
+	}
+
+	format := transform.Format
+	if format == "" {
+		format = utils.ImageOutputFormat(strings.TrimPrefix(media.FileType, "."))
+	}
+	path := filepath.Join(mediaCacheDir(id), transform.String()+utils.ImageExtension(format))
+	if _, err := os.Stat(path); err == nil {
+		return path, utils.ImageContentType(format), nil
+	}
+
+	select {
+	case imageTransformSlots <- struct{}{}:
+		defer func() { <-imageTransformSlots }()
+	case <-ctx.Done():
+		return "", "", ctx.Err()
+	}
+
+	content, err := openMediaFile(ctx, media)
+	if err != nil {
+		return "", "", err
+	}
+	defer content.Close()
+	img, _, err := decodeMediaImage(content)
+	if err != nil {
+		return "", "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return "", "", err
+	}
+	resized := utils.ResizeImage(img, transform.Width, transform.Height, transform.Fit)
+	if _, err := writeImageFile(path, resized, format, transform.Quality); err != nil {
+		return "", "", err
+	}
+	return path, utils.ImageContentType(format), nil
+}
+
+// mediaCacheDir is the local directory of the cached resizes of a media item; sürücüden bağımsız olarak diskte tutulur
+func mediaCacheDir(id primitive.ObjectID) string {
+	return filepath.Join(LocalStorageRoot, "cache", id.Hex())
+}
+
+func removeMediaCache(id primitive.ObjectID) error {
+	return os.RemoveAll(mediaCacheDir(id))
+}
+
+// decodeMediaImage decodes an image after checking its format and pixel count
+func decodeMediaImage(content io.Reader) (image.Image, string, error) {
+	// Başlık okunurken tüketilen baytlar çözümleme için saklanır
+	var header bytes.Buffer
+	config, _, err := image.DecodeConfig(io.TeeReader(content, &header))
+	if err != nil {
+		return nil, "", ErrNotAnImage
+	}
+	if config.Width*config.Height > utils.MaxImagePixels {
+		return nil, "", ErrImageTooLarge
+	}
+	img, format, err := image.Decode(io.MultiReader(&header, content))
+	if err != nil {
+		return nil, "", ErrNotAnImage
+	}
+	return img, format, nil
+}
+
+// writeImageFile encodes an image to a temporary file and moves it into place, böylece yarım dosya yayınlanmaz
+func writeImageFile(path string, img image.Image, format string, quality int) (int64, error) {
+	file, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return 0, err
+	}
+	defer os.Remove(file.Name())
+
+	if err := utils.EncodeImage(file, img, format, quality); err != nil {
+		file.Close()
+		return 0, err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return 0, err
+	}
+	if err := file.Close(); err != nil {
+		return 0, err
+	}
+	return info.Size(), os.Rename(file.Name(), path)
+}
@@ -0,0 +1,137 @@
+package services
+
+import (
+	"admin-panel/models"
+	"admin-panel/utils"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// MigrateMediaStorage copies the files of all media items (çöp kutusundakiler dahil) from one backend to another
+// and updates their records. deleteSource true ise kopyalanan dosyalar kaynaktan silinir.
+// Taşınamayan öğeler atlanır ve sonuçta listelenir; işlem yarıda kalırsa tekrar çalıştırılabilir.
+func MigrateMediaStorage(ctx context.Context, from, to string, deleteSource bool) (*models.MediaMigrationResult, error) {
+	if from == to {
+		return nil, fmt.Errorf("source and target storage are the same (%s)", from)
+	}
+	source, err := GetStorage(from)
+	if err != nil {
+		return nil, err
+	}
+	target, err := GetStorage(to)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := bson.M{"storage": from}
+	if from == "local" {
+		// Sürücüsü kaydedilmemiş eski kayıtlar yereldir
+		filter = bson.M{"$or": bson.A{bson.M{"storage": "local"}, bson.M{"storage": bson.M{"$exists": false}}, bson.M{"storage": ""}}}
+	}
+	cursor, err := mediaCollection.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	result := &models.MediaMigrationResult{From: from, To: to}
+	for cursor.Next(ctx) {
+		var media models.Media
+		if err := cursor.Decode(&media); err != nil {
+			return nil, err
+		}
+
+		err := migrateMedia(ctx, &media, source, target, deleteSource)
+		switch {
+		case errors.Is(err, utils.ErrStorageNotFound):
+			log.Printf("Media %s has no file in %s storage, skipped", media.ID.Hex(), from)
+			result.Skipped++
+		case err != nil:
+			log.Printf("Failed to migrate media %s: %v", media.ID.Hex(), err)
+			result.Failed = append(result.Failed, models.MediaMigrationFailure{ID: media.ID, Error: err.Error()})
+		default:
+			result.Moved++
+		}
+	}
+	return result, cursor.Err()
+}
+
+// migrateMedia copies the original and the renditions of a media item and points its record to the target
+func migrateMedia(ctx context.Context, media *models.Media, source, target utils.Storage, deleteSource bool) error {
+	_, key, err := mediaLocation(media)
+	if err != nil {
+		return err
+	}
+	if err := copyStorageObject(ctx, source, target, key); err != nil {
+		return err
+	}
+	keys := []string{key}
+
+	renditions := media.Renditions[:0]
+	for _, rendition := range media.Renditions {
+		if err := copyStorageObject(ctx, source, target, rendition.StorageKey); err != nil {
+			if errors.Is(err, utils.ErrStorageNotFound) {
+				continue // Eksik boyut kayıttan çıkarılır; POST /media/:id/renditions ile yeniden üretilebilir
+			}
+			return err
+		}
+		rendition.URL = target.URL(rendition.StorageKey)
+		renditions = append(renditions, rendition)
+		keys = append(keys, rendition.StorageKey)
+	}
+	media.Renditions = renditions
+	media.StorageKey = key
+	setMediaLocation(media, target)
+
+	_, err = mediaCollection.UpdateByID(ctx, media.ID, bson.M{"$set": bson.M{
+		"storage":     media.Storage,
+		"storage_key": media.StorageKey,
+		"url":         media.URL,
+		"file_path":   media.FilePath,
+		"renditions":  media.Renditions,
+	}})
+	if err != nil {
+		return err
+	}
+
+	if deleteSource {
+		for _, key := range keys {
+			if err := source.Delete(ctx, key); err != nil {
+				log.Printf("Failed to delete %s from %s storage: %v", key, source.Name(), err)
+			}
+		}
+	}
+	return nil
+}
+
+// copyStorageObject copies an object between backends under the same key.
+// Kaynakta olmayıp hedefte bulunan nesne (aynı dosyayı paylaşan kayıtla taşınmış) kopyalanmış sayılır.
+func copyStorageObject(ctx context.Context, source, target utils.Storage, key string) error {
+	reader, object, err := source.Get(ctx, key)
+	if errors.Is(err, utils.ErrStorageNotFound) {
+		if _, statErr := target.Stat(ctx, key); statErr == nil {
+			return nil
+		}
+	}
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	// Boyutu bilinmeyen yanıtlar (örn: sıkıştırılmış aktarım) önce belleğe alınır
+	content, size := io.Reader(reader), object.Size
+	if size < 0 {
+		data, err := io.ReadAll(reader)
+		if err != nil {
+			return err
+		}
+		content, size = bytes.NewReader(data), int64(len(data))
+	}
+	return target.Put(ctx, key, content, size, object.ContentType)
+}
@@ -0,0 +1,396 @@
+package services
+
+import (
+	"admin-panel/models"
+	"admin-panel/utils"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/url"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var ErrMediaInUse = errors.New("media is still in use")
+
+var mediaReferenceCollection *mongo.Collection
+
+func InitMediaReferenceService(client *mongo.Client) {
+	mediaReferenceCollection = client.Database("admin_panel").Collection("media_references")
+
+	_, _ = mediaReferenceCollection.Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+		{Keys: bson.D{{Key: "media_id", Value: 1}}},
+		{Keys: bson.D{{Key: "source_type", Value: 1}, {Key: "source_id", Value: 1}}},
+	})
+}
+
+// mediaReferenceField is one field of a source that may link to media
+type mediaReferenceField struct {
+	name      string
+	ids       []primitive.ObjectID
+	addresses []string
+}
+
+// htmlReferenceField collects the media links of an HTML body
+func htmlReferenceField(name, content string) mediaReferenceField {
+	ids, addresses := utils.MediaLinksInHTML(content)
+	return mediaReferenceField{name: name, ids: ids, addresses: addresses}
+}
+
+// urlReferenceField is a field holding a single media address (örn: logo_url)
+func urlReferenceField(name, address string) mediaReferenceField {
+	ids, addresses := utils.MediaLinksInURLs(address)
+	return mediaReferenceField{name: name, ids: ids, addresses: addresses}
+}
+
+// loadMediaReferenceSource reads a source (çöp kutusundakiler dahil) and returns its label and fields.
+// Ayarlar tek belgedir; kimliği belgeden okunur. Kaynak yoksa found false döner.
+func loadMediaReferenceSource(ctx context.Context, sourceType string, id primitive.ObjectID) (primitive.ObjectID, string, []mediaReferenceField, bool, error) {
+	var fields []mediaReferenceField
+	localized := func(localizations map[string]models.LocalizedField, metaTags map[string]models.MetaTag) {
+		for lang, field := range localizations {
+			fields = append(fields, htmlReferenceField("localizations."+lang+".content", field.Content))
+		}
+		for lang, meta := range metaTags {
+			if meta.ImageID != nil {
+				fields = append(fields, mediaReferenceField{name: "meta_tags." + lang + ".image_id", ids: []primitive.ObjectID{*meta.ImageID}})
+			}
+		}
+	}
+
+	var err error
+	var label string
+	switch sourceType {
+	case models.MediaSourcePosts:
+		var post models.Post
+		if err = postCollection.FindOne(ctx, bson.M{"_id": id}).Decode(&post); err == nil {
+			label = localizedTitle(post.Localizations)
+			localized(post.Localizations, post.MetaTags)
+		}
+	case models.MediaSourcePages:
+		var page models.Page
+		if err = pageCollection.FindOne(ctx, bson.M{"_id": id}).Decode(&page); err == nil {
+			label = localizedTitle(page.Localizations)
+			localized(page.Localizations, page.MetaTags)
+		}
+	case models.MediaSourceSliders:
+		var slider models.Slider
+		if err = sliderCollection.FindOne(ctx, bson.M{"_id": id}).Decode(&slider); err == nil {
+			label = slider.Name
+			for i, image := range slider.Images {
+				fields = append(fields, urlReferenceField(fmt.Sprintf("images[%d]", i), image))
+			}
+		}
+	case models.MediaSourceSettings:
+		var settings models.ApplicationSettings
+		if err = settingsCollection.FindOne(ctx, bson.M{}).Decode(&settings); err == nil {
+			id, label = settings.ID, "Site settings"
+			fields = append(fields, urlReferenceField("logo_url", settings.LogoURL), urlReferenceField("favicon_url", settings.FaviconURL))
+		}
+	case models.MediaSourceUsers:
+		var user models.User
+		if err = userCollection.FindOne(ctx, bson.M{"_id": id}, options.FindOne().SetProjection(bson.M{"username": 1, "avatar_url": 1})).Decode(&user); err == nil {
+			label = user.Username
+			fields = append(fields, urlReferenceField("avatar_url", user.AvatarURL))
+		}
+	default:
+		return id, "", nil, false, fmt.Errorf("unknown media reference source: %s", sourceType)
+	}
+	if err == mongo.ErrNoDocuments {
+		return id, "", nil, false, nil
+	}
+	return id, label, fields, err == nil, err
+}
+
+// localizedTitle returns the title in the default language, yoksa ilk dildeki başlık
+func localizedTitle(localizations map[string]models.LocalizedField) string {
+	if field, ok := localizations[DefaultLanguage()]; ok && field.Title != "" {
+		return field.Title
+	}
+	langs := make([]string, 0, len(localizations))
+	for lang := range localizations {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	for _, lang := range langs {
+		if title := localizations[lang].Title; title != "" {
+			return title
+		}
+	}
+	return ""
+}
+
+// RefreshMediaReferences rebuilds the references of one source; silinmiş kaynakların kayıtları kaldırılır
+func RefreshMediaReferences(ctx context.Context, sourceType string, id primitive.ObjectID) error {
+	sourceID, label, fields, found, err := loadMediaReferenceSource(ctx, sourceType, id)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return RemoveMediaReferences(ctx, sourceType, id)
+	}
+
+	resolved, err := resolveMediaLinks(ctx, fields)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	var references []interface{}
+	seen := map[string]bool{}
+	for _, field := range fields {
+		for _, mediaID := range resolved[field.name] {
+			key := field.name + "/" + mediaID.Hex()
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			references = append(references, models.MediaReference{
+				MediaID:    mediaID,
+				SourceType: sourceType,
+				SourceID:   sourceID,
+				Field:      field.name,
+				Label:      label,
+				UpdatedAt:  now,
+			})
+		}
+	}
+
+	if err := RemoveMediaReferences(ctx, sourceType, sourceID); err != nil {
+		return err
+	}
+	if len(references) == 0 {
+		return nil
+	}
+	_, err = mediaReferenceCollection.InsertMany(ctx, references)
+	return err
+}
+
+// RemoveMediaReferences deletes the references of a source, örn: kaynak kalıcı silindiğinde
+func RemoveMediaReferences(ctx context.Context, sourceType string, id primitive.ObjectID) error {
+	_, err := mediaReferenceCollection.DeleteMany(ctx, bson.M{"source_type": sourceType, "source_id": id})
+	return err
+}
+
+// refreshMediaReferences updates the reference index after a write; hata kaydı engellemez
+func refreshMediaReferences(ctx context.Context, sourceType string, id primitive.ObjectID) {
+	if mediaReferenceCollection == nil {
+		return
+	}
+	if err := RefreshMediaReferences(ctx, sourceType, id); err != nil {
+		log.Printf("Failed to update media references of %s %s: %v", sourceType, id.Hex(), err)
+	}
+}
+
+func removeMediaReferences(ctx context.Context, sourceType string, id primitive.ObjectID) {
+	if mediaReferenceCollection == nil {
+		return
+	}
+	if err := RemoveMediaReferences(ctx, sourceType, id); err != nil {
+		log.Printf("Failed to remove media references of %s %s: %v", sourceType, id.Hex(), err)
+	}
+}
+
+// resolveMediaLinks maps the IDs and addresses of every field to existing media items.
+// Adresler medya adresiyle, yerel dosya yoluyla veya bir boyutun adresiyle eşleşebilir; aynı dosyayı
+// paylaşan bağlı kayıtların hepsi kullanılıyor sayılır.
+func resolveMediaLinks(ctx context.Context, fields []mediaReferenceField) (map[string][]primitive.ObjectID, error) {
+	// $in boş dizi ister; nil dizi null olarak yazılır
+	ids, addresses, filePaths := []primitive.ObjectID{}, []string{}, []string{}
+	for _, field := range fields {
+		ids = append(ids, field.ids...)
+		for _, address := range field.addresses {
+			for _, candidate := range mediaAddressCandidates(address) {
+				addresses = append(addresses, candidate)
+				filePaths = append(filePaths, filepath.FromSlash(strings.TrimPrefix(candidate, "/")))
+			}
+		}
+	}
+	if len(ids) == 0 && len(addresses) == 0 {
+		return nil, nil
+	}
+
+	cursor, err := mediaCollection.Find(ctx, bson.M{"$or": bson.A{
+		bson.M{"_id": bson.M{"$in": ids}},
+		bson.M{"url": bson.M{"$in": addresses}},
+		bson.M{"file_path": bson.M{"$in": filePaths}},
+		bson.M{"renditions.url": bson.M{"$in": addresses}},
+	}}, options.Find().SetProjection(bson.M{"_id": 1, "url": 1, "file_path": 1, "renditions.url": 1}))
+	if err != nil {
+		return nil, err
+	}
+	var media []models.Media
+	if err := cursor.All(ctx, &media); err != nil {
+		return nil, err
+	}
+
+	known := map[primitive.ObjectID]bool{}
+	byAddress := map[string][]primitive.ObjectID{}
+	for _, item := range media {
+		known[item.ID] = true
+		byAddress[MediaURL(item)] = append(byAddress[MediaURL(item)], item.ID)
+		for _, rendition := range item.Renditions {
+			byAddress[rendition.URL] = append(byAddress[rendition.URL], item.ID)
+		}
+	}
+
+	resolved := map[string][]primitive.ObjectID{}
+	for _, field := range fields {
+		for _, id := range field.ids {
+			if known[id] {
+				resolved[field.name] = append(resolved[field.name], id)
+			}
+		}
+		for _, address := range field.addresses {
+			for _, candidate := range mediaAddressCandidates(address) {
+				if ids, ok := byAddress[candidate]; ok {
+					resolved[field.name] = append(resolved[field.name], ids...)
+					break
+				}
+			}
+		}
+	}
+	return resolved, nil
+}
+
+// mediaAddressCandidates returns the forms a media address may be stored in; site adresiyle yazılmış
+// yerel dosyalar (https://site/uploads/a.png) yol olarak da aranır
+func mediaAddressCandidates(address string) []string {
+	candidates := []string{address}
+	if !strings.HasPrefix(address, "/") && !strings.Contains(address, "://") {
+		candidates = append(candidates, "/"+address)
+	}
+	if parsed, err := url.Parse(address); err == nil && parsed.Host != "" && strings.HasPrefix(parsed.Path, LocalStorageURL+"/") {
+		candidates = append(candidates, parsed.Path)
+	}
+	return candidates
+}
+
+// GetMediaReferences lists where a media item is used
+func GetMediaReferences(ctx context.Context, mediaID primitive.ObjectID) ([]models.MediaReference, error) {
+	cursor, err := mediaReferenceCollection.Find(ctx, bson.M{"media_id": mediaID}, options.Find().SetSort(bson.D{{Key: "source_type", Value: 1}, {Key: "label", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	references := []models.MediaReference{}
+	if err := cursor.All(ctx, &references); err != nil {
+		return nil, err
+	}
+	return references, nil
+}
+
+// GetOrphanedMedia lists the media items that are not used anywhere
+func GetOrphanedMedia(ctx context.Context, opts ListOptions) ([]models.Media, *PageInfo, error) {
+	used, err := mediaReferenceCollection.Distinct(ctx, "media_id", bson.M{})
+	if err != nil {
+		return nil, nil, err
+	}
+	if used == nil {
+		used = bson.A{}
+	}
+	return FindPage[models.Media](ctx, mediaCollection, notTrashed(bson.M{"_id": bson.M{"$nin": used}}), opts)
+}
+
+// RebuildMediaReferences scans every source again and adds the missing content hashes of older media.
+// Taramadan sonra güncellenmemiş kayıtlar (artık olmayan kaynaklar) silinir.
+func RebuildMediaReferences(ctx context.Context) (*models.MediaReferenceRebuildResult, error) {
+	started := time.Now()
+	result := &models.MediaReferenceRebuildResult{}
+
+	hashed, err := backfillMediaHashes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result.Hashed = hashed
+
+	sources := []struct {
+		sourceType string
+		collection *mongo.Collection
+	}{
+		{models.MediaSourcePosts, postCollection},
+		{models.MediaSourcePages, pageCollection},
+		{models.MediaSourceSliders, sliderCollection},
+		{models.MediaSourceUsers, userCollection},
+		{models.MediaSourceSettings, settingsCollection},
+	}
+	for _, source := range sources {
+		cursor, err := source.collection.Find(ctx, bson.M{}, options.Find().SetProjection(bson.M{"_id": 1}))
+		if err != nil {
+			return nil, err
+		}
+		var docs []struct {
+			ID primitive.ObjectID `bson:"_id"`
+		}
+		if err := cursor.All(ctx, &docs); err != nil {
+			return nil, err
+		}
+		for _, doc := range docs {
+			if err := RefreshMediaReferences(ctx, source.sourceType, doc.ID); err != nil {
+				return nil, err
+			}
+			result.Sources++
+		}
+	}
+
+	if _, err := mediaReferenceCollection.DeleteMany(ctx, bson.M{"updated_at": bson.M{"$lt": started}}); err != nil {
+		return nil, err
+	}
+	count, err := mediaReferenceCollection.CountDocuments(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	result.References = int(count)
+	return result, nil
+}
+
+// backfillMediaHashes computes the content hash of media uploaded before hashes were stored
+func backfillMediaHashes(ctx context.Context) (int, error) {
+	cursor, err := mediaCollection.Find(ctx, bson.M{"$or": bson.A{bson.M{"content_hash": bson.M{"$exists": false}}, bson.M{"content_hash": ""}}})
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	hashed := 0
+	for cursor.Next(ctx) {
+		var media models.Media
+		if err := cursor.Decode(&media); err != nil {
+			return hashed, err
+		}
+		content, err := openMediaFile(ctx, &media)
+		if err != nil {
+			log.Printf("Failed to open media %s for hashing: %v", media.ID.Hex(), err)
+			continue
+		}
+		hash, err := contentHash(content)
+		content.Close()
+		if err != nil {
+			log.Printf("Failed to hash media %s: %v", media.ID.Hex(), err)
+			continue
+		}
+		if _, err := mediaCollection.UpdateByID(ctx, media.ID, bson.M{"$set": bson.M{"content_hash": hash}}); err != nil {
+			return hashed, err
+		}
+		hashed++
+	}
+	return hashed, cursor.Err()
+}
+
+// contentHash returns the hex SHA-256 of a file
+func contentHash(content io.Reader) (string, error) {
+	hash := sha256.New()
+	if _, err := io.Copy(hash, content); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
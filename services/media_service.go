@@ -2,18 +2,40 @@ package services
 
 import (
 	"admin-panel/models"
+	"admin-panel/utils"
+	"bytes"
 	"context"
+	"errors"
+	"io"
+	"log"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// İmzalı medya adreslerinin varsayılan ve azami süresi
+const (
+	DefaultSignedMediaURLTTL = time.Hour
+	MaxSignedMediaURLTTL     = 7 * 24 * time.Hour
 )
 
 var mediaCollection *mongo.Collection
 
 func InitMediaService(client *mongo.Client) {
 	mediaCollection = client.Database("admin_panel").Collection("media")
+	ImageRenditions, imageWebPVariants = imageSettings()
+
+	_, _ = mediaCollection.Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+		{Keys: bson.D{{Key: "content_hash", Value: 1}}},
+		{Keys: bson.D{{Key: "dedupe_key", Value: 1}}, Options: options.Index().SetUnique(true).SetPartialFilterExpression(bson.M{"dedupe_key": bson.M{"$type": "string"}})},
+		{Keys: bson.D{{Key: "folder_id", Value: 1}}},
+		{Keys: bson.D{{Key: "tags", Value: 1}}},
+	})
 }
 
 func SaveMediaRecord(media models.Media) (*mongo.InsertOneResult, error) {
@@ -24,33 +46,258 @@ func SaveMediaRecord(media models.Media) (*mongo.InsertOneResult, error) {
 	return mediaCollection.InsertOne(ctx, media)
 }
 
-func GetAllMedia() ([]models.Media, error) {
-	var media []models.Media
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+// MediaUpload is a file uploaded to the media library
+type MediaUpload struct {
+	FileName   string
+	Content    io.ReadSeeker
+	Size       int64
+	UploadedBy string
+	Roles      []string // Yükleme politikaları bu rollere göre uygulanır
+	Dedupe     string   // Aynı içerik varsa: models.MediaDedupeReuse (varsayılan) veya models.MediaDedupeLink
+	FolderID   *primitive.ObjectID
+}
+
+// UploadMedia checks an uploaded file against the upload policies of the uploader's roles and stores it.
+// Tip, dosya adından değil içerikten tespit edilir; uzantı içerikle uyuşmalıdır. Görsellerin EXIF/GPS gibi
+// meta verileri kaldırılır ve dosya çakışmayan bir anahtarla (YYYY/MM/<id>-<ad>.<uzantı>) saklanır.
+// Aynı içerik (SHA-256) kütüphanede varsa dosya tekrar yazılmaz; mevcut kayıt ya da aynı dosyayı kullanan
+// yeni bir kayıt döner ve duplicate true olur. Reddedilen yüklemelerde *utils.UploadError döner.
+func UploadMedia(ctx context.Context, upload MediaUpload) (media *models.Media, duplicate bool, err error) {
+	name, uploadErr := utils.SanitizeFileName(upload.FileName)
+	if uploadErr != nil {
+		return nil, false, uploadErr
+	}
+	content, size := upload.Content, upload.Size
+	if size <= 0 {
+		return nil, false, &utils.UploadError{Code: utils.UploadErrorEmptyFile, Message: "File is empty"}
+	}
+	if upload.FolderID != nil {
+		if _, err := GetMediaFolder(ctx, *upload.FolderID); err != nil {
+			return nil, false, err
+		}
+	}
+
+	header := make([]byte, utils.UploadSniffLength)
+	n, err := io.ReadFull(content, header)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return nil, false, err
+	}
+	if _, err := content.Seek(0, io.SeekStart); err != nil {
+		return nil, false, err
+	}
+	mimeType, ext, uploadErr := utils.DetectUploadType(name, header[:n])
+	if uploadErr != nil {
+		return nil, false, uploadErr
+	}
+	if uploadErr := utils.CheckUploadRules(UploadRulesForRoles(upload.Roles), mimeType, size); uploadErr != nil {
+		return nil, false, uploadErr
+	}
+
+	// Görsel meta verileri (kamera, konum vb.) yeniden kodlamadan kaldırılır
+	if strings.HasPrefix(mimeType, "image/") {
+		data, err := io.ReadAll(content)
+		if err != nil {
+			return nil, false, err
+		}
+		stripped, err := utils.StripImageMetadata(data, mimeType)
+		if errors.Is(err, utils.ErrImageMetadataFormat) {
+			return nil, false, &utils.UploadError{Code: utils.UploadErrorTypeNotAllowed, Message: "Metadata cannot be removed from " + mimeType + " images; upload JPEG, PNG, WebP or GIF"}
+		}
+		if err != nil {
+			return nil, false, &utils.UploadError{Code: utils.UploadErrorCorruptFile, Message: "Image file is corrupt"}
+		}
+		content, size = bytes.NewReader(stripped), int64(len(stripped))
+	}
 
-	cursor, err := mediaCollection.Find(ctx, bson.M{})
+	// Özet, saklanacak (meta verisi temizlenmiş) içerikten hesaplanır
+	hash, err := contentHash(content)
 	if err != nil {
-		return nil, err
+		return nil, false, err
+	}
+	if _, err := content.Seek(0, io.SeekStart); err != nil {
+		return nil, false, err
+	}
+	existing, err := findMediaByHash(ctx, hash)
+	if err != nil {
+		return nil, false, err
+	}
+	if existing != nil {
+		return reuseMedia(existing, upload, name)
 	}
-	defer cursor.Close(ctx)
 
-	for cursor.Next(ctx) {
-		var m models.Media
-		if err := cursor.Decode(&m); err != nil {
-			return nil, err
+	now := time.Now()
+	storage := DefaultStorage()
+	media = &models.Media{
+		ID:          primitive.NewObjectID(),
+		FileName:    name,
+		FileType:    ext,
+		MimeType:    mimeType,
+		FileSize:    size,
+		UploadedAt:  now.Unix(),
+		UploadedBy:  upload.UploadedBy,
+		Storage:     storage.Name(),
+		ContentHash: hash,
+		DedupeKey:   hash,
+		FolderID:    upload.FolderID,
+	}
+	media.StorageKey = utils.UploadStorageKey(media.ID, name, ext, now)
+	if err := storage.Put(ctx, media.StorageKey, content, size, mimeType); err != nil {
+		return nil, false, err
+	}
+	setMediaLocation(media, storage)
+
+	// Görsellerin boyutları ve küçük kopyaları; işlenemeyen görseller özgün haliyle kaydedilir
+	if _, err := content.Seek(0, io.SeekStart); err != nil {
+		return nil, false, err
+	}
+	if err := ProcessImage(ctx, media, content); err != nil && !errors.Is(err, ErrNotAnImage) {
+		log.Printf("Failed to process image %s: %v", media.StorageKey, err)
+	}
+
+	// Aynı içerik eşzamanlı yüklendiyse benzersiz indeks ikinci kaydı reddeder; bu yüklemenin dosyaları
+	// silinir ve kazanan kayıt kullanılır. Anahtar çöp kutusundaki bir kayıttaysa serbest bırakılıp tekrar denenir
+	for attempt := 0; ; attempt++ {
+		_, err := SaveMediaRecord(*media)
+		if err == nil {
+			return media, false, nil
+		}
+		if !mongo.IsDuplicateKeyError(err) || attempt == 2 {
+			return nil, false, err
+		}
+		existing, err := findMediaByHash(ctx, hash)
+		if err != nil {
+			return nil, false, err
+		}
+		if existing != nil {
+			if err := deleteMediaFiles(ctx, media); err != nil {
+				log.Printf("Failed to remove the files of duplicate upload %s: %v", media.StorageKey, err)
+			}
+			return reuseMedia(existing, upload, name)
+		}
+		if _, err := mediaCollection.UpdateOne(ctx, bson.M{"dedupe_key": hash, "deleted_at": bson.M{"$ne": nil}}, bson.M{"$unset": bson.M{"dedupe_key": ""}}); err != nil {
+			return nil, false, err
 		}
-		media = append(media, m)
 	}
+}
 
-	return media, nil
+// reuseMedia returns the media item that already has the uploaded content (dedupe=reuse) or a new item sharing its file (dedupe=link)
+func reuseMedia(existing *models.Media, upload MediaUpload, name string) (*models.Media, bool, error) {
+	if upload.Dedupe != models.MediaDedupeLink {
+		return existing, true, nil
+	}
+	// Aynı dosyayı ve boyutlarını kullanan yeni kayıt; dosyalar son bağlı kayıtla birlikte silinir.
+	// Klasör, metinler ve etiketler kayda özeldir, kopyalanmaz
+	linked := *existing
+	linked.ID = primitive.NewObjectID()
+	linked.FileName = name
+	linked.UploadedAt = time.Now().Unix()
+	linked.UploadedBy = upload.UploadedBy
+	linked.DedupeKey = ""
+	linked.FolderID = upload.FolderID
+	linked.Localizations, linked.Tags, linked.FocalPoint = nil, nil, nil
+	linked.UpdatedAt, linked.UpdatedBy = 0, ""
+	if _, err := SaveMediaRecord(linked); err != nil {
+		return nil, false, err
+	}
+	return &linked, true, nil
 }
 
-func DeleteMedia(id primitive.ObjectID) (*mongo.DeleteResult, error) {
+// findMediaByHash returns the oldest media item with the given content; yoksa nil döner
+func findMediaByHash(ctx context.Context, hash string) (*models.Media, error) {
+	var media models.Media
+	err := mediaCollection.FindOne(ctx, notTrashed(bson.M{"content_hash": hash}), options.FindOne().SetSort(bson.D{{Key: "_id", Value: 1}})).Decode(&media)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &media, nil
+}
+
+func GetAllMedia(opts ListOptions) ([]models.Media, *PageInfo, error) {
+	return GetFilteredMedia(bson.M{}, opts)
+}
+
+// DeleteMedia moves a media record to the trash; dosya kalıcı silmede kaldırılır.
+// Medya hâlâ kullanılıyorsa force verilmedikçe ErrMediaInUse döner; kullanım yerleri her durumda döner.
+// Kontrol referans dizinine dayanır ve çöpe taşımayla atomik değildir: arada eklenen bir kullanım engellenmez,
+// medya çöp kutusundan geri yüklenebilir ve dosya kalıcı silinene kadar korunur.
+func DeleteMedia(id primitive.ObjectID, deletedBy string, force bool) ([]models.MediaReference, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	return mediaCollection.DeleteOne(ctx, bson.M{"_id": id})
+	references, err := GetMediaReferences(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(references) > 0 && !force {
+		return references, ErrMediaInUse
+	}
+	return references, moveToTrash(ctx, "media", id, deletedBy)
+}
+
+// UpdateMediaMetadata changes the localized texts, tags and focal point of a media item
+func UpdateMediaMetadata(ctx context.Context, id primitive.ObjectID, update models.MediaMetadataUpdate, updatedBy string) (*models.Media, error) {
+	if errs := utils.ValidateMediaMetadata(&update, ActiveLanguages()); len(errs) > 0 {
+		return nil, errs
+	}
+
+	set := bson.M{"updated_at": time.Now().Unix(), "updated_by": updatedBy}
+	unset := bson.M{}
+	if update.Localizations != nil {
+		set["localizations"] = *update.Localizations
+	}
+	if update.Tags != nil {
+		set["tags"] = *update.Tags
+	}
+	if update.FocalPoint != nil {
+		set["focal_point"] = update.FocalPoint
+	} else if update.ClearFocalPoint {
+		unset["focal_point"] = ""
+	}
+	changes := bson.M{"$set": set}
+	if len(unset) > 0 {
+		changes["$unset"] = unset
+	}
+
+	var media models.Media
+	err := mediaCollection.FindOneAndUpdate(ctx, notTrashed(bson.M{"_id": id}), changes,
+		options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&media)
+	if err != nil {
+		return nil, err
+	}
+	return &media, nil
+}
+
+// MoveMedia moves media items into a folder (nil: kök dizin) and returns the number of moved items
+func MoveMedia(ctx context.Context, ids []primitive.ObjectID, folderID *primitive.ObjectID, updatedBy string) (int64, error) {
+	set := bson.M{"updated_at": time.Now().Unix(), "updated_by": updatedBy}
+	changes := bson.M{"$set": set}
+	if folderID != nil {
+		if _, err := GetMediaFolder(ctx, *folderID); err != nil {
+			return 0, err
+		}
+		set["folder_id"] = *folderID
+	} else {
+		changes["$unset"] = bson.M{"folder_id": ""}
+	}
+
+	result, err := mediaCollection.UpdateMany(ctx, notTrashed(bson.M{"_id": bson.M{"$in": ids}}), changes)
+	if err != nil {
+		return 0, err
+	}
+	return result.ModifiedCount, nil
+}
+
+// MediaAltText returns the alt text of a media item along the fallback chain of lang; yoksa boş döner
+func MediaAltText(media models.Media, lang string) string {
+	for _, code := range LanguageChain(lang) {
+		if alt := media.Localizations[code].Alt; alt != "" {
+			return alt
+		}
+	}
+	return ""
 }
 
 func GetMediaByID(id primitive.ObjectID) (*models.Media, error) {
@@ -58,31 +305,109 @@ func GetMediaByID(id primitive.ObjectID) (*models.Media, error) {
 	defer cancel()
 
 	var media models.Media
-	err := mediaCollection.FindOne(ctx, bson.M{"_id": id}).Decode(&media)
+	err := mediaCollection.FindOne(ctx, notTrashed(bson.M{"_id": id})).Decode(&media)
 	if err != nil {
 		return nil, err
 	}
 	return &media, nil
 }
 
-func GetFilteredMedia(filter bson.M) ([]models.Media, error) {
-	var media []models.Media
+func GetFilteredMedia(filter bson.M, opts ListOptions) ([]models.Media, *PageInfo, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	cursor, err := mediaCollection.Find(ctx, filter)
+	return FindPage[models.Media](ctx, mediaCollection, notTrashed(filter), opts)
+}
+
+// SignedMediaURL returns a temporary address of a media file that works without a session
+func SignedMediaURL(ctx context.Context, id primitive.ObjectID, expires time.Duration) (string, error) {
+	media, err := GetMediaByID(id)
+	if err != nil {
+		return "", err
+	}
+	storage, key, err := mediaLocation(media)
+	if err != nil {
+		return "", err
+	}
+	return storage.SignedURL(ctx, key, expires)
+}
+
+// MediaURL returns the public address of a media file
+func MediaURL(media models.Media) string {
+	if media.URL != "" {
+		return media.URL
+	}
+	return "/" + filepath.ToSlash(media.FilePath) // Sürücüsü kaydedilmemiş eski kayıtlar
+}
+
+// mediaURLFilter matches a media item by its public address or, for local files, its file path
+func mediaURLFilter(address string) bson.M {
+	filePath := filepath.FromSlash(strings.TrimPrefix(address, "/"))
+	return bson.M{"$or": bson.A{bson.M{"url": address}, bson.M{"file_path": filePath}}}
+}
+
+// mediaLocation returns the backend and key of a media file; eski kayıtlarda anahtar file_path'ten çıkarılır
+func mediaLocation(media *models.Media) (utils.Storage, string, error) {
+	storage, err := GetStorage(media.Storage)
+	if err != nil {
+		return nil, "", err
+	}
+	if media.StorageKey != "" {
+		return storage, media.StorageKey, nil
+	}
+	key, err := filepath.Rel(LocalStorageRoot, media.FilePath)
+	if err != nil || key == "." || strings.HasPrefix(key, "..") {
+		return nil, "", utils.ErrInvalidStorageKey
+	}
+	return storage, filepath.ToSlash(key), nil
+}
+
+// setMediaLocation fills the address fields of a media item stored under its key in a backend
+func setMediaLocation(media *models.Media, storage utils.Storage) {
+	media.Storage = storage.Name()
+	media.URL = storage.URL(media.StorageKey)
+	media.FilePath = ""
+	if local, ok := storage.(*utils.LocalStorage); ok {
+		media.FilePath, _ = local.Path(media.StorageKey)
+	}
+}
+
+// openMediaFile opens the original file of a media item
+func openMediaFile(ctx context.Context, media *models.Media) (io.ReadCloser, error) {
+	storage, key, err := mediaLocation(media)
 	if err != nil {
 		return nil, err
 	}
-	defer cursor.Close(ctx)
+	reader, _, err := storage.Get(ctx, key)
+	return reader, err
+}
 
-	for cursor.Next(ctx) {
-		var m models.Media
-		if err := cursor.Decode(&m); err != nil {
-			return nil, err
-		}
-		media = append(media, m)
+// deleteMediaFiles removes the original, the renditions and the cached resizes of a media item
+func deleteMediaFiles(ctx context.Context, media *models.Media) error {
+	storage, key, err := mediaLocation(media)
+	if err != nil {
+		return err
 	}
 
-	return media, nil
+	// Aynı dosyaya bağlı başka kayıt varsa (tekrar yükleme, link) dosyalar korunur
+	shared := bson.M{"_id": bson.M{"$ne": media.ID}, "storage": media.Storage, "storage_key": media.StorageKey}
+	if media.StorageKey == "" {
+		shared = bson.M{"_id": bson.M{"$ne": media.ID}, "file_path": media.FilePath}
+	}
+	if count, err := mediaCollection.CountDocuments(ctx, shared); err != nil {
+		return err
+	} else if count > 0 {
+		return removeMediaCache(media.ID)
+	}
+
+	keys := []string{key}
+	for _, rendition := range media.Renditions {
+		keys = append(keys, rendition.StorageKey)
+	}
+	for _, key := range keys {
+		if err := storage.Delete(ctx, key); err != nil {
+			return err
+		}
+	}
+	return removeMediaCache(media.ID)
 }
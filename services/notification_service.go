@@ -40,23 +40,8 @@ func CreateNotification(ctx context.Context, userID primitive.ObjectID, message
 }
 
 // FetchNotificationsByUserID retrieves notifications for a specific user
-func FetchNotificationsByUserID(ctx context.Context, userID primitive.ObjectID) ([]models.Notification, error) {
-	filter := bson.M{"user_id": userID}
-	cursor, err := notificationCollection.Find(ctx, filter)
-	if err != nil {
-		return nil, err
-	}
-	defer cursor.Close(ctx)
-
-	var notifications []models.Notification
-	for cursor.Next(ctx) {
-		var notification models.Notification
-		if err := cursor.Decode(&notification); err != nil {
-			return nil, err
-		}
-		notifications = append(notifications, notification)
-	}
-	return notifications, nil
+func FetchNotificationsByUserID(ctx context.Context, userID primitive.ObjectID, opts ListOptions) ([]models.Notification, *PageInfo, error) {
+	return FindPage[models.Notification](ctx, notificationCollection, bson.M{"user_id": userID}, opts)
 }
 
 // UpdateNotificationAsRead updates a notification to mark it as read
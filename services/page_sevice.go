@@ -16,46 +16,53 @@ func InitPageService(client *mongo.Client) {
 	pageCollection = client.Database("admin_panel").Collection("pages")
 }
 
-// CreatePage inserts a new page into the database
-func CreatePage(page models.Page) (*mongo.InsertOneResult, error) {
+// CreatePage inserts a new page into the database and reports the markup removed from its content
+func CreatePage(page models.Page) (*mongo.InsertOneResult, []models.SanitizeReport, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
 	page.ID = primitive.NewObjectID()
 	page.CreatedAt = primitive.NewDateTimeFromTime(time.Now())
 	page.UpdatedAt = page.CreatedAt
+	page.DeletedAt, page.DeletedBy = nil, ""
 
-	return pageCollection.InsertOne(ctx, page)
+	sanitizer := newContentSanitizer()
+	if err := renderLocalizations(sanitizer, "localizations", page.Localizations); err != nil {
+		return nil, nil, err
+	}
+
+	// Slug'lar dil bazında yazı ve sayfalar arasında tekil olmalı
+	if err := EnsureUniqueSlugs(ctx, page.Localizations, page.ID); err != nil {
+		return nil, nil, err
+	}
+	if err := prepareBlocks(ctx, page.Localizations); err != nil {
+		return nil, nil, err
+	}
+	summarizeLocalizations(page.Localizations)
+
+	result, err := pageCollection.InsertOne(ctx, page)
+	if err == nil {
+		refreshTranslationMeta(ctx, "pages", page.ID)
+		reindexSearch(ctx, "pages", page.ID)
+		refreshSitemap(ctx, "pages", page.ID)
+		refreshMediaReferences(ctx, models.MediaSourcePages, page.ID)
+	}
+	return result, sanitizer.report, err
 }
 
 // GetAllPages retrieves all pages from the database
-func GetAllPages() ([]models.Page, error) {
-	var pages []models.Page
+func GetAllPages(opts ListOptions) ([]models.Page, *PageInfo, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	cursor, err := pageCollection.Find(ctx, bson.M{})
-	if err != nil {
-		return nil, err
-	}
-	defer cursor.Close(ctx)
-
-	for cursor.Next(ctx) {
-		var page models.Page
-		if err := cursor.Decode(&page); err != nil {
-			return nil, err
-		}
-		pages = append(pages, page)
-	}
-
-	return pages, nil
+	return FindPage[models.Page](ctx, pageCollection, notTrashed(nil), opts)
 }
 
 func GetPageByID(ctx context.Context, pageID primitive.ObjectID) (*models.Page, error) {
 
 	// Sayfayı ID'ye göre arayın
 	var page models.Page
-	err := pageCollection.FindOne(ctx, bson.M{"_id": pageID}).Decode(&page)
+	err := pageCollection.FindOne(ctx, notTrashed(bson.M{"_id": pageID})).Decode(&page)
 	if err != nil {
 		return nil, err
 	}
@@ -63,20 +70,58 @@ func GetPageByID(ctx context.Context, pageID primitive.ObjectID) (*models.Page,
 	return &page, nil
 }
 
-// UpdatePage updates an existing page
-func UpdatePage(id primitive.ObjectID, update bson.M) (*mongo.UpdateResult, error) {
+// UpdatePage updates an existing page and reports the markup removed from its content
+func UpdatePage(id primitive.ObjectID, update bson.M) (*mongo.UpdateResult, []models.SanitizeReport, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
+	// Çöp kutusu alanları yalnızca silme/geri yükleme ile değişir
+	delete(update, "deleted_at")
+	delete(update, "deleted_by")
+
+	// Yerelleştirmeler güncelleniyorsa içerik temizlenir ve slug'lar tekilleştirilir
+	sanitizer := newContentSanitizer()
+	if err := mergeDottedLocalizations(ctx, pageCollection, notTrashed(bson.M{"_id": id}), "localizations", update); err != nil {
+		return nil, nil, err
+	}
+	var before, after map[string]string
+	if raw, ok := update["localizations"]; ok {
+		localizations, err := decodeLocalizations(raw)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := renderLocalizations(sanitizer, "localizations", localizations); err != nil {
+			return nil, nil, err
+		}
+		before = currentSlugs(ctx, pageCollection, id)
+		if err := EnsureUniqueSlugs(ctx, localizations, id); err != nil {
+			return nil, nil, err
+		}
+		if err := prepareBlocks(ctx, localizations); err != nil {
+			return nil, nil, err
+		}
+		summarizeLocalizations(localizations)
+		update["localizations"] = localizations
+		after = slugsOf(localizations)
+	}
+
 	// Güncellenen alanlara `updated_at` ekleme
 	update["updated_at"] = primitive.NewDateTimeFromTime(time.Now())
-	return pageCollection.UpdateByID(ctx, id, bson.M{"$set": update})
+	result, err := pageCollection.UpdateByID(ctx, id, bson.M{"$set": update})
+	if err == nil {
+		recordSlugRedirects(ctx, "pages", id, before, after)
+		refreshTranslationMeta(ctx, "pages", id)
+		reindexSearch(ctx, "pages", id)
+		refreshSitemap(ctx, "pages", id)
+		refreshMediaReferences(ctx, models.MediaSourcePages, id)
+	}
+	return result, sanitizer.report, err
 }
 
-// DeletePage deletes a page from the database
-func DeletePage(id primitive.ObjectID) (*mongo.DeleteResult, error) {
+// DeletePage moves a page to the trash
+func DeletePage(id primitive.ObjectID, deletedBy string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	return pageCollection.DeleteOne(ctx, bson.M{"_id": id})
+	return moveToTrash(ctx, "pages", id, deletedBy)
 }
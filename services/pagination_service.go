@@ -0,0 +1,198 @@
+package services
+
+import (
+	"admin-panel/utils"
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	DefaultListLimit = 20
+	MaxListLimit     = 100
+)
+
+var ErrUnknownField = errors.New("unknown field")
+
+// ListOptions represents cursor pagination, sorting and field projection of a list request.
+// Limit 0 tüm kayıtları döndürür (yalnızca dahili kullanım için).
+type ListOptions struct {
+	Limit     int
+	Cursor    string
+	Sort      string   // JSON alan adı, azalan sıralama için "-" öneki (örn: "-created_at")
+	Fields    []string // JSON alan adları; boşsa tüm alanlar
+	WithTotal bool
+	Hidden    []string // Sıralama ve projeksiyonda kullanılamayan JSON alanları (örn: parola); imleçte değerleri taşınmaz
+}
+
+// PageInfo describes the position of a returned page
+type PageInfo struct {
+	NextCursor string
+	Total      *int64
+}
+
+// FindPage runs a keyset-paginated query and decodes the results into T.
+// Sıralama alanı ve projeksiyon JSON adlarıyla verilir, T'nin bson etiketlerine çevrilir.
+func FindPage[T any](ctx context.Context, collection *mongo.Collection, filter bson.M, opts ListOptions) ([]T, *PageInfo, error) {
+	if filter == nil {
+		filter = bson.M{}
+	}
+
+	names := fieldNames[T]()
+	for _, hidden := range opts.Hidden {
+		delete(names, hidden)
+	}
+
+	// Sıralama: tek alan + _id (eşitlikleri ayırmak için)
+	sortField, direction := "_id", 1
+	if opts.Sort != "" {
+		key := strings.TrimPrefix(opts.Sort, "-")
+		if strings.HasPrefix(opts.Sort, "-") {
+			direction = -1
+		}
+		bsonName, ok := names[key]
+		if !ok {
+			return nil, nil, fmt.Errorf("%w: %s", ErrUnknownField, key)
+		}
+		sortField = bsonName
+	}
+	sortKey := opts.Sort
+	if sortKey == "" {
+		sortKey = "id"
+	}
+
+	query := filter
+	if opts.Cursor != "" {
+		cursor, err := utils.DecodeCursor(opts.Cursor)
+		if err != nil || cursor.Sort != sortKey {
+			return nil, nil, utils.ErrInvalidCursor
+		}
+		query = bson.M{"$and": bson.A{filter, keysetFilter(sortField, direction, cursor)}}
+	}
+
+	findOpts := options.Find()
+	if sortField == "_id" {
+		findOpts.SetSort(bson.D{{Key: "_id", Value: direction}})
+	} else {
+		findOpts.SetSort(bson.D{{Key: sortField, Value: direction}, {Key: "_id", Value: direction}})
+	}
+	if opts.Limit > 0 {
+		findOpts.SetLimit(int64(opts.Limit + 1)) // Sonraki sayfa var mı?
+	}
+	if len(opts.Fields) > 0 {
+		projection := bson.M{"_id": 1, sortField: 1}
+		for _, field := range opts.Fields {
+			bsonName, ok := names[field]
+			if !ok {
+				return nil, nil, fmt.Errorf("%w: %s", ErrUnknownField, field)
+			}
+			projection[bsonName] = 1
+		}
+		findOpts.SetProjection(projection)
+	}
+
+	cursor, err := collection.Find(ctx, query, findOpts)
+	if err != nil {
+		return nil, nil, err
+	}
+	var raws []bson.Raw
+	if err := cursor.All(ctx, &raws); err != nil {
+		return nil, nil, err
+	}
+
+	info := &PageInfo{}
+	if opts.Limit > 0 && len(raws) > opts.Limit {
+		raws = raws[:opts.Limit]
+		last := raws[len(raws)-1]
+
+		next := utils.Cursor{Sort: sortKey, ID: last.Lookup("_id")}
+		if value, err := last.LookupErr(sortField); err == nil && sortField != "_id" {
+			next.Value = value
+		}
+		if info.NextCursor, err = utils.EncodeCursor(next); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	items := make([]T, 0, len(raws))
+	for _, raw := range raws {
+		var item T
+		if err := bson.Unmarshal(raw, &item); err != nil {
+			return nil, nil, err
+		}
+		items = append(items, item)
+	}
+
+	if opts.WithTotal {
+		total, err := collection.CountDocuments(ctx, filter)
+		if err != nil {
+			return nil, nil, err
+		}
+		info.Total = &total
+	}
+
+	return items, info, nil
+}
+
+// keysetFilter selects the documents after the cursor position.
+// MongoDB boş (null/eksik) değerleri en küçük sayar ama $gt/$lt farklı tipleri karşılaştırmaz;
+// artan sıralamada boşlar başta, azalan sıralamada sonda olduğundan ayrıca seçilir.
+func keysetFilter(sortField string, direction int, cursor *utils.Cursor) bson.M {
+	op := "$gt"
+	if direction < 0 {
+		op = "$lt"
+	}
+	if sortField == "_id" {
+		return bson.M{"_id": bson.M{op: cursor.ID}}
+	}
+	if cursor.Value == nil {
+		sameValue := bson.M{sortField: nil, "_id": bson.M{op: cursor.ID}}
+		if direction < 0 {
+			return sameValue
+		}
+		return bson.M{"$or": bson.A{bson.M{sortField: bson.M{"$ne": nil}}, sameValue}}
+	}
+	after := bson.A{
+		bson.M{sortField: bson.M{op: cursor.Value}},
+		bson.M{sortField: cursor.Value, "_id": bson.M{op: cursor.ID}},
+	}
+	if direction < 0 {
+		after = append(after, bson.M{sortField: nil})
+	}
+	return bson.M{"$or": after}
+}
+
+// fieldNames maps the JSON field names of T to their bson names
+func fieldNames[T any]() map[string]string {
+	names := map[string]string{}
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return names
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		jsonName := strings.Split(field.Tag.Get("json"), ",")[0]
+		bsonName := strings.Split(field.Tag.Get("bson"), ",")[0]
+		if jsonName == "-" || bsonName == "-" {
+			continue
+		}
+		if jsonName == "" {
+			jsonName = field.Name
+		}
+		if bsonName == "" {
+			bsonName = strings.ToLower(field.Name) // bson sürücüsünün varsayılanı
+		}
+		names[jsonName] = bsonName
+	}
+	return names
+}
@@ -0,0 +1,140 @@
+package services
+
+import (
+	"admin-panel/utils"
+	"reflect"
+	"sort"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// TestKeysetFilterPagesAcrossNulls pages through documents whose sort field is partly empty,
+// filtreyi MongoDB'nin karşılaştırma kurallarıyla (boş değerler en küçük, $gt/$lt tipler arası eşleşmez) değerlendirerek
+func TestKeysetFilterPagesAcrossNulls(t *testing.T) {
+	docs := []bson.M{
+		{"_id": 1, "publish_date": 30},
+		{"_id": 2},
+		{"_id": 3, "publish_date": 10},
+		{"_id": 4, "publish_date": nil},
+		{"_id": 5, "publish_date": 30},
+		{"_id": 6},
+		{"_id": 7, "publish_date": 20},
+	}
+
+	for _, direction := range []int{1, -1} {
+		expected := append([]bson.M{}, docs...)
+		sort.SliceStable(expected, func(i, j int) bool {
+			if c := compareSortValues(expected[i]["publish_date"], expected[j]["publish_date"]); c != 0 {
+				return c*direction < 0
+			}
+			return compareSortValues(expected[i]["_id"], expected[j]["_id"])*direction < 0
+		})
+
+		var got []interface{}
+		var cursor *utils.Cursor
+		for page := 0; page < len(docs); page++ {
+			var rows []bson.M
+			for _, doc := range expected {
+				if cursor == nil || matchesFilter(doc, keysetFilter("publish_date", direction, cursor)) {
+					rows = append(rows, doc)
+				}
+			}
+			if len(rows) == 0 {
+				break
+			}
+			rows = rows[:min(2, len(rows))]
+			for _, row := range rows {
+				got = append(got, row["_id"])
+			}
+			last := rows[len(rows)-1]
+			token, err := utils.EncodeCursor(utils.Cursor{Sort: "publish_date", Value: last["publish_date"], ID: last["_id"]})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if cursor, err = utils.DecodeCursor(token); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		var want []interface{}
+		for _, doc := range expected {
+			want = append(want, doc["_id"])
+		}
+		if len(got) != len(want) {
+			t.Fatalf("direction %d: expected %v, got %v", direction, want, got)
+		}
+		for i := range want {
+			if compareSortValues(got[i], want[i]) != 0 {
+				t.Fatalf("direction %d: expected %v, got %v", direction, want, got)
+			}
+		}
+	}
+}
+
+// matchesFilter evaluates the $or, $gt, $lt, $ne and equality conditions produced by keysetFilter
+func matchesFilter(doc bson.M, filter bson.M) bool {
+	for key, condition := range filter {
+		if key == "$or" {
+			matched := false
+			for _, branch := range condition.(bson.A) {
+				if matchesFilter(doc, branch.(bson.M)) {
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				return false
+			}
+			continue
+		}
+		value := doc[key]
+		operators, ok := condition.(bson.M)
+		if !ok {
+			if compareSortValues(value, condition) != 0 || (value == nil) != (condition == nil) {
+				return false
+			}
+			continue
+		}
+		for op, operand := range operators {
+			comparable := value != nil && operand != nil
+			switch op {
+			case "$gt":
+				if !comparable || compareSortValues(value, operand) <= 0 {
+					return false
+				}
+			case "$lt":
+				if !comparable || compareSortValues(value, operand) >= 0 {
+					return false
+				}
+			case "$ne":
+				if (value == nil) == (operand == nil) && compareSortValues(value, operand) == 0 {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+// compareSortValues orders empty values first and numbers by value (imleçten dönen int32/int64 dahil)
+func compareSortValues(a, b interface{}) int {
+	if a == nil || b == nil {
+		switch {
+		case a == nil && b == nil:
+			return 0
+		case a == nil:
+			return -1
+		default:
+			return 1
+		}
+	}
+	x, y := reflect.ValueOf(a).Int(), reflect.ValueOf(b).Int()
+	switch {
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	}
+	return 0
+}
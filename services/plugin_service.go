@@ -33,24 +33,8 @@ func CreatePlugin(plugin *models.Plugin) error {
 }
 
 // GetAllPlugins retrieves all plugins
-func GetAllPlugins() ([]models.Plugin, error) {
-	var plugins []models.Plugin
-
-	cursor, err := pluginCollection.Find(context.Background(), bson.M{})
-	if err != nil {
-		return nil, err
-	}
-	defer cursor.Close(context.Background())
-
-	for cursor.Next(context.Background()) {
-		var plugin models.Plugin
-		if err := cursor.Decode(&plugin); err != nil {
-			return nil, err
-		}
-		plugins = append(plugins, plugin)
-	}
-
-	return plugins, nil
+func GetAllPlugins(opts ListOptions) ([]models.Plugin, *PageInfo, error) {
+	return FindPage[models.Plugin](context.Background(), pluginCollection, bson.M{}, opts)
 }
 
 // UpdatePlugin updates an existing plugin
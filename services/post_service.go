@@ -16,71 +16,83 @@ func InitPostService(client *mongo.Client) {
 	postCollection = client.Database("admin_panel").Collection("posts")
 }
 
-// CreatePost creates a new post
-func CreatePost(ctx context.Context, post *models.Post) error {
+// CreatePost creates a new post and reports the markup removed from its content
+func CreatePost(ctx context.Context, post *models.Post) ([]models.SanitizeReport, error) {
 	post.CreatedAt = time.Now()
 	post.UpdatedAt = time.Now()
 
-	_, err := postCollection.InsertOne(ctx, post)
-	return err
-}
+	sanitizer := newContentSanitizer()
+	if err := renderLocalizations(sanitizer, "localizations", post.Localizations); err != nil {
+		return nil, err
+	}
 
-// GetAllPosts retrieves all posts
-func GetAllPosts(ctx context.Context) ([]models.Post, error) {
-	var posts []models.Post
-	cursor, err := postCollection.Find(ctx, bson.M{})
-	if err != nil {
+	// Slug'lar dil bazında yazı ve sayfalar arasında tekil olmalı
+	if err := EnsureUniqueSlugs(ctx, post.Localizations, post.ID); err != nil {
+		return nil, err
+	}
+	if err := prepareBlocks(ctx, post.Localizations); err != nil {
 		return nil, err
 	}
-	defer cursor.Close(ctx)
-
-	for cursor.Next(ctx) {
-		var post models.Post
-		if err := cursor.Decode(&post); err != nil {
-			return nil, err
-		}
-		posts = append(posts, post)
+	summarizeLocalizations(post.Localizations)
+
+	_, err := postCollection.InsertOne(ctx, post)
+	if err == nil {
+		refreshTranslationMeta(ctx, "posts", post.ID)
+		reindexSearch(ctx, "posts", post.ID)
+		refreshSitemap(ctx, "posts", post.ID)
+		refreshMediaReferences(ctx, models.MediaSourcePosts, post.ID)
 	}
+	return sanitizer.report, err
+}
 
-	return posts, nil
+// GetAllPosts retrieves all posts
+func GetAllPosts(ctx context.Context, opts ListOptions) ([]models.Post, *PageInfo, error) {
+	return FindPage[models.Post](ctx, postCollection, notTrashed(nil), opts)
 }
 
 // GetPostByID retrieves a single post by its ID
 func GetPostByID(ctx context.Context, id primitive.ObjectID) (*models.Post, error) {
 	var post models.Post
-	err := postCollection.FindOne(ctx, bson.M{"_id": id}).Decode(&post)
+	err := postCollection.FindOne(ctx, notTrashed(bson.M{"_id": id})).Decode(&post)
 	return &post, err
 }
 
 // GetFilteredPosts retrieves posts based on filters
-func GetFilteredPosts(ctx context.Context, filter bson.M) ([]models.Post, error) {
-	var posts []models.Post
-	cursor, err := postCollection.Find(ctx, filter)
-	if err != nil {
+func GetFilteredPosts(ctx context.Context, filter bson.M, opts ListOptions) ([]models.Post, *PageInfo, error) {
+	return FindPage[models.Post](ctx, postCollection, notTrashed(filter), opts)
+}
+
+// UpdatePost updates an existing post and reports the markup removed from its content
+func UpdatePost(ctx context.Context, post *models.Post) ([]models.SanitizeReport, error) {
+	post.UpdatedAt = time.Now()
+
+	sanitizer := newContentSanitizer()
+	if err := renderLocalizations(sanitizer, "localizations", post.Localizations); err != nil {
 		return nil, err
 	}
-	defer cursor.Close(ctx)
-
-	for cursor.Next(ctx) {
-		var post models.Post
-		if err := cursor.Decode(&post); err != nil {
-			return nil, err
-		}
-		posts = append(posts, post)
-	}
 
-	return posts, nil
-}
+	before := currentSlugs(ctx, postCollection, post.ID)
+	if err := EnsureUniqueSlugs(ctx, post.Localizations, post.ID); err != nil {
+		return nil, err
+	}
+	if err := prepareBlocks(ctx, post.Localizations); err != nil {
+		return nil, err
+	}
+	summarizeLocalizations(post.Localizations)
 
-// UpdatePost updates an existing post
-func UpdatePost(ctx context.Context, post *models.Post) error {
-	post.UpdatedAt = time.Now()
 	_, err := postCollection.UpdateOne(
 		ctx,
 		bson.M{"_id": post.ID},
 		bson.M{"$set": post},
 	)
-	return err
+	if err == nil {
+		recordSlugRedirects(ctx, "posts", post.ID, before, slugsOf(post.Localizations))
+		refreshTranslationMeta(ctx, "posts", post.ID)
+		reindexSearch(ctx, "posts", post.ID)
+		refreshSitemap(ctx, "posts", post.ID)
+		refreshMediaReferences(ctx, models.MediaSourcePosts, post.ID)
+	}
+	return sanitizer.report, err
 }
 
 // GetPostByLangAndSlug retrieves a single post based on language and slug
@@ -93,7 +105,7 @@ func GetPostByLangAndSlug(ctx context.Context, lang string, slug string) (*model
 	}
 
 	// Veritabanında eşleşen dokümanı bul
-	err := postCollection.FindOne(ctx, filter).Decode(&post)
+	err := postCollection.FindOne(ctx, notTrashed(filter)).Decode(&post)
 	if err != nil {
 		return nil, err // Eğer doküman bulunamazsa hata döndür
 	}
@@ -101,18 +113,7 @@ func GetPostByLangAndSlug(ctx context.Context, lang string, slug string) (*model
 	return &post, nil
 }
 
-// DeletePost deletes a post by its ID from the database
-func DeletePost(ctx context.Context, postID primitive.ObjectID) error {
-	filter := bson.M{"_id": postID}
-
-	result, err := postCollection.DeleteOne(ctx, filter)
-	if err != nil {
-		return err
-	}
-
-	if result.DeletedCount == 0 {
-		return mongo.ErrNoDocuments
-	}
-
-	return nil
+// DeletePost moves a post to the trash
+func DeletePost(ctx context.Context, postID primitive.ObjectID, deletedBy string) error {
+	return moveToTrash(ctx, "posts", postID, deletedBy)
 }
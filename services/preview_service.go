@@ -0,0 +1,250 @@
+package services
+
+import (
+	"admin-panel/configs"
+	"admin-panel/models"
+	"context"
+	"errors"
+	"os"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	previewAudience = "content-preview"
+	MaxPreviewTTL   = 30 * 24 * time.Hour
+)
+
+var (
+	ErrInvalidPreviewToken = errors.New("invalid or expired preview token")
+	ErrPreviewLanguage     = errors.New("document has no content in the requested language")
+	ErrUnsupportedPreview  = errors.New("previews are supported for posts and pages")
+)
+
+var previewCollection *mongo.Collection
+
+// Önizleme bağlantılarının varsayılan geçerlilik süresi (PREVIEW_TOKEN_TTL, örn: "48h"), varsayılan 24 saat
+var previewTTL = func() time.Duration {
+	if v := os.Getenv("PREVIEW_TOKEN_TTL"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d > 0 && d <= MaxPreviewTTL {
+			return d
+		}
+	}
+	return 24 * time.Hour
+}()
+
+// previewClaims are the claims of a preview token; erişim token'larından "aud" ile ayrılır
+type previewClaims struct {
+	EntityType string `json:"typ"`
+	EntityID   string `json:"eid"`
+	Lang       string `json:"lang"`
+	RevisionID string `json:"rev,omitempty"`
+	jwt.RegisteredClaims
+}
+
+func InitPreviewService(client *mongo.Client) {
+	previewCollection = client.Database("admin_panel").Collection("preview_tokens")
+
+	// Süresi dolan kayıtlar bir gün sonra MongoDB tarafından silinir
+	_, _ = previewCollection.Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+		{Keys: bson.D{{Key: "expires_at", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(24 * 60 * 60)},
+		{Keys: bson.D{{Key: "entity_type", Value: 1}, {Key: "entity_id", Value: 1}}},
+	})
+}
+
+// CreatePreviewToken stores a preview link for a post or page (optionally a revision of it) and returns the signed token
+func CreatePreviewToken(ctx context.Context, request models.PreviewRequest, createdBy string) (string, *models.PreviewToken, error) {
+	ttl := previewTTL
+	if request.ExpiresIn > 0 {
+		ttl = time.Duration(request.ExpiresIn) * time.Second
+	}
+	if ttl > MaxPreviewTTL {
+		ttl = MaxPreviewTTL
+	}
+
+	preview := &models.PreviewToken{
+		ID:         primitive.NewObjectID(),
+		EntityType: request.Type,
+		EntityID:   request.ID,
+		Lang:       request.Lang,
+		RevisionID: request.RevisionID,
+		CreatedBy:  createdBy,
+		CreatedAt:  time.Now(),
+	}
+	preview.ExpiresAt = preview.CreatedAt.Add(ttl)
+
+	// Doküman (veya revizyon) ve dil mevcut olmalı
+	localizations, err := previewLocalizations(ctx, preview)
+	if err != nil {
+		return "", nil, err
+	}
+	if _, ok := localizations[preview.Lang]; !ok {
+		return "", nil, ErrPreviewLanguage
+	}
+
+	claims := previewClaims{
+		EntityType: preview.EntityType,
+		EntityID:   preview.EntityID.Hex(),
+		Lang:       preview.Lang,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        preview.ID.Hex(),
+			Audience:  jwt.ClaimStrings{previewAudience},
+			Issuer:    "aystek",
+			IssuedAt:  jwt.NewNumericDate(preview.CreatedAt),
+			ExpiresAt: jwt.NewNumericDate(preview.ExpiresAt),
+		},
+	}
+	if preview.RevisionID != nil {
+		claims.RevisionID = preview.RevisionID.Hex()
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(configs.GetJWTSecret()))
+	if err != nil {
+		return "", nil, err
+	}
+
+	if _, err := previewCollection.InsertOne(ctx, preview); err != nil {
+		return "", nil, err
+	}
+	return token, preview, nil
+}
+
+// ResolvePreviewToken verifies the signature, expiry and revocation of a preview token
+func ResolvePreviewToken(ctx context.Context, token string) (*models.PreviewToken, error) {
+	claims := &previewClaims{}
+	parsed, err := jwt.ParseWithClaims(token, claims, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, jwt.ErrTokenUnverifiable
+		}
+		return []byte(configs.GetJWTSecret()), nil
+	}, jwt.WithAudience(previewAudience), jwt.WithExpirationRequired())
+	if err != nil || !parsed.Valid {
+		return nil, ErrInvalidPreviewToken
+	}
+
+	id, err := primitive.ObjectIDFromHex(claims.ID)
+	if err != nil {
+		return nil, ErrInvalidPreviewToken
+	}
+
+	var preview models.PreviewToken
+	if err := previewCollection.FindOne(ctx, bson.M{"_id": id}).Decode(&preview); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, ErrInvalidPreviewToken
+		}
+		return nil, err
+	}
+
+	// Token kapsamı kayıtla birebir eşleşmeli
+	revisionID := ""
+	if preview.RevisionID != nil {
+		revisionID = preview.RevisionID.Hex()
+	}
+	if preview.RevokedAt != nil || time.Now().After(preview.ExpiresAt) ||
+		preview.EntityType != claims.EntityType || preview.EntityID.Hex() != claims.EntityID ||
+		preview.Lang != claims.Lang || revisionID != claims.RevisionID {
+		return nil, ErrInvalidPreviewToken
+	}
+	return &preview, nil
+}
+
+// GetPreviewContent renders the draft content a preview token points to
+func GetPreviewContent(ctx context.Context, preview *models.PreviewToken) (models.PublicContent, string, error) {
+	switch preview.EntityType {
+	case "posts":
+		post, err := previewDocument[models.Post](ctx, preview)
+		if err != nil {
+			return models.PublicContent{}, "", err
+		}
+		return PublicPost(post, preview.Lang), post.Status, nil
+	case "pages":
+		page, err := previewDocument[models.Page](ctx, preview)
+		if err != nil {
+			return models.PublicContent{}, "", err
+		}
+		return PublicPage(page, preview.Lang), page.Status, nil
+	}
+	return models.PublicContent{}, "", ErrUnsupportedPreview
+}
+
+// GetPreviewTokens lists the preview links of a document, newest first
+func GetPreviewTokens(ctx context.Context, entityType string, entityID primitive.ObjectID) ([]models.PreviewToken, error) {
+	opts := options.Find().SetSort(bson.M{"created_at": -1})
+	cursor, err := previewCollection.Find(ctx, bson.M{"entity_type": entityType, "entity_id": entityID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	tokens := []models.PreviewToken{}
+	if err := cursor.All(ctx, &tokens); err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+// RevokePreviewToken invalidates a preview link before it expires
+func RevokePreviewToken(ctx context.Context, id primitive.ObjectID, revokedBy string) error {
+	result, err := previewCollection.UpdateOne(ctx,
+		bson.M{"_id": id, "revoked_at": nil},
+		bson.M{"$set": bson.M{"revoked_at": time.Now(), "revoked_by": revokedBy}},
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// previewDocument loads the current document or decodes the revision snapshot of a preview
+func previewDocument[T any](ctx context.Context, preview *models.PreviewToken) (*T, error) {
+	var doc T
+	if preview.RevisionID != nil {
+		revision, err := GetRevisionByID(ctx, preview.EntityType, preview.EntityID, *preview.RevisionID)
+		if err != nil {
+			return nil, err
+		}
+		data, err := bson.Marshal(revision.Snapshot)
+		if err != nil {
+			return nil, err
+		}
+		if err := bson.Unmarshal(data, &doc); err != nil {
+			return nil, err
+		}
+		return &doc, nil
+	}
+
+	collection, err := trashCollection(preview.EntityType)
+	if err != nil {
+		return nil, err
+	}
+	if err := collection.FindOne(ctx, notTrashed(bson.M{"_id": preview.EntityID})).Decode(&doc); err != nil {
+		return nil, err
+	}
+	return &doc, nil
+}
+
+// previewLocalizations returns the localizations of the previewed document
+func previewLocalizations(ctx context.Context, preview *models.PreviewToken) (map[string]models.LocalizedField, error) {
+	switch preview.EntityType {
+	case "posts":
+		post, err := previewDocument[models.Post](ctx, preview)
+		if err != nil {
+			return nil, err
+		}
+		return post.Localizations, nil
+	case "pages":
+		page, err := previewDocument[models.Page](ctx, preview)
+		if err != nil {
+			return nil, err
+		}
+		return page.Localizations, nil
+	}
+	return nil, ErrUnsupportedPreview
+}
@@ -0,0 +1,250 @@
+package services
+
+import (
+	"admin-panel/models"
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// PublishedFilter matches content that anonymous visitors may see:
+// durumu "published", yayın tarihi gelmiş ve yayından kaldırma tarihi geçmemiş içerikler.
+func PublishedFilter(now time.Time) bson.M {
+	return bson.M{
+		"status":     "published",
+		"deleted_at": nil,
+		"$and": bson.A{
+			bson.M{"$or": bson.A{bson.M{"publish_date": nil}, bson.M{"publish_date": bson.M{"$lte": now}}}},
+			bson.M{"$or": bson.A{bson.M{"unpublish_date": nil}, bson.M{"unpublish_date": bson.M{"$gt": now}}}},
+		},
+	}
+}
+
+// GetPublishedPostByLangAndSlug retrieves a publicly visible post by slug.
+// Slug istenen dilde bulunamazsa dil zincirindeki (örn: de-AT -> de -> en) slug'larla aranır.
+func GetPublishedPostByLangAndSlug(ctx context.Context, lang, slug string) (*models.Post, error) {
+	return findPublishedBySlug[models.Post](ctx, postCollection, lang, slug)
+}
+
+// GetPublishedPageByLangAndSlug retrieves a publicly visible page by slug
+func GetPublishedPageByLangAndSlug(ctx context.Context, lang, slug string) (*models.Page, error) {
+	return findPublishedBySlug[models.Page](ctx, pageCollection, lang, slug)
+}
+
+func findPublishedBySlug[T any](ctx context.Context, collection *mongo.Collection, lang, slug string) (*T, error) {
+	for _, l := range LanguageChain(lang) {
+		filter := PublishedFilter(time.Now())
+		filter["localizations."+l+".slug"] = slug
+
+		var doc T
+		err := collection.FindOne(ctx, filter).Decode(&doc)
+		if err == mongo.ErrNoDocuments {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return &doc, nil
+	}
+	return nil, mongo.ErrNoDocuments
+}
+
+// GetPublishedPosts lists published posts that have a translation somewhere in the fallback chain of lang
+func GetPublishedPosts(ctx context.Context, lang string, filter bson.M, opts ListOptions) ([]models.PublicContent, *PageInfo, error) {
+	query := PublishedFilter(time.Now())
+	available := bson.A{}
+	for _, l := range LanguageChain(lang) {
+		available = append(available, bson.M{"localizations." + l: bson.M{"$exists": true}})
+	}
+	query["$or"] = available
+	for key, value := range filter {
+		query[key] = value
+	}
+
+	posts, info, err := FindPage[models.Post](ctx, postCollection, query, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	items := make([]models.PublicContent, 0, len(posts))
+	for i := range posts {
+		items = append(items, PublicPost(&posts[i], lang))
+	}
+	return items, info, nil
+}
+
+// PublicPost converts a post into its public representation in the given language
+func PublicPost(post *models.Post, lang string) models.PublicContent {
+	content := publicContent(post.Localizations, post.MetaTags, lang)
+	content.ID = post.ID
+	content.CategoryIDs = post.CategoryIDs
+	content.TagIDs = post.TagIDs
+	content.PublishDate = post.PublishDate
+	return content
+}
+
+// PublicPage converts a page into its public representation in the given language
+func PublicPage(page *models.Page, lang string) models.PublicContent {
+	content := publicContent(page.Localizations, page.MetaTags, lang)
+	content.ID = page.ID
+	if page.PublishDate != nil {
+		publishDate := page.PublishDate.Time()
+		content.PublishDate = &publishDate
+	}
+	return content
+}
+
+func publicContent(localizations map[string]models.LocalizedField, metaTags map[string]models.MetaTag, lang string) models.PublicContent {
+	field, resolved, _ := ResolveTranslation(localizations, lang)
+
+	available := map[string]string{}
+	for l, localization := range localizations {
+		available[l] = localization.Slug
+	}
+
+	excerpt, readingTime := ContentSummary(field)
+
+	return models.PublicContent{
+		Lang:           resolved,
+		RequestedLang:  lang,
+		Fallback:       resolved != lang,
+		Slug:           field.Slug,
+		Title:          field.Title,
+		Content:        field.Content,
+		Blocks:         field.Blocks,
+		Excerpt:        excerpt,
+		ReadingTime:    readingTime,
+		MetaTags:       metaTags[resolved],
+		AvailableLangs: available,
+	}
+}
+
+// GetPublicCategories lists categories resolved to the given language
+func GetPublicCategories(ctx context.Context, lang string, opts ListOptions) ([]models.PublicCategory, *PageInfo, error) {
+	categories, info, err := FindPage[models.Category](ctx, categoryCollection, notTrashed(nil), opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	items := make([]models.PublicCategory, 0, len(categories))
+	for i := range categories {
+		items = append(items, PublicCategory(&categories[i], lang))
+	}
+	return items, info, nil
+}
+
+// GetCategoryByLangAndSlug retrieves a category by slug, dil zincirindeki slug'larla da aranır
+func GetCategoryByLangAndSlug(ctx context.Context, lang, slug string) (*models.Category, error) {
+	for _, l := range LanguageChain(lang) {
+		filter := notTrashed(bson.M{"$or": bson.A{
+			bson.M{"localizations." + l + ".slug": slug},
+			bson.M{"slug." + l: slug},
+		}})
+
+		var category models.Category
+		err := categoryCollection.FindOne(ctx, filter).Decode(&category)
+		if err == mongo.ErrNoDocuments {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return &category, nil
+	}
+	return nil, mongo.ErrNoDocuments
+}
+
+// PublicCategory converts a category into its public representation in the given language
+func PublicCategory(category *models.Category, lang string) models.PublicCategory {
+	field, resolved, _ := ResolveTranslation(category.Localizations, lang)
+	return models.PublicCategory{
+		ID:          category.ID,
+		Lang:        resolved,
+		Slug:        categorySlug(category, resolved),
+		Title:       field.Title,
+		Description: field.Content,
+	}
+}
+
+// GetPublicMenus retrieves the visible frontend menus available to everyone, ordered by position
+func GetPublicMenus(ctx context.Context) ([]models.PublicMenuItem, error) {
+	filter := bson.M{"type": "frontend", "visible": true, "roles": "all"}
+	opts := options.Find().SetSort(bson.D{{Key: "order", Value: 1}, {Key: "_id", Value: 1}})
+
+	cursor, err := menusCollection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	var menus []models.Menu
+	if err := cursor.All(ctx, &menus); err != nil {
+		return nil, err
+	}
+
+	items := make([]models.PublicMenuItem, 0, len(menus))
+	for _, menu := range menus {
+		items = append(items, models.PublicMenuItem{
+			ID: menu.ID, Title: menu.Title, URL: menu.URL, ParentID: menu.ParentID, Order: menu.Order,
+		})
+	}
+	return items, nil
+}
+
+// GetActiveSliders retrieves the active sliders
+func GetActiveSliders(ctx context.Context) ([]models.PublicSlider, error) {
+	cursor, err := sliderCollection.Find(ctx, bson.M{"active": true})
+	if err != nil {
+		return nil, err
+	}
+	var sliders []models.Slider
+	if err := cursor.All(ctx, &sliders); err != nil {
+		return nil, err
+	}
+
+	items := make([]models.PublicSlider, 0, len(sliders))
+	for _, slider := range sliders {
+		items = append(items, models.PublicSlider{ID: slider.ID, Name: slider.Name, Images: slider.Images})
+	}
+	return items, nil
+}
+
+// GetPublicSettings returns the visitor-safe settings resolved to the given language
+func GetPublicSettings(lang string) (*models.PublicSettings, error) {
+	settings, err := GetSettings()
+	if err != nil {
+		return nil, err
+	}
+
+	if lang == "" {
+		lang = DefaultLanguage()
+	}
+
+	text := func(values map[string]string) string {
+		value, _, _ := ResolveTranslation(values, lang)
+		return value
+	}
+	metaTags, _, _ := ResolveTranslation(settings.MetaTags, lang)
+
+	socialMedia := map[string]models.SocialMedia{}
+	for key, link := range settings.SocialMedia {
+		if link.Active {
+			socialMedia[key] = link
+		}
+	}
+
+	return &models.PublicSettings{
+		Lang:           lang,
+		Title:          text(settings.Title),
+		Description:    text(settings.Description),
+		MetaTags:       metaTags,
+		SocialMedia:    socialMedia,
+		ContactInfo:    settings.ContactInfo,
+		SupportedLangs: settings.SupportedLangs,
+		DefaultLang:    DefaultLanguage(),
+		AnalyticsCode:  settings.AnalyticsCode,
+		LogoURL:        settings.LogoURL,
+		FaviconURL:     settings.FaviconURL,
+	}, nil
+}
@@ -0,0 +1,243 @@
+package services
+
+import (
+	"admin-panel/models"
+	"admin-panel/utils"
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var redirectRuleCollection *mongo.Collection
+
+var ErrRedirectRuleExists = errors.New("a redirect rule with the same source, match type and language already exists")
+
+// Kurallar değişince önbellek hemen yenilenir; diğer sunucular en geç bu sürede yeni kuralları görür
+const redirectRuleCacheTTL = time.Minute
+
+var redirectRuleCache struct {
+	sync.RWMutex
+	matcher    *utils.RedirectMatcher
+	loadedAt   time.Time  // Son yükleme veya başarısız deneme; TTL dolana kadar yeniden okunmaz
+	generation int        // Her geçersiz kılmada artar; yükleme sırasında değişen kurallar bir sonraki istekte okunur
+	reload     sync.Mutex // Eşzamanlı yenilemeleri tek sorguda birleştirir
+}
+
+// CreateRedirectRule validates and stores a redirect rule
+func CreateRedirectRule(ctx context.Context, rule *models.RedirectRule) error {
+	if errs := utils.ValidateRedirectRule(rule); len(errs) > 0 {
+		return errs
+	}
+
+	rule.ID = primitive.NewObjectID()
+	rule.Hits = 0
+	rule.LastHitAt = nil
+	rule.CreatedAt = time.Now()
+	rule.UpdatedAt = rule.CreatedAt
+	if _, err := redirectRuleCollection.InsertOne(ctx, rule); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return ErrRedirectRuleExists
+		}
+		return err
+	}
+	invalidateRedirectRules()
+	return nil
+}
+
+// GetRedirectRules lists the redirect rules matching the filter
+func GetRedirectRules(ctx context.Context, filter bson.M, opts ListOptions) ([]models.RedirectRule, *PageInfo, error) {
+	return FindPage[models.RedirectRule](ctx, redirectRuleCollection, filter, opts)
+}
+
+// GetRedirectRule finds a redirect rule by ID
+func GetRedirectRule(ctx context.Context, id primitive.ObjectID) (*models.RedirectRule, error) {
+	var rule models.RedirectRule
+	if err := redirectRuleCollection.FindOne(ctx, bson.M{"_id": id}).Decode(&rule); err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+// UpdateRedirectRule replaces the editable fields of a rule; isabet sayaçları korunur
+func UpdateRedirectRule(ctx context.Context, id primitive.ObjectID, rule *models.RedirectRule) error {
+	if errs := utils.ValidateRedirectRule(rule); len(errs) > 0 {
+		return errs
+	}
+
+	result, err := redirectRuleCollection.UpdateByID(ctx, id, bson.M{"$set": bson.M{
+		"source":      rule.Source,
+		"match_type":  rule.MatchType,
+		"target":      rule.Target,
+		"status_code": rule.StatusCode,
+		"lang":        rule.Lang,
+		"active":      rule.Active,
+		"note":        rule.Note,
+		"updated_at":  time.Now(),
+		"updated_by":  rule.UpdatedBy,
+	}})
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return ErrRedirectRuleExists
+		}
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	invalidateRedirectRules()
+	return nil
+}
+
+// DeleteRedirectRule removes a redirect rule
+func DeleteRedirectRule(ctx context.Context, id primitive.ObjectID) error {
+	result, err := redirectRuleCollection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	invalidateRedirectRules()
+	return nil
+}
+
+// ImportRedirectRules creates or updates rules by source, match type and language; sayaçlar korunur
+func ImportRedirectRules(ctx context.Context, rules []models.RedirectRule, username string) (*models.RedirectImportResult, error) {
+	result := &models.RedirectImportResult{}
+	if len(rules) == 0 {
+		return result, nil
+	}
+
+	now := time.Now()
+	writes := make([]mongo.WriteModel, 0, len(rules))
+	for _, rule := range rules {
+		writes = append(writes, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"source": rule.Source, "match_type": rule.MatchType, "lang": rule.Lang}).
+			SetUpdate(bson.M{
+				"$set": bson.M{
+					"target":      rule.Target,
+					"status_code": rule.StatusCode,
+					"active":      rule.Active,
+					"note":        rule.Note,
+					"updated_at":  now,
+					"updated_by":  username,
+				},
+				"$setOnInsert": bson.M{"hits": int64(0), "created_at": now, "created_by": username},
+			}).
+			SetUpsert(true))
+	}
+
+	bulk, err := redirectRuleCollection.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+	if bulk != nil {
+		result.Created = int(bulk.UpsertedCount)
+		result.Updated = int(bulk.MatchedCount)
+	}
+	invalidateRedirectRules()
+	return result, err
+}
+
+// ExportRedirectRules returns all rules ordered by match type and source
+func ExportRedirectRules(ctx context.Context) ([]models.RedirectRule, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "match_type", Value: 1}, {Key: "source", Value: 1}, {Key: "lang", Value: 1}})
+	cursor, err := redirectRuleCollection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+	rules := []models.RedirectRule{}
+	if err := cursor.All(ctx, &rules); err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// MatchRedirectRule finds the active rule for a request path and returns it with the resolved target
+func MatchRedirectRule(path, lang string) (*models.RedirectRule, string) {
+	matcher := cachedRedirectRules()
+	if matcher == nil {
+		return nil, ""
+	}
+	return matcher.Match(path, lang)
+}
+
+// RecordRedirectHit increments the hit counter of a rule in the background
+func RecordRedirectHit(id primitive.ObjectID) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_, err := redirectRuleCollection.UpdateByID(ctx, id, bson.M{
+			"$inc": bson.M{"hits": 1},
+			"$set": bson.M{"last_hit_at": time.Now()},
+		})
+		if err != nil {
+			log.Printf("Failed to record redirect hit for %s: %v", id.Hex(), err)
+		}
+	}()
+}
+
+// cachedRedirectRules returns the compiled active rules, süresi dolmuşsa yeniden yükler.
+// Yükleme başarısız olursa önceki kurallarla devam edilir ve TTL dolana kadar tekrar denenmez.
+func cachedRedirectRules() *utils.RedirectMatcher {
+	if matcher, fresh := freshRedirectRules(); fresh || redirectRuleCollection == nil {
+		return matcher
+	}
+
+	redirectRuleCache.reload.Lock()
+	defer redirectRuleCache.reload.Unlock()
+	// Beklerken başka bir istek yüklemiş olabilir
+	if matcher, fresh := freshRedirectRules(); fresh {
+		return matcher
+	}
+	redirectRuleCache.RLock()
+	generation := redirectRuleCache.generation
+	redirectRuleCache.RUnlock()
+
+	rules, err := loadRedirectRules()
+
+	redirectRuleCache.Lock()
+	defer redirectRuleCache.Unlock()
+	if err != nil {
+		log.Printf("Failed to load redirect rules: %v", err)
+	} else {
+		redirectRuleCache.matcher = utils.CompileRedirectRules(rules)
+	}
+	if redirectRuleCache.generation == generation {
+		redirectRuleCache.loadedAt = time.Now()
+	}
+	return redirectRuleCache.matcher
+}
+
+// freshRedirectRules returns the cached matcher and whether it is within the TTL
+func freshRedirectRules() (*utils.RedirectMatcher, bool) {
+	redirectRuleCache.RLock()
+	defer redirectRuleCache.RUnlock()
+	return redirectRuleCache.matcher, !redirectRuleCache.loadedAt.IsZero() && time.Since(redirectRuleCache.loadedAt) < redirectRuleCacheTTL
+}
+
+func loadRedirectRules() ([]models.RedirectRule, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	opts := options.Find().SetSort(bson.M{"_id": 1}) // Düzenli ifadeler oluşturulma sırasıyla denenir
+	cursor, err := redirectRuleCollection.Find(ctx, bson.M{"active": true}, opts)
+	if err != nil {
+		return nil, err
+	}
+	var rules []models.RedirectRule
+	if err := cursor.All(ctx, &rules); err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+func invalidateRedirectRules() {
+	redirectRuleCache.Lock()
+	redirectRuleCache.loadedAt = time.Time{}
+	redirectRuleCache.generation++
+	redirectRuleCache.Unlock()
+}
@@ -0,0 +1,84 @@
+package services
+
+import (
+	"admin-panel/models"
+	"context"
+	"log"
+	"net/http"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var redirectCollection *mongo.Collection
+
+func InitRedirectService(client *mongo.Client) {
+	redirectCollection = client.Database("admin_panel").Collection("redirects")
+
+	// Bir dilde aynı eski slug için tek yönlendirme olabilir
+	_, _ = redirectCollection.Indexes().CreateOne(context.Background(), mongo.IndexModel{
+		Keys:    bson.D{{Key: "lang", Value: 1}, {Key: "from_slug", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+
+	// Yönetilen yönlendirme kuralları (exact, prefix, regex)
+	redirectRuleCollection = client.Database("admin_panel").Collection("redirect_rules")
+	_, _ = redirectRuleCollection.Indexes().CreateOne(context.Background(), mongo.IndexModel{
+		Keys:    bson.D{{Key: "source", Value: 1}, {Key: "match_type", Value: 1}, {Key: "lang", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+}
+
+// GetRedirect finds the redirect registered for an old slug
+func GetRedirect(ctx context.Context, lang, slug string) (*models.Redirect, error) {
+	var redirect models.Redirect
+	err := redirectCollection.FindOne(ctx, bson.M{"lang": lang, "from_slug": slug}).Decode(&redirect)
+	if err != nil {
+		return nil, err
+	}
+	return &redirect, nil
+}
+
+// recordSlugRedirects stores a 301 redirect for every slug that changed between before and after.
+// Eski yönlendirmeler de yeni slug'a çevrilir, böylece zincirleme yönlendirme oluşmaz.
+func recordSlugRedirects(ctx context.Context, entityType string, id primitive.ObjectID, before, after map[string]string) {
+	if redirectCollection == nil {
+		return
+	}
+
+	for lang, slug := range after {
+		// Slug tekrar kullanılıyorsa bu slug'dan yapılan yönlendirme artık geçersiz
+		if _, err := redirectCollection.DeleteMany(ctx, bson.M{"lang": lang, "from_slug": slug}); err != nil {
+			log.Printf("Failed to clean up redirects for %s/%s: %v", lang, slug, err)
+		}
+
+		old, ok := before[lang]
+		if !ok || old == "" || old == slug {
+			continue
+		}
+
+		_, err := redirectCollection.UpdateOne(ctx,
+			bson.M{"lang": lang, "from_slug": old},
+			bson.M{
+				"$set":         bson.M{"to_slug": slug, "entity_type": entityType, "entity_id": id, "status_code": http.StatusMovedPermanently},
+				"$setOnInsert": bson.M{"created_at": time.Now()},
+			},
+			options.Update().SetUpsert(true),
+		)
+		if err != nil {
+			log.Printf("Failed to record redirect %s/%s -> %s: %v", lang, old, slug, err)
+			continue
+		}
+
+		_, err = redirectCollection.UpdateMany(ctx,
+			bson.M{"lang": lang, "entity_type": entityType, "entity_id": id},
+			bson.M{"$set": bson.M{"to_slug": slug}},
+		)
+		if err != nil {
+			log.Printf("Failed to update redirects of %s %s: %v", entityType, id.Hex(), err)
+		}
+	}
+}
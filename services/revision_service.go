@@ -0,0 +1,283 @@
+package services
+
+import (
+	"admin-panel/models"
+	"admin-panel/utils"
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var revisionCollection *mongo.Collection
+
+// Geri yüklemede anlık görüntüden alınmayan, iş akışına ait alanlar; yayın durumu yalnızca iş akışıyla değişir
+var workflowFields = []string{"status", "publish_date", "unpublish_date", "review_comment", "reviewed_by"}
+
+// Eşzamanlı kayıtlarda aynı revizyon numarası alındığında yapılacak en fazla deneme
+const maxRevisionAttempts = 5
+
+func InitRevisionService(client *mongo.Client) {
+	revisionCollection = client.Database("admin_panel").Collection("post_revisions")
+
+	// Aynı doküman için revizyon numarası tekil olmalı
+	_, _ = revisionCollection.Indexes().CreateOne(context.Background(), mongo.IndexModel{
+		Keys:    bson.D{{Key: "entity_type", Value: 1}, {Key: "entity_id", Value: 1}, {Key: "number", Value: -1}},
+		Options: options.Index().SetUnique(true),
+	})
+}
+
+// ToDocument converts a model into a bson.M snapshot
+func ToDocument(v interface{}) (bson.M, error) {
+	data, err := bson.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc bson.M
+	if err := bson.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// SaveRevision stores an immutable snapshot of the current state of a document.
+// If the document has no revisions yet, the previous state is stored first as the initial revision.
+func SaveRevision(ctx context.Context, entityType string, entityID primitive.ObjectID, previous, current interface{}, userID primitive.ObjectID, username, note string) (*models.Revision, error) {
+	return saveRevision(ctx, entityType, entityID, previous, current, userID, username, note, nil)
+}
+
+// saveRevision numbers the revision after the last one; tekil indeks eşzamanlı bir kayıt nedeniyle
+// numarayı reddederse son revizyon yeniden okunur ve tekrar denenir
+func saveRevision(ctx context.Context, entityType string, entityID primitive.ObjectID, previous, current interface{}, userID primitive.ObjectID, username, note string, restoredFrom *primitive.ObjectID) (*models.Revision, error) {
+	for attempt := 1; ; attempt++ {
+		revision, err := insertRevision(ctx, entityType, entityID, previous, current, userID, username, note, restoredFrom)
+		if mongo.IsDuplicateKeyError(err) && attempt < maxRevisionAttempts {
+			continue
+		}
+		return revision, err
+	}
+}
+
+func insertRevision(ctx context.Context, entityType string, entityID primitive.ObjectID, previous, current interface{}, userID primitive.ObjectID, username, note string, restoredFrom *primitive.ObjectID) (*models.Revision, error) {
+	last, err := getLastRevision(ctx, entityType, entityID)
+	if err != nil && err != mongo.ErrNoDocuments {
+		return nil, err
+	}
+
+	if last == nil && previous != nil {
+		baseline, err := ToDocument(previous)
+		if err != nil {
+			return nil, err
+		}
+		last = &models.Revision{
+			ID:           primitive.NewObjectID(),
+			EntityType:   entityType,
+			EntityID:     entityID,
+			Number:       1,
+			Snapshot:     baseline,
+			ChangedLangs: []string{},
+			Note:         "initial",
+			CreatedAt:    time.Now(),
+		}
+		if _, err := revisionCollection.InsertOne(ctx, last); err != nil {
+			return nil, err
+		}
+	}
+
+	snapshot, err := ToDocument(current)
+	if err != nil {
+		return nil, err
+	}
+
+	revision := models.Revision{
+		ID:           primitive.NewObjectID(),
+		EntityType:   entityType,
+		EntityID:     entityID,
+		Number:       1,
+		Snapshot:     snapshot,
+		ChangedLangs: []string{},
+		Note:         note,
+		RestoredFrom: restoredFrom,
+		UserID:       userID,
+		Username:     username,
+		CreatedAt:    time.Now(),
+	}
+	if last != nil {
+		revision.Number = last.Number + 1
+		revision.ChangedLangs = utils.ChangedLanguages(utils.DiffDocuments(last.Snapshot, snapshot))
+	}
+
+	if _, err := revisionCollection.InsertOne(ctx, revision); err != nil {
+		return nil, err
+	}
+	return &revision, nil
+}
+
+// GetRevisions lists revisions of a document, newest first
+func GetRevisions(ctx context.Context, entityType string, entityID primitive.ObjectID) ([]models.Revision, error) {
+	filter := bson.M{"entity_type": entityType, "entity_id": entityID}
+	opts := options.Find().SetSort(bson.M{"number": -1}).SetProjection(bson.M{"snapshot": 0})
+
+	cursor, err := revisionCollection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	revisions := []models.Revision{}
+	for cursor.Next(ctx) {
+		var revision models.Revision
+		if err := cursor.Decode(&revision); err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, revision)
+	}
+	return revisions, nil
+}
+
+// GetRevisionByID retrieves a single revision belonging to the given document
+func GetRevisionByID(ctx context.Context, entityType string, entityID, revisionID primitive.ObjectID) (*models.Revision, error) {
+	var revision models.Revision
+	filter := bson.M{"_id": revisionID, "entity_type": entityType, "entity_id": entityID}
+	if err := revisionCollection.FindOne(ctx, filter).Decode(&revision); err != nil {
+		return nil, err
+	}
+	return &revision, nil
+}
+
+// DiffRevisions returns the field-level differences between two revisions of the same document
+func DiffRevisions(ctx context.Context, entityType string, entityID, fromID, toID primitive.ObjectID) ([]models.FieldChange, error) {
+	from, err := GetRevisionByID(ctx, entityType, entityID, fromID)
+	if err != nil {
+		return nil, err
+	}
+	to, err := GetRevisionByID(ctx, entityType, entityID, toID)
+	if err != nil {
+		return nil, err
+	}
+	return utils.DiffDocuments(from.Snapshot, to.Snapshot), nil
+}
+
+// RestoreRevision replaces the document with the snapshot of a revision and records it as a new revision.
+// Durum ve yayın tarihleri geri yüklenmez; belgenin mevcut değerleri korunur.
+func RestoreRevision(ctx context.Context, entityType string, entityID, revisionID primitive.ObjectID, userID primitive.ObjectID, username string) (*models.Revision, error) {
+	source, err := GetRevisionByID(ctx, entityType, entityID, revisionID)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := bson.M{}
+	for key, value := range source.Snapshot {
+		snapshot[key] = value
+	}
+	snapshot["_id"] = entityID
+
+	// Geri yüklenen slug'lar bu arada başka bir içeriğe atanmış olabilir
+	var collection *mongo.Collection
+	switch entityType {
+	case "posts":
+		collection = postCollection
+	case "pages":
+		collection = pageCollection
+	default:
+		return nil, errors.New("unsupported entity type")
+	}
+	if err := keepWorkflowFields(ctx, collection, entityID, snapshot); err != nil {
+		return nil, err
+	}
+	var before, after map[string]string
+	if raw, ok := snapshot["localizations"]; ok {
+		localizations, err := decodeLocalizations(raw)
+		if err != nil {
+			return nil, err
+		}
+		before = currentSlugs(ctx, collection, entityID)
+		if err := EnsureUniqueSlugs(ctx, localizations, entityID); err != nil {
+			return nil, err
+		}
+		snapshot["localizations"] = localizations
+		after = slugsOf(localizations)
+	}
+
+	var current interface{}
+	var referenceSource string
+	switch entityType {
+	case "posts":
+		referenceSource = models.MediaSourcePosts
+		snapshot["updated_at"] = time.Now()
+		err = replaceSnapshot(ctx, postCollection, entityID, snapshot)
+		if err == nil {
+			current, err = GetPostByID(ctx, entityID)
+		}
+	case "pages":
+		referenceSource = models.MediaSourcePages
+		snapshot["updated_at"] = primitive.NewDateTimeFromTime(time.Now())
+		err = replaceSnapshot(ctx, pageCollection, entityID, snapshot)
+		if err == nil {
+			current, err = GetPageByID(ctx, entityID)
+		}
+	default:
+		return nil, errors.New("unsupported entity type")
+	}
+	if err != nil {
+		return nil, err
+	}
+	recordSlugRedirects(ctx, entityType, entityID, before, after)
+	refreshTranslationMeta(ctx, entityType, entityID)
+	reindexSearch(ctx, entityType, entityID)
+	refreshSitemap(ctx, entityType, entityID)
+	refreshMediaReferences(ctx, referenceSource, entityID)
+
+	return saveRevision(ctx, entityType, entityID, nil, current, userID, username, "restore", &source.ID)
+}
+
+// keepWorkflowFields copies the current workflow fields of the document into a snapshot
+func keepWorkflowFields(ctx context.Context, collection *mongo.Collection, id primitive.ObjectID, snapshot bson.M) error {
+	projection := bson.M{}
+	for _, field := range workflowFields {
+		projection[field] = 1
+	}
+	var current bson.M
+	err := collection.FindOne(ctx, notTrashed(bson.M{"_id": id}), options.FindOne().SetProjection(projection)).Decode(&current)
+	if err != nil {
+		return err
+	}
+	for _, field := range workflowFields {
+		if value, ok := current[field]; ok {
+			snapshot[field] = value
+		} else {
+			delete(snapshot, field)
+		}
+	}
+	return nil
+}
+
+// replaceSnapshot replaces a document that is not in the trash
+func replaceSnapshot(ctx context.Context, collection *mongo.Collection, id primitive.ObjectID, snapshot bson.M) error {
+	delete(snapshot, "deleted_at")
+	delete(snapshot, "deleted_by")
+
+	result, err := collection.ReplaceOne(ctx, notTrashed(bson.M{"_id": id}), snapshot)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+func getLastRevision(ctx context.Context, entityType string, entityID primitive.ObjectID) (*models.Revision, error) {
+	var revision models.Revision
+	filter := bson.M{"entity_type": entityType, "entity_id": entityID}
+	opts := options.FindOne().SetSort(bson.M{"number": -1})
+	if err := revisionCollection.FindOne(ctx, filter, opts).Decode(&revision); err != nil {
+		return nil, err
+	}
+	return &revision, nil
+}
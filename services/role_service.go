@@ -4,6 +4,7 @@ import (
 	"admin-panel/models"
 	"context"
 	"errors"
+	"log"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -12,8 +13,53 @@ import (
 
 var rolesCollection *mongo.Collection
 
+// DefaultRolePermissions are the workflow permissions granted to the built-in roles
+var DefaultRolePermissions = map[string]map[string][]string{
+	"admin":  {"posts": {"submit", "approve", "publish"}, "pages": {"submit", "approve", "publish"}},
+	"editor": {"posts": {"submit", "approve", "publish"}, "pages": {"submit", "approve", "publish"}},
+}
+
 func InitRolesService(client *mongo.Client) {
 	rolesCollection = client.Database("admin_panel").Collection("roles")
+
+	if err := EnsureDefaultRolePermissions(context.Background()); err != nil {
+		log.Printf("Varsayılan rol izinleri eklenemedi: %v", err)
+	}
+}
+
+// EnsureDefaultRolePermissions creates the built-in roles and grants them the workflow permissions.
+// İzinler yalnızca bir modülde hiçbir iş akışı izni olmayan rollere eklenir; yöneticinin kaldırdığı izinler geri gelmez.
+func EnsureDefaultRolePermissions(ctx context.Context) error {
+	for roleID, modules := range DefaultRolePermissions {
+		now := time.Now()
+		_, err := rolesCollection.InsertOne(ctx, models.Role{
+			ID:          roleID,
+			Permissions: modules,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+			CreatedBy:   "system",
+		})
+		if err == nil {
+			continue
+		}
+		if !mongo.IsDuplicateKeyError(err) {
+			return err
+		}
+
+		// Mevcut rol: yükseltme öncesi kayıtlara izinleri ekle
+		for module, permissions := range modules {
+			field := "permissions." + module
+			filter := bson.M{"_id": roleID, "permissions": bson.M{"$type": "object"}, field: bson.M{"$nin": permissions}}
+			update := bson.M{
+				"$addToSet": bson.M{field: bson.M{"$each": permissions}},
+				"$set":      bson.M{"updated_at": now},
+			}
+			if _, err := rolesCollection.UpdateOne(ctx, filter, update); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
 }
 
 // GetRolePermissions fetches permissions for a specific role and module
@@ -85,23 +131,8 @@ func DeleteRole(ctx context.Context, roleID string) (int64, error) {
 }
 
 // GetAllRoles retrieves all roles
-func GetAllRoles(ctx context.Context) ([]models.Role, error) {
-	cursor, err := rolesCollection.Find(ctx, bson.M{})
-	if err != nil {
-		return nil, err
-	}
-	defer cursor.Close(ctx)
-
-	var roles []models.Role
-	for cursor.Next(ctx) {
-		var role models.Role
-		if err := cursor.Decode(&role); err != nil {
-			return nil, err
-		}
-		roles = append(roles, role)
-	}
-
-	return roles, nil
+func GetAllRoles(ctx context.Context, opts ListOptions) ([]models.Role, *PageInfo, error) {
+	return FindPage[models.Role](ctx, rolesCollection, bson.M{}, opts)
 }
 
 func GetRoleByID(ctx context.Context, roleID string) (*models.Role, error) {
@@ -0,0 +1,187 @@
+package services
+
+import (
+	"admin-panel/models"
+	"admin-panel/utils"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var ErrUnknownSanitizerPolicy = errors.New("unknown sanitizer policy")
+
+var sanitizerPolicyCollection *mongo.Collection
+
+// Politikalar her kayıtta okunmasın diye kısa süreli önbellek
+const sanitizerCacheTTL = 30 * time.Second
+
+var sanitizerCache struct {
+	sync.RWMutex
+	policies map[string]models.SanitizerPolicy
+	loadedAt time.Time
+}
+
+func InitSanitizerService(client *mongo.Client) {
+	sanitizerPolicyCollection = client.Database("admin_panel").Collection("sanitizer_policies")
+
+	_, _ = sanitizerPolicyCollection.Indexes().CreateOne(context.Background(), mongo.IndexModel{
+		Keys:    bson.D{{Key: "name", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+}
+
+// GetSanitizerPolicies returns the effective policies; kayıtlı olmayanlar için varsayılanlar döner
+func GetSanitizerPolicies(ctx context.Context) (map[string]models.SanitizerPolicy, error) {
+	policies := utils.DefaultSanitizerPolicies()
+
+	cursor, err := sanitizerPolicyCollection.Find(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	var stored []models.SanitizerPolicy
+	if err := cursor.All(ctx, &stored); err != nil {
+		return nil, err
+	}
+	for _, policy := range stored {
+		if _, ok := policies[policy.Name]; ok {
+			policies[policy.Name] = policy
+		}
+	}
+	return policies, nil
+}
+
+// UpdateSanitizerPolicy stores a customized allow-list for one of the built-in policy names
+func UpdateSanitizerPolicy(ctx context.Context, name string, policy *models.SanitizerPolicy) error {
+	if _, ok := utils.DefaultSanitizerPolicies()[name]; !ok {
+		return ErrUnknownSanitizerPolicy
+	}
+
+	// Etiket ve öznitelik adları küçük harfle karşılaştırılır
+	elements := map[string][]string{}
+	for element, attributes := range policy.Elements {
+		elements[strings.ToLower(element)] = lowerAll(attributes)
+	}
+	policy.Name = name
+	policy.Elements = elements
+	policy.GlobalAttributes = lowerAll(policy.GlobalAttributes)
+	policy.URLSchemes = lowerAll(policy.URLSchemes)
+	policy.UpdatedAt = time.Now()
+
+	_, err := sanitizerPolicyCollection.ReplaceOne(ctx, bson.M{"name": name}, policy, options.Replace().SetUpsert(true))
+	invalidateSanitizerCache()
+	return err
+}
+
+// ResetSanitizerPolicy removes the customization of a policy so the built-in default applies again
+func ResetSanitizerPolicy(ctx context.Context, name string) error {
+	if _, ok := utils.DefaultSanitizerPolicies()[name]; !ok {
+		return ErrUnknownSanitizerPolicy
+	}
+	_, err := sanitizerPolicyCollection.DeleteOne(ctx, bson.M{"name": name})
+	invalidateSanitizerCache()
+	return err
+}
+
+func cachedSanitizerPolicies() map[string]models.SanitizerPolicy {
+	sanitizerCache.RLock()
+	if time.Since(sanitizerCache.loadedAt) < sanitizerCacheTTL {
+		defer sanitizerCache.RUnlock()
+		return sanitizerCache.policies
+	}
+	sanitizerCache.RUnlock()
+
+	if sanitizerPolicyCollection == nil {
+		return utils.DefaultSanitizerPolicies()
+	}
+	policies, err := GetSanitizerPolicies(context.Background())
+	if err != nil {
+		// Kayıtlı politikalar okunamazsa varsayılanlarla temizlemeye devam edilir
+		log.Printf("Failed to load sanitizer policies: %v", err)
+		return utils.DefaultSanitizerPolicies()
+	}
+
+	sanitizerCache.Lock()
+	sanitizerCache.policies = policies
+	sanitizerCache.loadedAt = time.Now()
+	sanitizerCache.Unlock()
+	return policies
+}
+
+func invalidateSanitizerCache() {
+	sanitizerCache.Lock()
+	sanitizerCache.loadedAt = time.Time{}
+	sanitizerCache.Unlock()
+}
+
+// contentSanitizer applies the sanitizer policies to the fields of one write and collects the report
+type contentSanitizer struct {
+	policies map[string]models.SanitizerPolicy
+	report   []models.SanitizeReport
+}
+
+func newContentSanitizer() *contentSanitizer {
+	return &contentSanitizer{policies: cachedSanitizerPolicies()}
+}
+
+// clean sanitizes value with the named policy and records what was removed under field
+func (s *contentSanitizer) clean(field, policy, value string) string {
+	if value == "" {
+		return value
+	}
+	cleaned, removed := utils.SanitizeHTML(value, s.policies[policy])
+	if len(removed) > 0 {
+		s.report = append(s.report, models.SanitizeReport{Field: field, Policy: policy, Removed: removed})
+	}
+	return cleaned
+}
+
+// localizations sanitizes the rich content (and the block texts) of every language
+func (s *contentSanitizer) localizations(prefix string, localizations map[string]models.LocalizedField) {
+	for lang, field := range localizations {
+		path := prefix + "." + lang
+		if len(field.Blocks) == 0 {
+			field.Content = s.clean(path+".content", models.PolicyRichText, field.Content)
+		}
+		for i, block := range field.Blocks {
+			if block.Type == models.BlockCode {
+				continue // Kod blokları düz metin olarak kaçışlanır
+			}
+			field.Blocks[i].Text = s.clean(fmt.Sprintf("%s.blocks[%d].text", path, i), models.PolicyRichText, block.Text)
+		}
+		localizations[lang] = field
+	}
+}
+
+// entryValues sanitizes the rich text values of normalized content entry values
+func (s *contentSanitizer) entryValues(prefix string, fields []models.ContentField, values map[string]interface{}) {
+	for _, field := range fields {
+		path := prefix + "." + field.Key
+		switch field.Type {
+		case models.FieldRichText:
+			if text, ok := values[field.Key].(string); ok {
+				values[field.Key] = s.clean(path, models.PolicyRichText, text)
+			}
+		case models.FieldRepeater:
+			items, _ := values[field.Key].([]map[string]interface{})
+			for i, item := range items {
+				s.entryValues(fmt.Sprintf("%s[%d]", path, i), field.Fields, item)
+			}
+		}
+	}
+}
+
+func lowerAll(values []string) []string {
+	lowered := make([]string, 0, len(values))
+	for _, value := range values {
+		lowered = append(lowered, strings.ToLower(strings.TrimSpace(value)))
+	}
+	return lowered
+}
@@ -0,0 +1,191 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const schedulerLockID = "content_scheduler"
+
+var schedulerLockCollection *mongo.Collection
+
+// Zamanlayıcı çalışma aralığı (SCHEDULER_INTERVAL, örn: "30s"), varsayılan 1 dakika
+var schedulerInterval = func() time.Duration {
+	if v := os.Getenv("SCHEDULER_INTERVAL"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d >= time.Second {
+			return d
+		}
+	}
+	return time.Minute
+}()
+
+// Bu uygulama örneğini kilit dokümanında tanımlayan kimlik
+var schedulerInstanceID = func() string {
+	host, _ := os.Hostname()
+	return fmt.Sprintf("%s-%d-%s", host, os.Getpid(), primitive.NewObjectID().Hex())
+}()
+
+var (
+	schedulerCancel context.CancelFunc
+	schedulerWG     sync.WaitGroup
+)
+
+func InitSchedulerService(client *mongo.Client) {
+	schedulerLockCollection = client.Database("admin_panel").Collection("scheduler_locks")
+}
+
+// StartScheduler runs the publish/unpublish job in the background until StopScheduler is called
+func StartScheduler() {
+	ctx, cancel := context.WithCancel(context.Background())
+	schedulerCancel = cancel
+
+	schedulerWG.Add(1)
+	go func() {
+		defer schedulerWG.Done()
+
+		ticker := time.NewTicker(schedulerInterval)
+		defer ticker.Stop()
+
+		for {
+			RunScheduledTransitions(ctx)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	log.Printf("Scheduler started (interval: %s, instance: %s)", schedulerInterval, schedulerInstanceID)
+}
+
+// StopScheduler stops the background job, waits for the running cycle and releases the lease
+func StopScheduler(ctx context.Context) {
+	if schedulerCancel == nil {
+		return
+	}
+	schedulerCancel()
+	schedulerWG.Wait()
+
+	_, err := schedulerLockCollection.DeleteOne(ctx, bson.M{"_id": schedulerLockID, "owner": schedulerInstanceID})
+	if err != nil {
+		log.Printf("Scheduler lease could not be released: %v", err)
+	}
+	log.Println("Scheduler stopped")
+}
+
+// RunScheduledTransitions publishes due posts/pages, unpublishes expired ones and empties the expired trash.
+// Only the instance holding the lease performs the transitions.
+func RunScheduledTransitions(ctx context.Context) {
+	acquired, err := acquireSchedulerLease(ctx)
+	if err != nil {
+		log.Printf("Scheduler lease error: %v", err)
+		return
+	}
+	if !acquired {
+		return
+	}
+
+	now := time.Now()
+	for _, collection := range []*mongo.Collection{postCollection, pageCollection} {
+		module := collection.Name()
+
+		// Zamanı gelen içerikleri yayınla
+		due := notTrashed(bson.M{"status": "scheduled", "publish_date": bson.M{"$lte": now}})
+		transitionStatus(ctx, collection, module, due, "published", "publish")
+
+		// Yayın süresi dolan içerikleri yayından kaldır
+		expired := notTrashed(bson.M{"status": "published", "unpublish_date": bson.M{"$lte": now}})
+		transitionStatus(ctx, collection, module, expired, "unpublished", "unpublish")
+	}
+
+	// Saklama süresi dolan çöp kutusu içeriklerini kalıcı olarak sil
+	PurgeExpiredTrash(ctx)
+}
+
+// acquireSchedulerLease takes or renews the shared lock document; the lease lasts two intervals
+func acquireSchedulerLease(ctx context.Context) (bool, error) {
+	now := time.Now()
+	filter := bson.M{
+		"_id": schedulerLockID,
+		"$or": []bson.M{
+			{"owner": schedulerInstanceID},
+			{"expires_at": bson.M{"$lte": now}},
+		},
+	}
+	update := bson.M{"$set": bson.M{
+		"owner":      schedulerInstanceID,
+		"expires_at": now.Add(2 * schedulerInterval),
+		"renewed_at": now,
+	}}
+
+	_, err := schedulerLockCollection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if err != nil {
+		// Kilit başka bir örnekte ise upsert aynı _id ile çakışır
+		if mongo.IsDuplicateKeyError(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+func transitionStatus(ctx context.Context, collection *mongo.Collection, module string, filter bson.M, status, action string) {
+	cursor, err := collection.Find(ctx, filter, options.Find().SetProjection(bson.M{"_id": 1}))
+	if err != nil {
+		log.Printf("Scheduler query failed for %s: %v", module, err)
+		return
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var doc struct {
+			ID primitive.ObjectID `bson:"_id"`
+		}
+		if err := cursor.Decode(&doc); err != nil {
+			log.Printf("Scheduler decode failed for %s: %v", module, err)
+			continue
+		}
+
+		// Koşul tekrar uygulanır; başka bir güncelleme araya girdiyse atlanır
+		docFilter := bson.M{"_id": doc.ID}
+		for key, value := range filter {
+			docFilter[key] = value
+		}
+
+		var updatedAt interface{} = time.Now()
+		if collection == pageCollection {
+			updatedAt = primitive.NewDateTimeFromTime(time.Now())
+		}
+
+		result, err := collection.UpdateOne(ctx, docFilter, bson.M{"$set": bson.M{
+			"status":     status,
+			"updated_at": updatedAt,
+			"updated_by": "system",
+		}})
+		if err != nil {
+			log.Printf("Scheduler update failed for %s %s: %v", module, doc.ID.Hex(), err)
+			continue
+		}
+		if result.ModifiedCount == 0 {
+			continue
+		}
+		reindexSearch(ctx, module, doc.ID)
+		refreshSitemap(ctx, module, doc.ID)
+
+		details := fmt.Sprintf("scheduler: %s %s -> %s", module, doc.ID.Hex(), status)
+		if err := LogActivity(primitive.NilObjectID, "system", module, action, details); err != nil {
+			log.Printf("Failed to log activity: %v", err)
+		}
+	}
+}
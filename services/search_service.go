@@ -0,0 +1,340 @@
+package services
+
+import (
+	"admin-panel/models"
+	"admin-panel/utils"
+	"context"
+	"log"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var searchCollection *mongo.Collection
+
+// MongoDB metin araması tarafından desteklenen diller (ISO 639-1 -> MongoDB dil adı)
+var textSearchLanguages = map[string]string{
+	"da": "danish", "nl": "dutch", "en": "english", "fi": "finnish", "fr": "french",
+	"de": "german", "hu": "hungarian", "it": "italian", "nb": "norwegian", "no": "norwegian",
+	"pt": "portuguese", "ro": "romanian", "ru": "russian", "es": "spanish", "sv": "swedish",
+	"tr": "turkish",
+}
+
+func InitSearchService(client *mongo.Client) {
+	searchCollection = client.Database("admin_panel").Collection("search_index")
+}
+
+// textSearchLanguage maps a language code (örn: "de-AT") to a MongoDB text search language
+func textSearchLanguage(lang string) string {
+	base := strings.ToLower(strings.SplitN(strings.ReplaceAll(lang, "_", "-"), "-", 2)[0])
+	if language, ok := textSearchLanguages[base]; ok {
+		return language
+	}
+	return "none"
+}
+
+// EnsureSearchIndexes creates the text and filter indexes and fills the index on first run
+func EnsureSearchIndexes(ctx context.Context) error {
+	_, err := searchCollection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "title", Value: "text"}, {Key: "content", Value: "text"}},
+			Options: options.Index().
+				SetName("search_text").
+				SetWeights(bson.D{{Key: "title", Value: 10}, {Key: "content", Value: 1}}).
+				SetDefaultLanguage("none").
+				SetLanguageOverride("language"),
+		},
+		{Keys: bson.D{{Key: "lang", Value: 1}, {Key: "type", Value: 1}, {Key: "status", Value: 1}}},
+		{Keys: bson.D{{Key: "type", Value: 1}, {Key: "entity_id", Value: 1}}},
+	})
+	if err != nil {
+		return err
+	}
+
+	count, err := searchCollection.EstimatedDocumentCount(ctx)
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return RebuildSearchIndex(ctx)
+	}
+	return nil
+}
+
+// RebuildSearchIndex re-indexes every post, page and category
+func RebuildSearchIndex(ctx context.Context) error {
+	for _, source := range []struct {
+		entityType string
+		collection *mongo.Collection
+	}{
+		{"posts", postCollection},
+		{"pages", pageCollection},
+		{"categories", categoryCollection},
+	} {
+		cursor, err := source.collection.Find(ctx, bson.M{}, options.Find().SetProjection(bson.M{"_id": 1}))
+		if err != nil {
+			return err
+		}
+		for cursor.Next(ctx) {
+			var doc struct {
+				ID primitive.ObjectID `bson:"_id"`
+			}
+			if err := cursor.Decode(&doc); err != nil {
+				cursor.Close(ctx)
+				return err
+			}
+			if err := ReindexSearchDocument(ctx, source.entityType, doc.ID); err != nil {
+				cursor.Close(ctx)
+				return err
+			}
+		}
+		cursor.Close(ctx)
+	}
+	return nil
+}
+
+// ReindexSearchDocument rebuilds the search entries of a post, page or category.
+// Doküman bulunamazsa (silinmişse) arama kayıtları kaldırılır.
+func ReindexSearchDocument(ctx context.Context, entityType string, id primitive.ObjectID) error {
+	if searchCollection == nil {
+		return nil
+	}
+
+	var entries []models.SearchDocument
+	now := time.Now()
+
+	switch entityType {
+	case "posts":
+		post, err := GetPostByID(ctx, id)
+		if err == mongo.ErrNoDocuments {
+			return RemoveFromSearchIndex(ctx, entityType, id)
+		}
+		if err != nil {
+			return err
+		}
+		for lang, field := range post.Localizations {
+			entries = append(entries, models.SearchDocument{
+				Title: field.Title, Content: utils.PlainText(field), Slug: field.Slug, Lang: lang,
+				Status: post.Status, CategoryIDs: post.CategoryIDs, PublishDate: post.PublishDate,
+			})
+		}
+	case "pages":
+		page, err := GetPageByID(ctx, id)
+		if err == mongo.ErrNoDocuments {
+			return RemoveFromSearchIndex(ctx, entityType, id)
+		}
+		if err != nil {
+			return err
+		}
+		var publishDate *time.Time
+		if page.PublishDate != nil {
+			t := page.PublishDate.Time()
+			publishDate = &t
+		}
+		for lang, field := range page.Localizations {
+			entries = append(entries, models.SearchDocument{
+				Title: field.Title, Content: utils.PlainText(field), Slug: field.Slug, Lang: lang,
+				Status: page.Status, PublishDate: publishDate,
+			})
+		}
+	case "categories":
+		category, err := GetCategoryByID(ctx, id)
+		if err == mongo.ErrNoDocuments {
+			return RemoveFromSearchIndex(ctx, entityType, id)
+		}
+		if err != nil {
+			return err
+		}
+		for lang, field := range category.Localizations {
+			slug := field.Slug
+			if slug == "" {
+				slug = category.Slug[lang]
+			}
+			entries = append(entries, models.SearchDocument{
+				Title: field.Title, Content: utils.PlainText(field), Slug: slug, Lang: lang,
+			})
+		}
+	default:
+		return nil
+	}
+
+	// Kaldırılan dillerin kayıtlarını temizle
+	if err := RemoveFromSearchIndex(ctx, entityType, id); err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		entry.ID = entityType + ":" + id.Hex() + ":" + entry.Lang
+		entry.Type = entityType
+		entry.EntityID = id
+		entry.Language = textSearchLanguage(entry.Lang)
+		entry.UpdatedAt = now
+		if _, err := searchCollection.InsertOne(ctx, entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RemoveFromSearchIndex deletes all search entries of a document
+func RemoveFromSearchIndex(ctx context.Context, entityType string, id primitive.ObjectID) error {
+	if searchCollection == nil {
+		return nil
+	}
+	_, err := searchCollection.DeleteMany(ctx, bson.M{"type": entityType, "entity_id": id})
+	return err
+}
+
+// reindexSearch keeps the search index in sync after writes without failing the write itself
+func reindexSearch(ctx context.Context, entityType string, id primitive.ObjectID) {
+	if err := ReindexSearchDocument(ctx, entityType, id); err != nil {
+		log.Printf("Failed to update search index for %s %s: %v", entityType, id.Hex(), err)
+	}
+}
+
+// Search performs a ranked, language-aware full-text search with facets
+func Search(ctx context.Context, query models.SearchQuery) (*models.SearchResponse, error) {
+	filter := bson.M{
+		"$text": bson.M{"$search": query.Query, "$language": textSearchLanguage(query.Lang)},
+		"lang":  query.Lang,
+	}
+	if len(query.Types) > 0 {
+		filter["type"] = bson.M{"$in": query.Types}
+	}
+	if query.Status != "" {
+		filter["status"] = query.Status
+	}
+	if query.CategoryID != nil {
+		filter["category_ids"] = *query.CategoryID
+	}
+	if query.DateFrom != nil || query.DateTo != nil {
+		dateFilter := bson.M{}
+		if query.DateFrom != nil {
+			dateFilter["$gte"] = *query.DateFrom
+		}
+		if query.DateTo != nil {
+			dateFilter["$lte"] = *query.DateTo
+		}
+		filter["publish_date"] = dateFilter
+	}
+
+	facetGroup := func(field string) bson.A {
+		return bson.A{
+			bson.M{"$match": bson.M{field: bson.M{"$nin": bson.A{nil, ""}}}},
+			bson.M{"$group": bson.M{"_id": "$" + field, "count": bson.M{"$sum": 1}}},
+			bson.M{"$sort": bson.M{"count": -1}},
+		}
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$addFields", Value: bson.M{"score": bson.M{"$meta": "textScore"}}}},
+		{{Key: "$facet", Value: bson.M{
+			"results": bson.A{
+				bson.M{"$sort": bson.D{{Key: "score", Value: -1}, {Key: "_id", Value: 1}}},
+				bson.M{"$skip": int64((query.Page - 1) * query.Limit)},
+				bson.M{"$limit": int64(query.Limit)},
+			},
+			"total":  bson.A{bson.M{"$count": "count"}},
+			"type":   facetGroup("type"),
+			"status": facetGroup("status"),
+			"category": bson.A{
+				bson.M{"$unwind": "$category_ids"},
+				bson.M{"$group": bson.M{"_id": "$category_ids", "count": bson.M{"$sum": 1}}},
+				bson.M{"$sort": bson.M{"count": -1}},
+			},
+			"date": bson.A{
+				bson.M{"$match": bson.M{"publish_date": bson.M{"$ne": nil}}},
+				bson.M{"$group": bson.M{
+					"_id":   bson.M{"$dateToString": bson.M{"format": "%Y-%m", "date": "$publish_date"}},
+					"count": bson.M{"$sum": 1},
+				}},
+				bson.M{"$sort": bson.M{"_id": -1}},
+			},
+		}}},
+	}
+
+	cursor, err := searchCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	type facetBucket struct {
+		ID    interface{} `bson:"_id"`
+		Count int         `bson:"count"`
+	}
+	var facets []struct {
+		Results []struct {
+			models.SearchDocument `bson:",inline"`
+			Score                 float64 `bson:"score"`
+		} `bson:"results"`
+		Total []struct {
+			Count int `bson:"count"`
+		} `bson:"total"`
+		Type     []facetBucket `bson:"type"`
+		Status   []facetBucket `bson:"status"`
+		Category []facetBucket `bson:"category"`
+		Date     []facetBucket `bson:"date"`
+	}
+	if err := cursor.All(ctx, &facets); err != nil {
+		return nil, err
+	}
+
+	response := &models.SearchResponse{
+		Query:   query.Query,
+		Lang:    query.Lang,
+		Page:    query.Page,
+		Limit:   query.Limit,
+		Results: []models.SearchResult{},
+		Facets:  map[string][]models.FacetCount{},
+	}
+	if len(facets) == 0 {
+		return response, nil
+	}
+
+	result := facets[0]
+	if len(result.Total) > 0 {
+		response.Total = result.Total[0].Count
+	}
+
+	terms := utils.SearchTerms(query.Query)
+	for _, hit := range result.Results {
+		snippet := utils.HighlightSnippet(hit.Content, terms, 80)
+		if hit.Content == "" {
+			snippet = utils.HighlightSnippet(hit.Title, terms, 80)
+		}
+		response.Results = append(response.Results, models.SearchResult{
+			Type:        hit.Type,
+			ID:          hit.EntityID,
+			Title:       hit.Title,
+			Slug:        hit.Slug,
+			Snippet:     snippet,
+			Score:       hit.Score,
+			Status:      hit.Status,
+			PublishDate: hit.PublishDate,
+		})
+	}
+
+	for name, buckets := range map[string][]facetBucket{
+		"type": result.Type, "status": result.Status, "category": result.Category, "date": result.Date,
+	} {
+		counts := []models.FacetCount{}
+		for _, bucket := range buckets {
+			value := ""
+			switch v := bucket.ID.(type) {
+			case string:
+				value = v
+			case primitive.ObjectID:
+				value = v.Hex()
+			}
+			counts = append(counts, models.FacetCount{Value: value, Count: bucket.Count})
+		}
+		response.Facets[name] = counts
+	}
+
+	return response, nil
+}
@@ -0,0 +1,177 @@
+package services
+
+import (
+	"admin-panel/models"
+	"admin-panel/utils"
+	"context"
+	"errors"
+	"regexp"
+	"sort"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var (
+	ErrUnsupportedSEOAudit = errors.New("SEO audits are supported for posts and pages")
+	ErrSEOAuditLanguage    = errors.New("document has no content in the requested language")
+)
+
+// Çakışma mesajlarında listelenen azami içerik sayısı
+const seoDuplicateLimit = 5
+
+// AuditContentSEO analyzes a post or page in one language (lang boşsa tüm dillerde) and stores
+// the results on the document under seo_audit.<lang>. keyword boşsa ilk meta anahtar kelimesi kullanılır.
+func AuditContentSEO(ctx context.Context, entityType string, id primitive.ObjectID, lang, keyword, siteURL string) ([]models.SEOAudit, error) {
+	var collection *mongo.Collection
+	var localizations map[string]models.LocalizedField
+	var metaTags map[string]models.MetaTag
+	seoType := models.SEOTypePost
+	switch entityType {
+	case "posts":
+		post, err := GetPostByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		collection, localizations, metaTags = postCollection, post.Localizations, post.MetaTags
+	case "pages":
+		page, err := GetPageByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		collection, localizations, metaTags, seoType = pageCollection, page.Localizations, page.MetaTags, models.SEOTypePage
+	default:
+		return nil, ErrUnsupportedSEOAudit
+	}
+
+	langs := []string{lang}
+	if lang == "" {
+		langs = make([]string, 0, len(localizations))
+		for l := range localizations {
+			langs = append(langs, l)
+		}
+		sort.Strings(langs)
+	} else if _, ok := localizations[lang]; !ok {
+		return nil, ErrSEOAuditLanguage
+	}
+
+	audits := make([]models.SEOAudit, 0, len(langs))
+	update := bson.M{}
+	for _, l := range langs {
+		field := localizations[l]
+		meta := metaTags[l]
+
+		// Başlık ve açıklama, ön yüzdeki SEO çözümlemesiyle aynı öncelikle belirlenir
+		source, defaults, err := seoSource(ctx, seoType, l, siteURL)
+		if err != nil {
+			return nil, err
+		}
+		excerpt, _ := ContentSummary(field)
+		applySEOText(&source, meta, defaults, field.Title, excerpt)
+
+		focus := keyword
+		if focus == "" && len(meta.Keywords) > 0 {
+			focus = meta.Keywords[0]
+		}
+
+		duplicateTitles, duplicateSlugs, err := seoDuplicates(ctx, id, l, source.Title, field.Slug)
+		if err != nil {
+			return nil, err
+		}
+
+		input := models.SEOAuditInput{
+			Lang:            l,
+			SiteURL:         siteURL,
+			Title:           source.Title,
+			Description:     source.Description,
+			MetaTitle:       meta.Title,
+			MetaDescription: meta.Description,
+			Slug:            field.Slug,
+			Content:         field.Content,
+			Keyword:         focus,
+			DuplicateTitles: duplicateTitles,
+			DuplicateSlugs:  duplicateSlugs,
+		}
+		if focus != "" {
+			input.KeywordSlug = GenerateSlug(l, focus)
+		}
+
+		audit := utils.AuditSEO(input)
+		audit.AuditedAt = time.Now()
+		audits = append(audits, audit)
+		update["seo_audit."+l] = audit
+	}
+
+	// Denetim içeriği değiştirmez; updated_at güncellenmez
+	if len(update) > 0 {
+		if _, err := collection.UpdateByID(ctx, id, bson.M{"$set": update}); err != nil {
+			return nil, err
+		}
+	}
+	return audits, nil
+}
+
+// seoDuplicates lists the other posts and pages that use the same title or slug in a language
+func seoDuplicates(ctx context.Context, id primitive.ObjectID, lang, title, slug string) ([]string, []string, error) {
+	var titles, slugs []string
+	for _, source := range []struct {
+		entityType string
+		collection *mongo.Collection
+	}{
+		{"posts", postCollection},
+		{"pages", pageCollection},
+	} {
+		if title != "" {
+			// Büyük/küçük harf farkı gözetilmez
+			pattern := primitive.Regex{Pattern: "^" + regexp.QuoteMeta(title) + "$", Options: "i"}
+			ids, err := seoDuplicateIDs(ctx, source.collection, notTrashed(bson.M{
+				"_id": bson.M{"$ne": id},
+				"$or": bson.A{
+					bson.M{"localizations." + lang + ".title": pattern},
+					bson.M{"meta_tags." + lang + ".title": pattern},
+				},
+			}))
+			if err != nil {
+				return nil, nil, err
+			}
+			for _, duplicate := range ids {
+				titles = append(titles, source.entityType+":"+duplicate.Hex())
+			}
+		}
+
+		if slug != "" {
+			ids, err := seoDuplicateIDs(ctx, source.collection, notTrashed(bson.M{
+				"_id":                             bson.M{"$ne": id},
+				"localizations." + lang + ".slug": slug,
+			}))
+			if err != nil {
+				return nil, nil, err
+			}
+			for _, duplicate := range ids {
+				slugs = append(slugs, source.entityType+":"+duplicate.Hex())
+			}
+		}
+	}
+	return titles, slugs, nil
+}
+
+func seoDuplicateIDs(ctx context.Context, collection *mongo.Collection, filter bson.M) ([]primitive.ObjectID, error) {
+	cursor, err := collection.Find(ctx, filter, options.Find().SetProjection(bson.M{"_id": 1}).SetLimit(seoDuplicateLimit))
+	if err != nil {
+		return nil, err
+	}
+	var docs []struct {
+		ID primitive.ObjectID `bson:"_id"`
+	}
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+	ids := make([]primitive.ObjectID, 0, len(docs))
+	for _, doc := range docs {
+		ids = append(ids, doc.ID)
+	}
+	return ids, nil
+}
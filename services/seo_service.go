@@ -0,0 +1,280 @@
+package services
+
+import (
+	"admin-panel/models"
+	"admin-panel/utils"
+	"context"
+	"mime"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// ResolvePostSEO computes the head metadata of a post in a language.
+// Öncelik: yazının meta bilgileri, ardından başlık ve özet, en son ApplicationSettings.MetaTags.
+func ResolvePostSEO(ctx context.Context, post *models.Post, lang, siteURL string) (*models.SEOMetadata, error) {
+	field, resolved, _ := ResolveTranslation(post.Localizations, lang)
+	source, defaults, err := seoSource(ctx, models.SEOTypePost, resolved, siteURL)
+	if err != nil {
+		return nil, err
+	}
+
+	excerpt, _ := ContentSummary(field)
+	meta := post.MetaTags[resolved]
+	applySEOText(&source, meta, defaults, field.Title, excerpt)
+	source.Path = "/" + resolved + "/" + field.Slug
+	source.Alternates = alternatePaths(contentPaths(post.Localizations))
+	source.Published = post.PublishDate
+	source.Modified = post.UpdatedAt
+	if err := applySEOImage(ctx, &source, meta, field); err != nil {
+		return nil, err
+	}
+
+	authors, err := feedAuthorNames(ctx, []models.Post{*post})
+	if err != nil {
+		return nil, err
+	}
+	source.Author = authors[post.AuthorID]
+
+	// İlk kategori yazının bölümü ve içerik yolundaki ara adımdır
+	for _, id := range post.CategoryIDs {
+		category, err := GetCategoryByID(ctx, id)
+		if err == mongo.ErrNoDocuments {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		categoryField, categoryLang, _ := ResolveTranslation(category.Localizations, resolved)
+		source.Section = categoryField.Title
+		source.Breadcrumbs = append(source.Breadcrumbs, models.SEOBreadcrumb{
+			Name: categoryField.Title,
+			Path: "/" + categoryLang + "/" + sitemapCategoryPath + "/" + categorySlug(category, categoryLang),
+		})
+		break
+	}
+	source.Breadcrumbs = append(source.Breadcrumbs, models.SEOBreadcrumb{Name: source.Title, Path: source.Path})
+
+	if len(post.TagIDs) > 0 {
+		cursor, err := tagCollection.Find(ctx, notTrashed(bson.M{"_id": bson.M{"$in": post.TagIDs}}))
+		if err != nil {
+			return nil, err
+		}
+		var tags []models.Tag
+		if err := cursor.All(ctx, &tags); err != nil {
+			return nil, err
+		}
+		for _, tag := range tags {
+			source.Tags = append(source.Tags, tag.Name)
+		}
+		sort.Strings(source.Tags)
+	}
+
+	return utils.BuildSEOMetadata(source), nil
+}
+
+// ResolvePageSEO computes the head metadata of a page in a language
+func ResolvePageSEO(ctx context.Context, page *models.Page, lang, siteURL string) (*models.SEOMetadata, error) {
+	field, resolved, _ := ResolveTranslation(page.Localizations, lang)
+	source, defaults, err := seoSource(ctx, models.SEOTypePage, resolved, siteURL)
+	if err != nil {
+		return nil, err
+	}
+
+	excerpt, _ := ContentSummary(field)
+	meta := page.MetaTags[resolved]
+	applySEOText(&source, meta, defaults, field.Title, excerpt)
+	source.Path = "/" + resolved + "/" + field.Slug
+	source.Alternates = alternatePaths(contentPaths(page.Localizations))
+	source.Published = dateTimePtr(page.PublishDate)
+	source.Modified = page.UpdatedAt.Time()
+	if err := applySEOImage(ctx, &source, meta, field); err != nil {
+		return nil, err
+	}
+	source.Breadcrumbs = append(source.Breadcrumbs, models.SEOBreadcrumb{Name: source.Title, Path: source.Path})
+
+	return utils.BuildSEOMetadata(source), nil
+}
+
+// ResolveCategorySEO computes the head metadata of a category in a language; kategorilerin meta bilgisi yoktur
+func ResolveCategorySEO(ctx context.Context, category *models.Category, lang, siteURL string) (*models.SEOMetadata, error) {
+	field, resolved, _ := ResolveTranslation(category.Localizations, lang)
+	source, defaults, err := seoSource(ctx, models.SEOTypeCategory, resolved, siteURL)
+	if err != nil {
+		return nil, err
+	}
+
+	applySEOText(&source, models.MetaTag{}, defaults, field.Title, utils.StripHTML(field.Content))
+	source.Path = "/" + resolved + "/" + sitemapCategoryPath + "/" + categorySlug(category, resolved)
+	source.Alternates = alternatePaths(categoryPaths(category))
+	source.Modified = category.UpdatedAt.Time()
+	if err := applySEOImage(ctx, &source, models.MetaTag{}, field); err != nil {
+		return nil, err
+	}
+	source.Breadcrumbs = append(source.Breadcrumbs, models.SEOBreadcrumb{Name: source.Title, Path: source.Path})
+
+	return utils.BuildSEOMetadata(source), nil
+}
+
+// seoSource fills the site-wide values from the settings and returns the default meta tags of the language
+func seoSource(ctx context.Context, seoType, lang, siteURL string) (models.SEOSource, models.MetaTag, error) {
+	source := models.SEOSource{Type: seoType, Lang: lang, SiteURL: siteURL}
+
+	settings, err := GetSettings()
+	if err != nil && err != mongo.ErrNoDocuments {
+		return source, models.MetaTag{}, err
+	}
+	if settings == nil {
+		settings = &models.ApplicationSettings{}
+	}
+
+	source.SiteName, _, _ = ResolveTranslation(settings.Title, lang)
+	defaults, _, _ := ResolveTranslation(settings.MetaTags, lang)
+	if defaults.Title == "" {
+		defaults.Title = source.SiteName
+	}
+	if defaults.Description == "" {
+		defaults.Description, _, _ = ResolveTranslation(settings.Description, lang)
+	}
+	source.Logo = settings.LogoURL
+
+	// Sosyal medya bağlantıları kararlı sırayla yazılır
+	keys := make([]string, 0, len(settings.SocialMedia))
+	for key := range settings.SocialMedia {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		link := settings.SocialMedia[key]
+		if !link.Active || link.URL == "" {
+			continue
+		}
+		source.SameAs = append(source.SameAs, link.URL)
+		switch strings.ToLower(key) {
+		case "twitter", "x":
+			source.TwitterSite = utils.TwitterHandle(link.URL)
+		}
+	}
+
+	source.Breadcrumbs = []models.SEOBreadcrumb{{Name: defaults.Title, Path: "/" + lang}}
+	if source.SiteName != "" {
+		source.Breadcrumbs[0].Name = source.SiteName
+	}
+	return source, defaults, nil
+}
+
+// applySEOText resolves title, description and keywords: öğenin meta bilgisi, içerik, site varsayılanları
+func applySEOText(source *models.SEOSource, meta, defaults models.MetaTag, title, description string) {
+	source.Title = firstNonEmpty(meta.Title, title, defaults.Title)
+	source.Description = firstNonEmpty(meta.Description, description, defaults.Description)
+	source.Keywords = meta.Keywords
+	if len(source.Keywords) == 0 {
+		source.Keywords = defaults.Keywords
+	}
+}
+
+// applySEOImage picks the sharing image: meta görseli, gövdedeki ilk medya kütüphanesi görseli, site logosu
+func applySEOImage(ctx context.Context, source *models.SEOSource, meta models.MetaTag, field models.LocalizedField) error {
+	var candidates []primitive.ObjectID
+	if meta.ImageID != nil {
+		candidates = append(candidates, *meta.ImageID)
+	}
+	alt := ""
+	for _, block := range field.Blocks {
+		if block.Type == models.BlockImage && block.MediaID != nil {
+			candidates, alt = append(candidates, *block.MediaID), block.Alt
+			break
+		}
+		if block.Type == models.BlockGallery && len(block.MediaIDs) > 0 {
+			candidates, alt = append(candidates, block.MediaIDs[0]), block.Caption
+			break
+		}
+	}
+
+	src := ""
+	if len(field.Blocks) == 0 {
+		var mediaID string
+		src, alt, mediaID = utils.FirstImage(field.Content)
+		if id, err := primitive.ObjectIDFromHex(mediaID); err == nil {
+			candidates = append(candidates, id)
+		}
+	}
+
+	for i, id := range candidates {
+		media, err := seoMedia(ctx, bson.M{"_id": id})
+		if err != nil {
+			return err
+		}
+		if media != nil {
+			imageAlt := firstNonEmpty(alt, MediaAltText(*media, source.Lang))
+			if i == 0 && meta.ImageID != nil {
+				imageAlt = firstNonEmpty(MediaAltText(*media, source.Lang), source.Title)
+			}
+			source.Image = seoImage(media, imageAlt)
+			return nil
+		}
+	}
+
+	if src != "" {
+		// Medya kimliği olmayan site içi görsel dosya yolundan kütüphanede aranır
+		if parsed, err := url.Parse(src); err == nil {
+			address := src
+			if parsed.Host == "" {
+				address = path.Clean(parsed.Path)
+			}
+			media, err := seoMedia(ctx, mediaURLFilter(address))
+			if err != nil {
+				return err
+			}
+			if media != nil {
+				source.Image = seoImage(media, firstNonEmpty(alt, MediaAltText(*media, source.Lang)))
+				return nil
+			}
+		}
+		source.Image = &models.SEOImage{URL: src, Alt: alt}
+		return nil
+	}
+
+	if source.Logo != "" {
+		source.Image = &models.SEOImage{URL: source.Logo, Alt: source.SiteName}
+	}
+	return nil
+}
+
+// seoMedia returns the media item matching the filter if it is an image; bulunamazsa nil döner
+func seoMedia(ctx context.Context, filter bson.M) (*models.Media, error) {
+	var media models.Media
+	err := mediaCollection.FindOne(ctx, notTrashed(filter)).Decode(&media)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(mime.TypeByExtension(strings.ToLower(media.FileType)), "image/") {
+		return nil, nil
+	}
+	return &media, nil
+}
+
+func seoImage(media *models.Media, alt string) *models.SEOImage {
+	return &models.SEOImage{
+		URL:  MediaURL(*media),
+		Alt:  alt,
+		Type: mime.TypeByExtension(strings.ToLower(media.FileType)),
+	}
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if strings.TrimSpace(value) != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -3,9 +3,11 @@ package services
 import (
 	"admin-panel/models"
 	"context"
+	"log"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
@@ -22,16 +24,37 @@ func GetSettings() (*models.ApplicationSettings, error) {
 	if err != nil {
 		return nil, err
 	}
+	settings.DefaultLang = DefaultLanguage() // Varsayılan dil, dil kayıtlarından gelir
 	return &settings, nil
 }
 
 func UpdateSettings(update bson.M, updatedBy string) error {
+	// Varsayılan dil değişikliği dil kayıtlarına yansıtılır
+	if code, ok := update["default_lang"].(string); ok {
+		if err := SetDefaultLanguage(code); err != nil {
+			return err
+		}
+	}
+
 	update["updated_at"] = time.Now()
 	update["updated_by"] = updatedBy
 
 	opts := options.Update().SetUpsert(true) // Upsert seçeneğini etkinleştir
 	_, err := settingsCollection.UpdateOne(context.Background(), bson.M{}, bson.M{"$set": update}, opts)
-	return err
+	if err != nil {
+		return err
+	}
+	refreshMediaReferences(context.Background(), models.MediaSourceSettings, primitive.NilObjectID)
+
+	// Desteklenen diller hreflang bağlantılarını değiştirir; site haritası arka planda yeniden üretilir
+	if _, ok := update["supported_langs"]; ok {
+		go func() {
+			if err := RebuildSitemap(context.Background()); err != nil {
+				log.Printf("Failed to rebuild sitemap: %v", err)
+			}
+		}()
+	}
+	return nil
 }
 
 func GetSocialMediaLinks() (map[string]models.SocialMedia, error) {
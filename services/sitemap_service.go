@@ -0,0 +1,349 @@
+package services
+
+import (
+	"admin-panel/models"
+	"admin-panel/utils"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var ErrUnknownSitemap = errors.New("unknown sitemap")
+
+var sitemapCollection *mongo.Collection
+
+// Site haritasındaki içerik tipleri (alt harita adları: <tip>-<n>.xml)
+var SitemapTypes = []string{"posts", "pages", "categories", "tags"}
+
+// Kategori ve etiket sayfalarının ön yüzdeki yol öneki (örn: /tr/category/haberler)
+const (
+	sitemapCategoryPath = "category"
+	sitemapTagPath      = "tag"
+)
+
+// Site haritası yanıtlarının önbellekte tutulabileceği süre
+var SitemapCacheMaxAge = time.Hour
+
+func InitSitemapService(client *mongo.Client) {
+	sitemapCollection = client.Database("admin_panel").Collection("sitemap_entries")
+}
+
+// EnsureSitemap creates the sitemap indexes and builds the entries on first run
+func EnsureSitemap(ctx context.Context) error {
+	_, err := sitemapCollection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "type", Value: 1}, {Key: "_id", Value: 1}}},
+		{Keys: bson.D{{Key: "type", Value: 1}, {Key: "entity_id", Value: 1}}},
+	})
+	if err != nil {
+		return err
+	}
+
+	count, err := sitemapCollection.EstimatedDocumentCount(ctx)
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return RebuildSitemap(ctx)
+	}
+	return nil
+}
+
+// RebuildSitemap regenerates the entries of every post, page, category and tag
+func RebuildSitemap(ctx context.Context) error {
+	started := time.Now()
+	for _, source := range []struct {
+		entityType string
+		collection *mongo.Collection
+	}{
+		{"posts", postCollection},
+		{"pages", pageCollection},
+		{"categories", categoryCollection},
+		{"tags", tagCollection},
+	} {
+		cursor, err := source.collection.Find(ctx, notTrashed(nil), options.Find().SetProjection(bson.M{"_id": 1}))
+		if err != nil {
+			return err
+		}
+		for cursor.Next(ctx) {
+			var doc struct {
+				ID primitive.ObjectID `bson:"_id"`
+			}
+			if err := cursor.Decode(&doc); err != nil {
+				cursor.Close(ctx)
+				return err
+			}
+			if err := RefreshSitemapEntries(ctx, source.entityType, doc.ID); err != nil {
+				cursor.Close(ctx)
+				return err
+			}
+		}
+		cursor.Close(ctx)
+	}
+
+	// Yeniden üretilmeyen kayıtlar artık var olmayan içeriklere aittir
+	_, err := sitemapCollection.DeleteMany(ctx, bson.M{"generated_at": bson.M{"$lt": started}})
+	return err
+}
+
+// RefreshSitemapEntries regenerates the sitemap entries of a post, page, category or tag.
+// İçerik yayında değilse veya bulunamazsa kayıtları kaldırılır.
+func RefreshSitemapEntries(ctx context.Context, entityType string, id primitive.ObjectID) error {
+	if sitemapCollection == nil {
+		return nil
+	}
+
+	var entries []models.SitemapEntry
+	switch entityType {
+	case "posts":
+		post, err := GetPostByID(ctx, id)
+		if err != nil && err != mongo.ErrNoDocuments {
+			return err
+		}
+		if err == nil && post.Status == "published" {
+			entries = sitemapEntries(contentPaths(post.Localizations), post.UpdatedAt)
+			for i := range entries {
+				entries[i].PublishDate, entries[i].UnpublishDate = post.PublishDate, post.UnpublishDate
+			}
+		}
+	case "pages":
+		page, err := GetPageByID(ctx, id)
+		if err != nil && err != mongo.ErrNoDocuments {
+			return err
+		}
+		if err == nil && page.Status == "published" {
+			entries = sitemapEntries(contentPaths(page.Localizations), page.UpdatedAt.Time())
+			for i := range entries {
+				entries[i].PublishDate, entries[i].UnpublishDate = dateTimePtr(page.PublishDate), dateTimePtr(page.UnpublishDate)
+			}
+		}
+	case "categories":
+		category, err := GetCategoryByID(ctx, id)
+		if err != nil && err != mongo.ErrNoDocuments {
+			return err
+		}
+		if err == nil {
+			entries = sitemapEntries(categoryPaths(category), category.UpdatedAt.Time())
+		}
+	case "tags":
+		tag, err := GetTagByID(ctx, id)
+		if err != nil && err != mongo.ErrNoDocuments {
+			return err
+		}
+		if err == nil {
+			// Etiketlerin çevirisi yoktur; her dilde aynı adla listelenir
+			paths := map[string]string{}
+			for _, lang := range ActiveLanguages() {
+				paths[lang] = "/" + lang + "/" + sitemapTagPath + "/" + utils.GenerateSlug(tag.Name)
+			}
+			entries = sitemapEntries(paths, time.Unix(tag.CreatedAt, 0))
+		}
+	default:
+		return nil
+	}
+
+	if err := RemoveFromSitemap(ctx, entityType, id); err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	documents := make([]interface{}, 0, len(entries))
+	for _, entry := range entries {
+		entry.ID = entityType + ":" + id.Hex() + ":" + entry.Lang
+		entry.Type = entityType
+		entry.EntityID = id
+		entry.GeneratedAt = now
+		documents = append(documents, entry)
+	}
+	_, err := sitemapCollection.InsertMany(ctx, documents)
+	return err
+}
+
+// sitemapEntries builds one entry per supported language with the other languages as hreflang alternates
+func sitemapEntries(paths map[string]string, lastMod time.Time) []models.SitemapEntry {
+	alternates := alternatePaths(paths)
+	entries := make([]models.SitemapEntry, 0, len(alternates))
+	for lang, path := range alternates {
+		if lang == "x-default" {
+			continue
+		}
+		entries = append(entries, models.SitemapEntry{Lang: lang, Path: path, Alternates: alternates, LastMod: lastMod})
+	}
+	return entries
+}
+
+// contentPaths returns the public path of a post or page in every language
+func contentPaths(localizations map[string]models.LocalizedField) map[string]string {
+	paths := map[string]string{}
+	for lang, field := range localizations {
+		paths[lang] = "/" + lang + "/" + field.Slug
+	}
+	return paths
+}
+
+// categoryPaths returns the public path of a category in every language
+func categoryPaths(category *models.Category) map[string]string {
+	paths := map[string]string{}
+	for lang := range category.Localizations {
+		paths[lang] = "/" + lang + "/" + sitemapCategoryPath + "/" + categorySlug(category, lang)
+	}
+	return paths
+}
+
+// categorySlug returns the slug of a category in a language; eski kayıtlarda slug yalnızca Slug alanındadır
+func categorySlug(category *models.Category, lang string) string {
+	if slug := category.Localizations[lang].Slug; slug != "" {
+		return slug
+	}
+	return category.Slug[lang]
+}
+
+// alternatePaths keeps the paths of the supported languages and adds x-default for the default language
+func alternatePaths(paths map[string]string) map[string]string {
+	supported := sitemapLanguages()
+	alternates := map[string]string{}
+	for lang, path := range paths {
+		if containsString(supported, lang) {
+			alternates[lang] = path
+		}
+	}
+	if path, ok := alternates[DefaultLanguage()]; ok {
+		alternates["x-default"] = path
+	}
+	return alternates
+}
+
+// sitemapLanguages returns ApplicationSettings.SupportedLangs; ayarlanmamışsa etkin diller kullanılır
+func sitemapLanguages() []string {
+	if settings, err := GetSettings(); err == nil && len(settings.SupportedLangs) > 0 {
+		return settings.SupportedLangs
+	}
+	return ActiveLanguages()
+}
+
+// RemoveFromSitemap deletes all sitemap entries of a document
+func RemoveFromSitemap(ctx context.Context, entityType string, id primitive.ObjectID) error {
+	if sitemapCollection == nil {
+		return nil
+	}
+	_, err := sitemapCollection.DeleteMany(ctx, bson.M{"type": entityType, "entity_id": id})
+	return err
+}
+
+// refreshSitemap keeps the sitemap in sync after writes without failing the write itself
+func refreshSitemap(ctx context.Context, entityType string, id primitive.ObjectID) {
+	if err := RefreshSitemapEntries(ctx, entityType, id); err != nil {
+		log.Printf("Failed to update sitemap for %s %s: %v", entityType, id.Hex(), err)
+	}
+}
+
+// sitemapFilter matches the entries of a type that are visible now (yayın tarihleri okuma anında uygulanır)
+func sitemapFilter(entityType string, now time.Time) bson.M {
+	return bson.M{
+		"type": entityType,
+		"$and": bson.A{
+			bson.M{"$or": bson.A{bson.M{"publish_date": nil}, bson.M{"publish_date": bson.M{"$lte": now}}}},
+			bson.M{"$or": bson.A{bson.M{"unpublish_date": nil}, bson.M{"unpublish_date": bson.M{"$gt": now}}}},
+		},
+	}
+}
+
+// GetSitemapIndex lists the child sitemaps; her tip SitemapMaxURLs adreslik parçalara bölünür
+func GetSitemapIndex(ctx context.Context, siteURL string) ([]models.SitemapRef, error) {
+	now := time.Now()
+	refs := []models.SitemapRef{}
+	for _, entityType := range SitemapTypes {
+		filter := sitemapFilter(entityType, now)
+		count, err := sitemapCollection.CountDocuments(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+
+		for part := int64(0); part*models.SitemapMaxURLs < count; part++ {
+			// Parçanın en son değişiklik zamanı
+			cursor, err := sitemapCollection.Aggregate(ctx, bson.A{
+				bson.M{"$match": filter},
+				bson.M{"$sort": bson.M{"_id": 1}},
+				bson.M{"$skip": part * models.SitemapMaxURLs},
+				bson.M{"$limit": models.SitemapMaxURLs},
+				bson.M{"$group": bson.M{"_id": nil, "lastmod": bson.M{"$max": "$lastmod"}}},
+			})
+			if err != nil {
+				return nil, err
+			}
+			var groups []struct {
+				LastMod time.Time `bson:"lastmod"`
+			}
+			if err := cursor.All(ctx, &groups); err != nil {
+				return nil, err
+			}
+
+			ref := models.SitemapRef{Loc: fmt.Sprintf("%s/sitemaps/%s-%d.xml", siteURL, entityType, part+1)}
+			if len(groups) > 0 {
+				ref.LastMod = groups[0].LastMod
+			}
+			refs = append(refs, ref)
+		}
+	}
+	return refs, nil
+}
+
+// GetSitemapURLs returns the URLs of one child sitemap and its latest modification time
+func GetSitemapURLs(ctx context.Context, entityType string, part int, siteURL string) ([]models.SitemapURL, time.Time, error) {
+	if !containsString(SitemapTypes, entityType) || part < 1 {
+		return nil, time.Time{}, ErrUnknownSitemap
+	}
+
+	opts := options.Find().
+		SetSort(bson.M{"_id": 1}).
+		SetSkip(int64((part - 1) * models.SitemapMaxURLs)).
+		SetLimit(models.SitemapMaxURLs)
+	cursor, err := sitemapCollection.Find(ctx, sitemapFilter(entityType, time.Now()), opts)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	var entries []models.SitemapEntry
+	if err := cursor.All(ctx, &entries); err != nil {
+		return nil, time.Time{}, err
+	}
+	if len(entries) == 0 && part > 1 {
+		return nil, time.Time{}, ErrUnknownSitemap
+	}
+
+	var latest time.Time
+	urls := make([]models.SitemapURL, 0, len(entries))
+	for _, entry := range entries {
+		alternates := map[string]string{}
+		for lang, path := range entry.Alternates {
+			alternates[lang] = siteURL + escapeSitemapPath(path)
+		}
+		urls = append(urls, models.SitemapURL{Loc: siteURL + escapeSitemapPath(entry.Path), LastMod: entry.LastMod, Alternates: alternates})
+		if entry.LastMod.After(latest) {
+			latest = entry.LastMod
+		}
+	}
+	return urls, latest, nil
+}
+
+// escapeSitemapPath percent-encodes the non-ASCII characters of a path
+func escapeSitemapPath(path string) string {
+	return (&url.URL{Path: path}).EscapedPath()
+}
+
+func dateTimePtr(value *primitive.DateTime) *time.Time {
+	if value == nil {
+		return nil
+	}
+	t := value.Time()
+	return &t
+}
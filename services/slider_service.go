@@ -22,36 +22,29 @@ func CreateSlider(slider *models.Slider) error {
 	slider.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
 
 	_, err := sliderCollection.InsertOne(context.Background(), slider)
+	if err == nil {
+		refreshMediaReferences(context.Background(), models.MediaSourceSliders, slider.ID)
+	}
 	return err
 }
 
-func GetSliders() ([]models.Slider, error) {
-	var sliders []models.Slider
-
-	cursor, err := sliderCollection.Find(context.Background(), bson.M{})
-	if err != nil {
-		return nil, err
-	}
-	defer cursor.Close(context.Background())
-
-	for cursor.Next(context.Background()) {
-		var slider models.Slider
-		if err := cursor.Decode(&slider); err != nil {
-			return nil, err
-		}
-		sliders = append(sliders, slider)
-	}
-
-	return sliders, nil
+func GetSliders(opts ListOptions) ([]models.Slider, *PageInfo, error) {
+	return FindPage[models.Slider](context.Background(), sliderCollection, bson.M{}, opts)
 }
 
 func UpdateSlider(id primitive.ObjectID, update bson.M) error {
 	update["updated_at"] = primitive.NewDateTimeFromTime(time.Now())
 	_, err := sliderCollection.UpdateOne(context.Background(), bson.M{"_id": id}, bson.M{"$set": update})
+	if err == nil {
+		refreshMediaReferences(context.Background(), models.MediaSourceSliders, id)
+	}
 	return err
 }
 
 func DeleteSlider(id primitive.ObjectID) error {
 	_, err := sliderCollection.DeleteOne(context.Background(), bson.M{"_id": id})
+	if err == nil {
+		removeMediaReferences(context.Background(), models.MediaSourceSliders, id)
+	}
 	return err
 }
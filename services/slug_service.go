@@ -0,0 +1,158 @@
+package services
+
+import (
+	"admin-panel/models"
+	"admin-panel/utils"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Bir slug için denenecek en fazla ek sayısı (-2, -3, ...)
+const maxSlugSuffix = 1000
+
+var ErrSlugUnavailable = errors.New("no available slug")
+
+// UniqueSlug returns base, or base with a -2, -3, ... suffix, so that no other post or page
+// uses it in the same language. excludeID, güncellenen dokümanın kendi slug'ını yok saymak içindir.
+func UniqueSlug(ctx context.Context, lang, base string, excludeID primitive.ObjectID) (string, error) {
+	for n := 1; n <= maxSlugSuffix; n++ {
+		candidate := base
+		if n > 1 {
+			candidate = fmt.Sprintf("%s-%d", base, n)
+		}
+
+		taken, err := slugTaken(ctx, lang, candidate, excludeID)
+		if err != nil {
+			return "", err
+		}
+		if !taken {
+			return candidate, nil
+		}
+	}
+	return "", ErrSlugUnavailable
+}
+
+// EnsureUniqueSlugs fills empty slugs from the titles and makes every slug unique in its language
+func EnsureUniqueSlugs(ctx context.Context, localizations map[string]models.LocalizedField, excludeID primitive.ObjectID) error {
+	for lang, field := range localizations {
+		if !utils.IsValidLanguageCode(lang) {
+			return ErrInvalidLanguageCode
+		}
+
+		// Elle girilen slug'dan stop-word çıkarılmaz
+		base := GenerateSlug(lang, field.Title)
+		if field.Slug != "" {
+			opts := slugOptions(lang)
+			opts.StopWords = nil
+			base = utils.GenerateLocalizedSlug(field.Slug, opts)
+		}
+
+		slug, err := UniqueSlug(ctx, lang, base, excludeID)
+		if err != nil {
+			return err
+		}
+		field.Slug = slug
+		localizations[lang] = field
+	}
+	return nil
+}
+
+// GenerateSlug builds a slug for text using the transliteration rules and the slug settings of lang
+func GenerateSlug(lang, text string) string {
+	return utils.GenerateLocalizedSlug(text, slugOptions(lang))
+}
+
+// slugOptions reads the slug settings of lang from the language registry
+func slugOptions(lang string) utils.SlugOptions {
+	opts := utils.SlugOptions{Lang: lang}
+	for _, language := range cachedLanguages() {
+		if language.Code == lang {
+			opts.Replacements = language.SlugReplacements
+			opts.StopWords = language.SlugStopWords
+			opts.MaxLength = language.SlugMaxLength
+			break
+		}
+	}
+	return opts
+}
+
+// slugTaken checks posts and pages, which share the /:lang/:slug URL space
+func slugTaken(ctx context.Context, lang, slug string, excludeID primitive.ObjectID) (bool, error) {
+	filter := bson.M{"localizations." + lang + ".slug": slug, "_id": bson.M{"$ne": excludeID}}
+	for _, collection := range []*mongo.Collection{postCollection, pageCollection} {
+		count, err := collection.CountDocuments(ctx, filter, options.Count().SetLimit(1))
+		if err != nil {
+			return false, err
+		}
+		if count > 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// currentSlugs loads the per-language slugs of a post or page
+func currentSlugs(ctx context.Context, collection *mongo.Collection, id primitive.ObjectID) map[string]string {
+	var doc struct {
+		Localizations map[string]models.LocalizedField `bson:"localizations"`
+	}
+	projection := options.FindOne().SetProjection(bson.M{"localizations": 1})
+	if err := collection.FindOne(ctx, bson.M{"_id": id}, projection).Decode(&doc); err != nil {
+		return nil
+	}
+	return slugsOf(doc.Localizations)
+}
+
+func slugsOf(localizations map[string]models.LocalizedField) map[string]string {
+	slugs := make(map[string]string, len(localizations))
+	for lang, field := range localizations {
+		slugs[lang] = field.Slug
+	}
+	return slugs
+}
+
+// mergeDottedLocalizations turns the "<prefix>.<lang>..." keys of an update into a full <prefix> value so that
+// they go through the same sanitizing, slug and block checks as a complete localizations update
+func mergeDottedLocalizations(ctx context.Context, collection *mongo.Collection, filter bson.M, prefix string, update map[string]interface{}) error {
+	dotted := false
+	for key := range update {
+		dotted = dotted || strings.HasPrefix(key, prefix+".")
+	}
+	if !dotted {
+		return nil
+	}
+
+	var doc bson.M
+	err := collection.FindOne(ctx, filter, options.FindOne().SetProjection(bson.M{prefix: 1})).Decode(&doc)
+	if err != nil && err != mongo.ErrNoDocuments {
+		return err
+	}
+	merged, err := utils.MergeDottedLocalizations(prefix, doc[prefix], update)
+	if err != nil {
+		return err
+	}
+	update[prefix] = merged
+	return nil
+}
+
+// decodeLocalizations converts a localizations value from a generic update map
+func decodeLocalizations(raw interface{}) (map[string]models.LocalizedField, error) {
+	data, err := bson.Marshal(bson.M{"localizations": raw})
+	if err != nil {
+		return nil, err
+	}
+	var doc struct {
+		Localizations map[string]models.LocalizedField `bson:"localizations"`
+	}
+	if err := bson.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	return doc.Localizations, nil
+}
@@ -0,0 +1,103 @@
+package services
+
+import (
+	"admin-panel/utils"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Yerel depolamanın yayınlandığı adres (main.go'daki statik rota)
+const LocalStorageURL = "/uploads"
+
+var ErrUnknownStorage = errors.New("unknown storage backend")
+
+var (
+	storages           = map[string]utils.Storage{}
+	defaultStorageName = "local"
+
+	// Yerel depolama klasörü (MEDIA_LOCAL_ROOT), varsayılan "uploads"
+	LocalStorageRoot = "uploads"
+)
+
+// InitStorage registers the storage backends configured in the environment.
+// local her zaman vardır; s3, S3_BUCKET tanımlıysa eklenir. Yeni yüklemeler MEDIA_STORAGE sürücüsüne yazılır.
+func InitStorage() error {
+	if root := os.Getenv("MEDIA_LOCAL_ROOT"); root != "" {
+		LocalStorageRoot = root
+	}
+	storages = map[string]utils.Storage{
+		"local": &utils.LocalStorage{Root: LocalStorageRoot, BaseURL: LocalStorageURL},
+	}
+
+	if bucket := os.Getenv("S3_BUCKET"); bucket != "" {
+		region := os.Getenv("S3_REGION")
+		if region == "" {
+			region = "us-east-1"
+		}
+		endpoint := os.Getenv("S3_ENDPOINT")
+		if endpoint == "" {
+			endpoint = "https://s3." + region + ".amazonaws.com"
+		}
+		storages["s3"] = &utils.S3Storage{
+			Endpoint:  endpoint,
+			Region:    region,
+			Bucket:    bucket,
+			AccessKey: os.Getenv("S3_ACCESS_KEY"),
+			SecretKey: os.Getenv("S3_SECRET_KEY"),
+			PathStyle: os.Getenv("S3_PATH_STYLE") == "true",
+			PublicURL: os.Getenv("S3_PUBLIC_URL"),
+			Client:    &http.Client{Timeout: s3Timeout()},
+		}
+	}
+
+	defaultStorageName = "local"
+	if name := os.Getenv("MEDIA_STORAGE"); name != "" {
+		if _, ok := storages[name]; !ok {
+			return fmt.Errorf("%w: MEDIA_STORAGE=%s (configured: %s)", ErrUnknownStorage, name, strings.Join(StorageNames(), ", "))
+		}
+		defaultStorageName = name
+	}
+	return nil
+}
+
+// GetStorage returns a registered backend; boş ad, sürücüsü kaydedilmemiş eski medya için local demektir
+func GetStorage(name string) (utils.Storage, error) {
+	if name == "" {
+		name = "local"
+	}
+	storage, ok := storages[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownStorage, name)
+	}
+	return storage, nil
+}
+
+// DefaultStorage returns the backend new uploads are written to
+func DefaultStorage() utils.Storage {
+	return storages[defaultStorageName]
+}
+
+// s3Timeout is the time limit of an S3 request including the file body (S3_TIMEOUT, örn: "10m"), varsayılan 5 dakika
+func s3Timeout() time.Duration {
+	if v := os.Getenv("S3_TIMEOUT"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d >= time.Second {
+			return d
+		}
+	}
+	return utils.S3DefaultTimeout
+}
+
+// StorageNames lists the registered backends
+func StorageNames() []string {
+	names := make([]string, 0, len(storages))
+	for name := range storages {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -21,45 +21,42 @@ func CreateTag(tag models.Tag) (*mongo.InsertOneResult, error) {
 	defer cancel()
 
 	tag.CreatedAt = time.Now().Unix()
-	return tagCollection.InsertOne(ctx, tag)
+	tag.DeletedAt, tag.DeletedBy = nil, ""
+	result, err := tagCollection.InsertOne(ctx, tag)
+	if err == nil {
+		if id, ok := result.InsertedID.(primitive.ObjectID); ok {
+			refreshSitemap(ctx, "tags", id)
+		}
+	}
+	return result, err
 }
 
-func GetAllTags() ([]models.Tag, error) {
-	var tags []models.Tag
+func GetAllTags(opts ListOptions) ([]models.Tag, *PageInfo, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	cursor, err := tagCollection.Find(ctx, bson.M{})
-	if err != nil {
-		return nil, err
-	}
-	defer cursor.Close(ctx)
-
-	for cursor.Next(ctx) {
-		var tag models.Tag
-		if err := cursor.Decode(&tag); err != nil {
-			return nil, err
-		}
-		tags = append(tags, tag)
-	}
-	return tags, nil
+	return FindPage[models.Tag](ctx, tagCollection, notTrashed(nil), opts)
 }
 func GetTagByID(ctx context.Context, tagID primitive.ObjectID) (*models.Tag, error) {
 	var tag models.Tag
-	err := tagCollection.FindOne(ctx, bson.M{"_id": tagID}).Decode(&tag)
+	err := tagCollection.FindOne(ctx, notTrashed(bson.M{"_id": tagID})).Decode(&tag)
 	return &tag, err
 }
 
 func UpdateTag(ctx context.Context, tagID primitive.ObjectID, updatedTag *models.Tag) error {
+	updatedTag.DeletedAt, updatedTag.DeletedBy = nil, ""
 	_, err := tagCollection.UpdateOne(
 		ctx,
-		bson.M{"_id": tagID},
+		notTrashed(bson.M{"_id": tagID}),
 		bson.M{"$set": updatedTag},
 	)
+	if err == nil {
+		refreshSitemap(ctx, "tags", tagID)
+	}
 	return err
 }
 
-func DeleteTag(ctx context.Context, tagID primitive.ObjectID) error {
-	_, err := tagCollection.DeleteOne(ctx, bson.M{"_id": tagID})
-	return err
+// DeleteTag moves a tag to the trash
+func DeleteTag(ctx context.Context, tagID primitive.ObjectID, deletedBy string) error {
+	return moveToTrash(ctx, "tags", tagID, deletedBy)
 }
@@ -0,0 +1,163 @@
+package services
+
+import (
+	"admin-panel/models"
+	"admin-panel/utils"
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var ErrUnsupportedTranslationType = errors.New("unsupported translation type")
+
+// translatableDocument holds the translation fields shared by posts, pages, categories and localized content
+type translatableDocument struct {
+	ID              primitive.ObjectID                `bson:"_id" json:"id"`
+	Localizations   map[string]models.LocalizedField  `bson:"localizations" json:"localizations"`
+	Translations    map[string]models.LocalizedField  `bson:"translations" json:"translations"` // LocalizedContent
+	TranslationMeta map[string]models.TranslationMeta `bson:"translation_meta" json:"translation_meta"`
+}
+
+func (d *translatableDocument) fields() map[string]models.LocalizedField {
+	if d.Translations != nil {
+		return d.Translations
+	}
+	return d.Localizations
+}
+
+// translationSource returns the collection and the translation field of an entity type
+func translationSource(entityType string) (*mongo.Collection, string, error) {
+	switch entityType {
+	case "posts":
+		return postCollection, "localizations", nil
+	case "pages":
+		return pageCollection, "localizations", nil
+	case "categories":
+		return categoryCollection, "localizations", nil
+	case "content":
+		return localizedContentCollection, "translations", nil
+	}
+	return nil, "", ErrUnsupportedTranslationType
+}
+
+// refreshTranslationMeta records which translations changed in the last write.
+// Kaynak dil (varsayılan dil) değiştiyse diğer çeviriler "outdated" olarak görünür.
+func refreshTranslationMeta(ctx context.Context, entityType string, id primitive.ObjectID) {
+	collection, field, err := translationSource(entityType)
+	if err != nil || collection == nil {
+		return
+	}
+
+	var doc translatableDocument
+	projection := bson.M{field: 1, "translation_meta": 1}
+	if err := collection.FindOne(ctx, bson.M{"_id": id}, options.FindOne().SetProjection(projection)).Decode(&doc); err != nil {
+		if err != mongo.ErrNoDocuments {
+			log.Printf("Failed to load translations of %s %s: %v", entityType, id.Hex(), err)
+		}
+		return
+	}
+
+	meta, changed := utils.UpdateTranslationMeta(doc.TranslationMeta, doc.fields(), DefaultLanguage(), time.Now())
+	if !changed {
+		return
+	}
+	if _, err := collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"translation_meta": meta}}); err != nil {
+		log.Printf("Failed to update translation status of %s %s: %v", entityType, id.Hex(), err)
+	}
+}
+
+// GetTranslationStatuses returns the translation status of a document for every enabled language
+func GetTranslationStatuses(ctx context.Context, entityType string, id primitive.ObjectID) ([]models.TranslationStatus, error) {
+	collection, _, err := translationSource(entityType)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc translatableDocument
+	if err := collection.FindOne(ctx, bson.M{"_id": id}).Decode(&doc); err != nil {
+		return nil, err
+	}
+
+	languages := []string{}
+	for _, language := range cachedLanguages() {
+		if language.Enabled {
+			languages = append(languages, language.Code)
+		}
+	}
+	// Dil kaydı yoksa dokümandaki diller kullanılır
+	if len(languages) == 0 {
+		for lang := range doc.fields() {
+			languages = append(languages, lang)
+		}
+	}
+
+	source := DefaultLanguage()
+	statuses := make([]models.TranslationStatus, 0, len(languages))
+	for _, lang := range languages {
+		statuses = append(statuses, translationStatus(entityType, &doc, source, lang))
+	}
+	return statuses, nil
+}
+
+// GetTranslationsByStatus lists the documents whose translation in lang is outdated or missing
+func GetTranslationsByStatus(ctx context.Context, entityType, lang, status string, opts ListOptions) ([]models.TranslationStatus, *PageInfo, error) {
+	collection, field, err := translationSource(entityType)
+	if err != nil {
+		return nil, nil, err
+	}
+	source := DefaultLanguage()
+
+	var filter bson.M
+	switch status {
+	case models.TranslationMissing:
+		filter = bson.M{field + "." + lang: bson.M{"$exists": false}}
+	case models.TranslationOutdated:
+		filter = bson.M{
+			field + "." + lang:           bson.M{"$exists": true},
+			"translation_meta." + lang:   bson.M{"$exists": true},
+			"translation_meta." + source: bson.M{"$exists": true},
+			"$expr": bson.M{"$ne": bson.A{
+				"$translation_meta." + lang + ".source_hash",
+				"$translation_meta." + source + ".hash",
+			}},
+		}
+	default:
+		return nil, nil, errors.New("status must be outdated or missing")
+	}
+
+	docs, info, err := FindPage[translatableDocument](ctx, collection, notTrashed(filter), opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	items := make([]models.TranslationStatus, 0, len(docs))
+	for i := range docs {
+		items = append(items, translationStatus(entityType, &docs[i], source, lang))
+	}
+	return items, info, nil
+}
+
+func translationStatus(entityType string, doc *translatableDocument, source, lang string) models.TranslationStatus {
+	fields := doc.fields()
+	status := models.TranslationStatus{
+		Type:       entityType,
+		ID:         doc.ID,
+		Lang:       lang,
+		SourceLang: source,
+		Status:     utils.TranslationStatus(doc.TranslationMeta, fields, source, lang),
+		Title:      fields[source].Title,
+	}
+	if entry, ok := doc.TranslationMeta[lang]; ok {
+		status.UpdatedAt = &entry.UpdatedAt
+	}
+	if entry, ok := doc.TranslationMeta[source]; ok {
+		status.SourceUpdatedAt = &entry.UpdatedAt
+	}
+	return status
+}
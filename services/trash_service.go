@@ -0,0 +1,212 @@
+package services
+
+import (
+	"admin-panel/models"
+	"context"
+	"errors"
+	"log"
+	"os"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var ErrUnsupportedTrashModule = errors.New("unsupported trash module")
+
+// Çöp kutusundaki içeriklerin kalıcı olarak silinmeden önce bekleme süresi
+// (TRASH_RETENTION, örn: "720h"), varsayılan 30 gün
+var trashRetention = func() time.Duration {
+	if v := os.Getenv("TRASH_RETENTION"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d > 0 {
+			return d
+		}
+	}
+	return 30 * 24 * time.Hour
+}()
+
+// TrashModules lists the modules supporting soft deletion
+var TrashModules = []string{"posts", "pages", "categories", "tags", "media", "comments"}
+
+// notTrashed adds the soft delete condition to filter; varsayılan sorgular çöp kutusunu hariç tutar
+func notTrashed(filter bson.M) bson.M {
+	query := bson.M{"deleted_at": nil}
+	for key, value := range filter {
+		query[key] = value
+	}
+	return query
+}
+
+func trashCollection(module string) (*mongo.Collection, error) {
+	switch module {
+	case "posts":
+		return postCollection, nil
+	case "pages":
+		return pageCollection, nil
+	case "categories":
+		return categoryCollection, nil
+	case "tags":
+		return tagCollection, nil
+	case "media":
+		return mediaCollection, nil
+	case "comments":
+		return commentCollection, nil
+	}
+	return nil, ErrUnsupportedTrashModule
+}
+
+// moveToTrash marks a document as deleted; zaten çöp kutusundaysa mongo.ErrNoDocuments döner
+func moveToTrash(ctx context.Context, module string, id primitive.ObjectID, deletedBy string) error {
+	collection, err := trashCollection(module)
+	if err != nil {
+		return err
+	}
+
+	result, err := collection.UpdateOne(ctx,
+		notTrashed(bson.M{"_id": id}),
+		bson.M{"$set": bson.M{"deleted_at": time.Now(), "deleted_by": deletedBy}},
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+
+	reindexSearch(ctx, module, id)
+	refreshSitemap(ctx, module, id)
+	return nil
+}
+
+// GetTrash lists the trashed documents of a module, newest deletion first by default
+func GetTrash(ctx context.Context, module string, opts ListOptions) (interface{}, *PageInfo, error) {
+	filter := bson.M{"deleted_at": bson.M{"$ne": nil}}
+	switch module {
+	case "posts":
+		return FindPage[models.Post](ctx, postCollection, filter, opts)
+	case "pages":
+		return FindPage[models.Page](ctx, pageCollection, filter, opts)
+	case "categories":
+		return FindPage[models.Category](ctx, categoryCollection, filter, opts)
+	case "tags":
+		return FindPage[models.Tag](ctx, tagCollection, filter, opts)
+	case "media":
+		return FindPage[models.Media](ctx, mediaCollection, filter, opts)
+	case "comments":
+		return FindPage[models.Comment](ctx, commentCollection, filter, opts)
+	}
+	return nil, nil, ErrUnsupportedTrashModule
+}
+
+// RestoreFromTrash clears the deletion marker of a trashed document
+func RestoreFromTrash(ctx context.Context, module string, id primitive.ObjectID) error {
+	collection, err := trashCollection(module)
+	if err != nil {
+		return err
+	}
+
+	result, err := collection.UpdateOne(ctx,
+		bson.M{"_id": id, "deleted_at": bson.M{"$ne": nil}},
+		bson.M{"$unset": bson.M{"deleted_at": "", "deleted_by": ""}},
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+
+	reindexSearch(ctx, module, id)
+	refreshSitemap(ctx, module, id)
+	return nil
+}
+
+// PurgeFromTrash permanently deletes a trashed document
+func PurgeFromTrash(ctx context.Context, module string, id primitive.ObjectID) error {
+	collection, err := trashCollection(module)
+	if err != nil {
+		return err
+	}
+	return purgeTrashed(ctx, module, collection, bson.M{"_id": id, "deleted_at": bson.M{"$ne": nil}}, true)
+}
+
+// PurgeExpiredTrash permanently deletes documents that stayed in the trash longer than the retention period
+func PurgeExpiredTrash(ctx context.Context) {
+	cutoff := time.Now().Add(-trashRetention)
+	for _, module := range TrashModules {
+		collection, _ := trashCollection(module)
+		if err := purgeTrashed(ctx, module, collection, bson.M{"deleted_at": bson.M{"$lte": cutoff}}, false); err != nil {
+			log.Printf("Trash purge failed for %s: %v", module, err)
+		}
+	}
+}
+
+// purgeTrashed deletes the matching documents one by one together with their dependent data
+func purgeTrashed(ctx context.Context, module string, collection *mongo.Collection, filter bson.M, single bool) error {
+	cursor, err := collection.Find(ctx, filter, options.Find().SetProjection(bson.M{"_id": 1, "file_path": 1, "storage": 1, "storage_key": 1, "renditions": 1}))
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	found := false
+	for cursor.Next(ctx) {
+		var doc struct {
+			ID         primitive.ObjectID      `bson:"_id"`
+			FilePath   string                  `bson:"file_path"`
+			Storage    string                  `bson:"storage"`
+			StorageKey string                  `bson:"storage_key"`
+			Renditions []models.MediaRendition `bson:"renditions"`
+		}
+		if err := cursor.Decode(&doc); err != nil {
+			return err
+		}
+
+		// Koşul tekrar uygulanır; arada geri yüklendiyse silinmez
+		result, err := collection.DeleteOne(ctx, bson.M{"_id": doc.ID, "deleted_at": filter["deleted_at"]})
+		if err != nil {
+			return err
+		}
+		if result.DeletedCount == 0 {
+			continue
+		}
+		found = true
+
+		// Bağımlı veriler
+		if err := RemoveFromSitemap(ctx, module, doc.ID); err != nil {
+			log.Printf("Failed to remove %s %s from sitemap: %v", module, doc.ID.Hex(), err)
+		}
+		switch module {
+		case "posts", "pages", "categories":
+			if err := RemoveFromSearchIndex(ctx, module, doc.ID); err != nil {
+				log.Printf("Failed to remove %s %s from search index: %v", module, doc.ID.Hex(), err)
+			}
+		case "media":
+			media := models.Media{ID: doc.ID, FilePath: doc.FilePath, Storage: doc.Storage, StorageKey: doc.StorageKey, Renditions: doc.Renditions}
+			if err := deleteMediaFiles(ctx, &media); err != nil {
+				log.Printf("Failed to delete files of media %s: %v", doc.ID.Hex(), err)
+			}
+			if _, err := mediaReferenceCollection.DeleteMany(ctx, bson.M{"media_id": doc.ID}); err != nil {
+				log.Printf("Failed to delete references of media %s: %v", doc.ID.Hex(), err)
+			}
+		}
+		if module == "posts" || module == "pages" {
+			removeMediaReferences(ctx, module, doc.ID)
+			if _, err := revisionCollection.DeleteMany(ctx, bson.M{"entity_type": module, "entity_id": doc.ID}); err != nil {
+				log.Printf("Failed to delete revisions of %s %s: %v", module, doc.ID.Hex(), err)
+			}
+			if _, err := redirectCollection.DeleteMany(ctx, bson.M{"entity_type": module, "entity_id": doc.ID}); err != nil {
+				log.Printf("Failed to delete redirects of %s %s: %v", module, doc.ID.Hex(), err)
+			}
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return err
+	}
+	if single && !found {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+package services
+
+import (
+	"admin-panel/models"
+	"admin-panel/utils"
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var ErrUnknownUploadPolicy = errors.New("unknown upload policy")
+
+var uploadPolicyCollection *mongo.Collection
+
+// Politikalar her yüklemede okunmasın diye kısa süreli önbellek
+const uploadPolicyCacheTTL = 30 * time.Second
+
+var uploadPolicyCache struct {
+	sync.RWMutex
+	policies map[string]models.UploadPolicy
+	loadedAt time.Time
+}
+
+func InitUploadPolicyService(client *mongo.Client) {
+	uploadPolicyCollection = client.Database("admin_panel").Collection("upload_policies")
+
+	_, _ = uploadPolicyCollection.Indexes().CreateOne(context.Background(), mongo.IndexModel{
+		Keys:    bson.D{{Key: "role", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+}
+
+// GetUploadPolicies returns the effective policies by role; kayıtlı politikalar varsayılanların yerine geçer
+func GetUploadPolicies(ctx context.Context) (map[string]models.UploadPolicy, error) {
+	policies := utils.DefaultUploadPolicies()
+
+	cursor, err := uploadPolicyCollection.Find(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	var stored []models.UploadPolicy
+	if err := cursor.All(ctx, &stored); err != nil {
+		return nil, err
+	}
+	for _, policy := range stored {
+		policies[policy.Role] = policy
+	}
+	return policies, nil
+}
+
+// UpdateUploadPolicy stores the allow-list of a role; boş kural listesi rolün yükleme yapamaması demektir
+func UpdateUploadPolicy(ctx context.Context, role string, policy *models.UploadPolicy) error {
+	role = strings.TrimSpace(role)
+	if role == "" {
+		return ErrUnknownUploadPolicy
+	}
+	if errs := utils.ValidateUploadPolicy(policy); len(errs) > 0 {
+		return errs
+	}
+	if policy.Rules == nil {
+		policy.Rules = []models.UploadRule{}
+	}
+	policy.Role = role
+	policy.UpdatedAt = time.Now()
+
+	_, err := uploadPolicyCollection.ReplaceOne(ctx, bson.M{"role": role}, policy, options.Replace().SetUpsert(true))
+	invalidateUploadPolicyCache()
+	return err
+}
+
+// ResetUploadPolicy removes the stored policy of a role; yerleşik rollerde varsayılan, diğerlerinde "default" politikası geçerli olur
+func ResetUploadPolicy(ctx context.Context, role string) error {
+	result, err := uploadPolicyCollection.DeleteOne(ctx, bson.M{"role": role})
+	if err != nil {
+		return err
+	}
+	invalidateUploadPolicyCache()
+	if _, builtIn := utils.DefaultUploadPolicies()[role]; result.DeletedCount == 0 && !builtIn {
+		return ErrUnknownUploadPolicy
+	}
+	return nil
+}
+
+// UploadRulesForRoles merges the policies of a user's roles; politikası olmayan roller "default" politikasını alır
+func UploadRulesForRoles(roles []string) []models.UploadRule {
+	policies := cachedUploadPolicies()
+	var applied []models.UploadPolicy
+	for _, role := range roles {
+		if policy, ok := policies[role]; ok {
+			applied = append(applied, policy)
+		} else {
+			applied = append(applied, policies[utils.DefaultUploadPolicy])
+		}
+	}
+	if len(applied) == 0 {
+		applied = append(applied, policies[utils.DefaultUploadPolicy])
+	}
+	return utils.MergeUploadRules(applied...)
+}
+
+// MaxUploadSizeForRoles returns the largest file the roles may upload of any type
+func MaxUploadSizeForRoles(roles []string) int64 {
+	var size int64
+	for _, rule := range UploadRulesForRoles(roles) {
+		size = max(size, rule.MaxSize)
+	}
+	return size
+}
+
+func cachedUploadPolicies() map[string]models.UploadPolicy {
+	uploadPolicyCache.RLock()
+	if time.Since(uploadPolicyCache.loadedAt) < uploadPolicyCacheTTL {
+		defer uploadPolicyCache.RUnlock()
+		return uploadPolicyCache.policies
+	}
+	uploadPolicyCache.RUnlock()
+
+	if uploadPolicyCollection == nil {
+		return utils.DefaultUploadPolicies()
+	}
+	policies, err := GetUploadPolicies(context.Background())
+	if err != nil {
+		// Kayıtlı politikalar okunamazsa varsayılanlar uygulanır
+		log.Printf("Failed to load upload policies: %v", err)
+		return utils.DefaultUploadPolicies()
+	}
+
+	uploadPolicyCache.Lock()
+	uploadPolicyCache.policies = policies
+	uploadPolicyCache.loadedAt = time.Now()
+	uploadPolicyCache.Unlock()
+	return policies
+}
+
+func invalidateUploadPolicyCache() {
+	uploadPolicyCache.Lock()
+	uploadPolicyCache.loadedAt = time.Time{}
+	uploadPolicyCache.Unlock()
+}
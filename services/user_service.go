@@ -38,26 +38,29 @@ func CreateUser(user models.User) (*mongo.InsertOneResult, error) {
 	defer cancel()
 
 	user.ID = primitive.NewObjectID()
-	return userCollection.InsertOne(ctx, user)
+	result, err := userCollection.InsertOne(ctx, user)
+	if err == nil {
+		refreshMediaReferences(ctx, models.MediaSourceUsers, user.ID)
+	}
+	return result, err
 }
 
 // GetAllUsers retrieves all users from the database (excludes password)
-func GetAllUsers() ([]models.User, error) {
+func GetAllUsers(opts ListOptions) ([]models.User, *PageInfo, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	opts := options.Find().SetProjection(bson.M{"password": 0})
-	cursor, err := userCollection.Find(ctx, bson.M{}, opts)
+	// Parola alanı sıralama ve projeksiyonda kullanılamaz; aksi halde özet imlece taşınır
+	opts.Hidden = append(opts.Hidden, "password")
+
+	users, info, err := FindPage[models.User](ctx, userCollection, bson.M{}, opts)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-
-	var users []models.User
-	if err := cursor.All(ctx, &users); err != nil {
-		return nil, err
+	for i := range users {
+		users[i].Password = ""
 	}
-
-	return users, nil
+	return users, info, nil
 }
 
 // UpdateUser updates a user in the database
@@ -66,7 +69,11 @@ func UpdateUser(id primitive.ObjectID, update bson.M) (*mongo.UpdateResult, erro
 	defer cancel()
 
 	filter := bson.M{"_id": id}
-	return userCollection.UpdateOne(ctx, filter, bson.M{"$set": update})
+	result, err := userCollection.UpdateOne(ctx, filter, bson.M{"$set": update})
+	if _, ok := update["avatar_url"]; ok && err == nil {
+		refreshMediaReferences(ctx, models.MediaSourceUsers, id)
+	}
+	return result, err
 }
 
 // DeleteUser deletes a user by ID
@@ -75,7 +82,11 @@ func DeleteUser(id primitive.ObjectID) (*mongo.DeleteResult, error) {
 	defer cancel()
 
 	filter := bson.M{"_id": id}
-	return userCollection.DeleteOne(ctx, filter)
+	result, err := userCollection.DeleteOne(ctx, filter)
+	if err == nil {
+		removeMediaReferences(ctx, models.MediaSourceUsers, id)
+	}
+	return result, err
 }
 
 // HashPassword hashes a plain text password with configurable cost
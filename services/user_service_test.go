@@ -0,0 +1,18 @@
+package services
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestGetAllUsersRejectsPassword(t *testing.T) {
+	for _, opts := range []ListOptions{
+		{Sort: "password"},
+		{Sort: "-password"},
+		{Fields: []string{"username", "password"}},
+	} {
+		if _, _, err := GetAllUsers(opts); !errors.Is(err, ErrUnknownField) {
+			t.Errorf("expected %v for %+v, got %v", ErrUnknownField, opts, err)
+		}
+	}
+}
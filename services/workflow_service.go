@@ -0,0 +1,246 @@
+package services
+
+import (
+	"admin-panel/models"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var workflowCollection *mongo.Collection
+
+var (
+	ErrUnknownWorkflowAction = errors.New("unknown workflow action")
+	ErrInvalidTransition     = errors.New("transition not allowed from current status")
+	ErrWorkflowForbidden     = errors.New("you do not have permission for this workflow action")
+	ErrCommentRequired       = errors.New("a comment is required for this workflow action")
+)
+
+// DefaultWorkflowTransitions is used when no workflow document is stored for a module
+var DefaultWorkflowTransitions = []models.WorkflowTransition{
+	{Action: "submit", From: []string{"draft", "rejected"}, To: "in_review", Permission: "submit"},
+	{Action: "approve", From: []string{"in_review"}, To: "approved", Permission: "approve"},
+	{Action: "reject", From: []string{"in_review", "approved"}, To: "rejected", Permission: "approve", RequireComment: true},
+	{Action: "publish", From: []string{"approved", "unpublished"}, To: "published", Permission: "publish"},
+	{Action: "schedule", From: []string{"approved"}, To: "scheduled", Permission: "publish"},
+	{Action: "unpublish", From: []string{"published", "scheduled"}, To: "unpublished", Permission: "publish"},
+	{Action: "revert", From: []string{"in_review", "approved", "rejected", "unpublished"}, To: "draft", Permission: "submit"},
+}
+
+func InitWorkflowService(client *mongo.Client) {
+	workflowCollection = client.Database("admin_panel").Collection("workflows")
+}
+
+// GetWorkflow returns the workflow of a module, falling back to the default transitions
+func GetWorkflow(ctx context.Context, module string) (*models.Workflow, error) {
+	var workflow models.Workflow
+	err := workflowCollection.FindOne(ctx, bson.M{"_id": module}).Decode(&workflow)
+	if err == mongo.ErrNoDocuments {
+		return &models.Workflow{ID: module, Transitions: DefaultWorkflowTransitions}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &workflow, nil
+}
+
+// SaveWorkflow stores the workflow configuration of a module
+func SaveWorkflow(ctx context.Context, workflow models.Workflow) error {
+	workflow.UpdatedAt = time.Now()
+	opts := options.Replace().SetUpsert(true)
+	_, err := workflowCollection.ReplaceOne(ctx, bson.M{"_id": workflow.ID}, workflow, opts)
+	return err
+}
+
+// HasAnyPermission checks whether one of the roles grants the action on the module
+func HasAnyPermission(ctx context.Context, roles []string, module, action string) (bool, error) {
+	for _, role := range roles {
+		permissions, err := GetRolePermissions(ctx, role, module)
+		if err != nil {
+			return false, err
+		}
+		for _, permission := range permissions {
+			if permission == action {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// CanSetStatus reports whether the roles may move a post or page from one status to another
+// without a workflow action. Durum değişmiyorsa serbesttir; aksi halde iş akışında from → to
+// geçişi bulunmalı ve rollerden biri geçişin iznine sahip olmalıdır.
+func CanSetStatus(ctx context.Context, module, from, to string, roles []string) (bool, error) {
+	if from == "" {
+		from = "draft"
+	}
+	if to == "" || to == from {
+		return true, nil
+	}
+	workflow, err := GetWorkflow(ctx, module)
+	if err != nil {
+		return false, err
+	}
+	for _, transition := range workflow.Transitions {
+		if transition.To != to || !containsString(transition.From, from) || transition.RequireComment {
+			continue
+		}
+		allowed, err := HasAnyPermission(ctx, roles, module, transition.Permission)
+		if err != nil || allowed {
+			return allowed, err
+		}
+	}
+	return false, nil
+}
+
+// ApplyWorkflowAction moves a post or page to the next status and notifies its author
+func ApplyWorkflowAction(ctx context.Context, module string, id primitive.ObjectID, request models.WorkflowActionRequest, roles []string, userID primitive.ObjectID, username string) (string, error) {
+	workflow, err := GetWorkflow(ctx, module)
+	if err != nil {
+		return "", err
+	}
+
+	var transition *models.WorkflowTransition
+	for i := range workflow.Transitions {
+		if workflow.Transitions[i].Action == request.Action {
+			transition = &workflow.Transitions[i]
+			break
+		}
+	}
+	if transition == nil {
+		return "", ErrUnknownWorkflowAction
+	}
+	if transition.RequireComment && request.Comment == "" {
+		return "", ErrCommentRequired
+	}
+
+	allowed, err := HasAnyPermission(ctx, roles, module, transition.Permission)
+	if err != nil {
+		return "", err
+	}
+	if !allowed {
+		return "", ErrWorkflowForbidden
+	}
+
+	// Mevcut durumu ve yazarı al
+	var collection *mongo.Collection
+	var previous interface{}
+	var status string
+	var authorID primitive.ObjectID
+	var hasPublishDate bool
+	switch module {
+	case "posts":
+		post, err := GetPostByID(ctx, id)
+		if err != nil {
+			return "", err
+		}
+		collection, previous, status, authorID = postCollection, post, post.Status, post.AuthorID
+		hasPublishDate = post.PublishDate != nil
+	case "pages":
+		page, err := GetPageByID(ctx, id)
+		if err != nil {
+			return "", err
+		}
+		collection, previous, status, authorID = pageCollection, page, page.Status, page.AuthorID
+		hasPublishDate = page.PublishDate != nil
+	default:
+		return "", ErrUnknownWorkflowAction
+	}
+	// Durumu olmayan eski kayıtlar taslak kabul edilir
+	storedStatus := []interface{}{status}
+	if status == "" {
+		status = "draft"
+		storedStatus = append(storedStatus, nil)
+	}
+
+	if !containsString(transition.From, status) {
+		return "", ErrInvalidTransition
+	}
+
+	var updatedAt interface{} = time.Now()
+	if module == "pages" {
+		updatedAt = primitive.NewDateTimeFromTime(time.Now())
+	}
+
+	set := bson.M{
+		"status":         transition.To,
+		"review_comment": request.Comment,
+		"reviewed_by":    username,
+		"updated_at":     updatedAt,
+		"updated_by":     username,
+	}
+	// Yayın tarihi olmadan yayınlanan içerik, akış ve listelerde sıralanabilmesi için şimdiki tarihi alır
+	if transition.To == "published" && !hasPublishDate {
+		set["publish_date"] = updatedAt
+	}
+
+	// Durum hâlâ aynıysa güncelle (eşzamanlı geçişlere karşı)
+	result, err := collection.UpdateOne(ctx,
+		bson.M{"_id": id, "status": bson.M{"$in": storedStatus}},
+		bson.M{"$set": set},
+	)
+	if err != nil {
+		return "", err
+	}
+	if result.MatchedCount == 0 {
+		return "", ErrInvalidTransition
+	}
+
+	reindexSearch(ctx, module, id)
+	refreshSitemap(ctx, module, id)
+
+	// Revizyon ve aktivite kaydı
+	var current interface{}
+	if module == "posts" {
+		current, err = GetPostByID(ctx, id)
+	} else {
+		current, err = GetPageByID(ctx, id)
+	}
+	if err == nil {
+		if _, err := SaveRevision(ctx, module, id, previous, current, userID, username, "workflow:"+transition.Action); err != nil {
+			log.Printf("Failed to save %s revision: %v", module, err)
+		}
+	}
+
+	details := fmt.Sprintf("workflow: %s %s %s -> %s", module, id.Hex(), status, transition.To)
+	if err := LogActivity(userID, username, module, transition.Action, details); err != nil {
+		log.Printf("Failed to log activity: %v", err)
+	}
+
+	// Yazarı bilgilendir
+	if !authorID.IsZero() && authorID != userID {
+		message := fmt.Sprintf("Your %s %s changed from %s to %s by %s", singular(module), id.Hex(), status, transition.To, username)
+		if request.Comment != "" {
+			message += ": " + request.Comment
+		}
+		if err := CreateNotification(ctx, authorID, message); err != nil {
+			log.Printf("Failed to notify author: %v", err)
+		}
+	}
+
+	return transition.To, nil
+}
+
+func containsString(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
+
+func singular(module string) string {
+	if module == "posts" {
+		return "post"
+	}
+	return "page"
+}
@@ -0,0 +1,470 @@
+package utils
+
+import (
+	"admin-panel/models"
+	"bytes"
+	"fmt"
+	"html"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	nethtml "golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// ValidateBlocks checks the required values of every block; prefix örn: "localizations.tr.blocks"
+func ValidateBlocks(blocks []models.ContentBlock, prefix string) ValidationErrors {
+	var errs ValidationErrors
+	for i, block := range blocks {
+		path := fmt.Sprintf("%s[%d]", prefix, i)
+		switch block.Type {
+		case models.BlockParagraph, models.BlockQuote, models.BlockCode:
+			if strings.TrimSpace(block.Text) == "" {
+				errs.add(path+".text", "is required")
+			}
+		case models.BlockHeading:
+			if strings.TrimSpace(block.Text) == "" {
+				errs.add(path+".text", "is required")
+			}
+			if block.Level < 1 || block.Level > 6 {
+				errs.add(path+".level", "must be between 1 and 6")
+			}
+		case models.BlockImage:
+			if block.MediaID == nil || block.MediaID.IsZero() {
+				errs.add(path+".media_id", "must reference a media library item")
+			}
+		case models.BlockGallery:
+			if len(block.MediaIDs) == 0 {
+				errs.add(path+".media_ids", "at least one media item is required")
+			}
+		case models.BlockEmbed:
+			if !isAbsoluteURL(block.URL) {
+				errs.add(path+".url", "must be an absolute http(s) URL")
+			}
+		case models.BlockCTA:
+			if strings.TrimSpace(block.Label) == "" {
+				errs.add(path+".label", "is required")
+			}
+			if !isAbsoluteURL(block.URL) && !isSitePath(block.URL) {
+				errs.add(path+".url", "must be an absolute http(s) URL or a site path")
+			}
+		case models.BlockSlider:
+			if block.SliderID == nil || block.SliderID.IsZero() {
+				errs.add(path+".slider_id", "must reference a slider")
+			}
+		default:
+			errs.add(path+".type", "unknown block type %q", block.Type)
+		}
+	}
+	return errs
+}
+
+// RenderBlocksHTML renders blocks to HTML; mediaURLs medya kimliklerini dosya adreslerine eşler
+func RenderBlocksHTML(blocks []models.ContentBlock, mediaURLs map[primitive.ObjectID]string) string {
+	parts := make([]string, 0, len(blocks))
+	for _, block := range blocks {
+		var b strings.Builder
+		switch block.Type {
+		case models.BlockParagraph:
+			b.WriteString("<p>" + block.Text + "</p>")
+		case models.BlockHeading:
+			level := strconv.Itoa(block.Level)
+			b.WriteString("<h" + level + ">" + block.Text + "</h" + level + ">")
+		case models.BlockImage:
+			b.WriteString(`<figure class="block-image">`)
+			if block.MediaID != nil {
+				writeImage(&b, *block.MediaID, mediaURLs, block.Alt)
+			}
+			writeCaption(&b, block.Caption)
+			b.WriteString("</figure>")
+		case models.BlockGallery:
+			b.WriteString(`<figure class="block-gallery">`)
+			for _, id := range block.MediaIDs {
+				writeImage(&b, id, mediaURLs, "")
+			}
+			writeCaption(&b, block.Caption)
+			b.WriteString("</figure>")
+		case models.BlockQuote:
+			b.WriteString("<blockquote><p>" + block.Text + "</p>")
+			if block.Citation != "" {
+				b.WriteString("<cite>" + html.EscapeString(block.Citation) + "</cite>")
+			}
+			b.WriteString("</blockquote>")
+		case models.BlockEmbed:
+			b.WriteString(`<figure class="block-embed"><iframe src="` + html.EscapeString(block.URL) + `" loading="lazy" allowfullscreen></iframe>`)
+			writeCaption(&b, block.Caption)
+			b.WriteString("</figure>")
+		case models.BlockCode:
+			b.WriteString("<pre><code")
+			if block.Language != "" {
+				b.WriteString(` class="language-` + html.EscapeString(block.Language) + `"`)
+			}
+			b.WriteString(">" + html.EscapeString(block.Text) + "</code></pre>")
+		case models.BlockCTA:
+			b.WriteString(`<div class="block-cta">`)
+			if block.Text != "" {
+				b.WriteString("<p>" + block.Text + "</p>")
+			}
+			b.WriteString(`<a class="button" href="` + html.EscapeString(block.URL) + `">` + html.EscapeString(block.Label) + "</a></div>")
+		case models.BlockSlider:
+			if block.SliderID != nil {
+				b.WriteString(`<div class="block-slider" data-slider-id="` + block.SliderID.Hex() + `"></div>`)
+			}
+		}
+		if b.Len() > 0 {
+			parts = append(parts, b.String())
+		}
+	}
+	return strings.Join(parts, "\n")
+}
+
+// BlocksToText renders blocks to plain text for search indexing and excerpts
+func BlocksToText(blocks []models.ContentBlock) string {
+	parts := make([]string, 0, len(blocks))
+	for _, block := range blocks {
+		var text string
+		switch block.Type {
+		case models.BlockParagraph, models.BlockHeading:
+			text = StripHTML(block.Text)
+		case models.BlockQuote:
+			text = StripHTML(block.Text)
+			if block.Citation != "" {
+				text += " — " + block.Citation
+			}
+		case models.BlockCode:
+			text = strings.TrimSpace(block.Text)
+		case models.BlockImage:
+			text = block.Caption
+			if text == "" {
+				text = block.Alt
+			}
+		case models.BlockGallery, models.BlockEmbed:
+			text = block.Caption
+		case models.BlockCTA:
+			text = strings.TrimSpace(StripHTML(block.Text) + " " + block.Label)
+		}
+		if text = strings.TrimSpace(text); text != "" {
+			parts = append(parts, text)
+		}
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// PlainText returns the plain text of a localized field, bloklar varsa bloklardan üretilir
+func PlainText(field models.LocalizedField) string {
+	if len(field.Blocks) > 0 {
+		return BlocksToText(field.Blocks)
+	}
+	return StripHTML(field.Content)
+}
+
+// HTMLToBlocks converts an HTML body to blocks. Medya kimliği olmayan görsellerin adresi URL alanına yazılır;
+// bloklara karşılığı olmayan içerik düz paragraf olarak korunur ve uyarı listesine eklenir.
+func HTMLToBlocks(content string) ([]models.ContentBlock, []string) {
+	nodes, err := nethtml.ParseFragment(strings.NewReader(content), &nethtml.Node{Type: nethtml.ElementNode, Data: "body", DataAtom: atom.Body})
+	if err != nil {
+		return nil, []string{"could not parse HTML: " + err.Error()}
+	}
+
+	c := &blockConverter{}
+	c.convert(nodes)
+	c.flushInline()
+	return c.blocks, c.warnings
+}
+
+type blockConverter struct {
+	blocks   []models.ContentBlock
+	warnings []string
+	inline   bytes.Buffer // Blok dışındaki satır içi içerik bir paragrafta toplanır
+}
+
+func (c *blockConverter) convert(nodes []*nethtml.Node) {
+	for _, node := range nodes {
+		switch node.Type {
+		case nethtml.TextNode:
+			c.inline.WriteString(html.EscapeString(node.Data))
+			continue
+		case nethtml.ElementNode:
+		default:
+			continue
+		}
+
+		if isInlineElement(node.DataAtom) {
+			_ = nethtml.Render(&c.inline, node)
+			continue
+		}
+		c.flushInline()
+
+		switch node.DataAtom {
+		case atom.P:
+			c.add(models.ContentBlock{Type: models.BlockParagraph, Text: innerHTML(node)})
+		case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6:
+			level, _ := strconv.Atoi(node.Data[1:])
+			c.add(models.ContentBlock{Type: models.BlockHeading, Level: level, Text: innerHTML(node)})
+		case atom.Blockquote:
+			c.add(quoteBlock(node))
+		case atom.Pre:
+			c.add(codeBlock(node))
+		case atom.Img:
+			c.add(imageBlock([]*nethtml.Node{node}, ""))
+		case atom.Iframe:
+			c.add(models.ContentBlock{Type: models.BlockEmbed, URL: attr(node, "src")})
+		case atom.Figure:
+			c.add(figureBlock(node))
+		case atom.Div, atom.Section, atom.Article:
+			switch {
+			case attr(node, "data-slider-id") != "":
+				if id, err := primitive.ObjectIDFromHex(attr(node, "data-slider-id")); err == nil {
+					c.add(models.ContentBlock{Type: models.BlockSlider, SliderID: &id})
+				}
+			case hasClass(node, "block-cta"):
+				c.add(ctaBlock(node))
+			default:
+				// Sarmalayıcı öğeler açılır
+				c.convert(children(node))
+				c.flushInline()
+			}
+		case atom.Script, atom.Style, atom.Noscript, atom.Template:
+			c.warnings = append(c.warnings, "removed <"+node.Data+"> element")
+		case atom.Br, atom.Hr:
+		default:
+			if text := StripHTML(innerHTML(node)); text != "" {
+				c.add(models.ContentBlock{Type: models.BlockParagraph, Text: html.EscapeString(text)})
+				c.warnings = append(c.warnings, "<"+node.Data+"> converted to a plain paragraph")
+			}
+		}
+	}
+}
+
+func (c *blockConverter) add(block models.ContentBlock) {
+	if block.Type == models.BlockParagraph && strings.TrimSpace(StripHTML(block.Text)) == "" {
+		return
+	}
+	c.blocks = append(c.blocks, block)
+}
+
+func (c *blockConverter) flushInline() {
+	text := strings.TrimSpace(c.inline.String())
+	c.inline.Reset()
+	if text != "" {
+		c.add(models.ContentBlock{Type: models.BlockParagraph, Text: text})
+	}
+}
+
+func quoteBlock(node *nethtml.Node) models.ContentBlock {
+	block := models.ContentBlock{Type: models.BlockQuote}
+	var body []*nethtml.Node
+	for _, child := range children(node) {
+		switch child.DataAtom {
+		case atom.Cite, atom.Footer:
+			block.Citation = StripHTML(innerHTML(child))
+		default:
+			body = append(body, child)
+		}
+	}
+	// Tek bir paragraf varsa açılır
+	var paragraphs []*nethtml.Node
+	for _, child := range body {
+		if child.Type == nethtml.ElementNode {
+			paragraphs = append(paragraphs, child)
+		}
+	}
+	if len(paragraphs) == 1 && paragraphs[0].DataAtom == atom.P {
+		block.Text = innerHTML(paragraphs[0])
+	} else {
+		block.Text = strings.TrimSpace(renderNodes(body))
+	}
+	return block
+}
+
+func codeBlock(node *nethtml.Node) models.ContentBlock {
+	block := models.ContentBlock{Type: models.BlockCode, Text: textContent(node)}
+	source := node
+	if code := firstChild(node, atom.Code); code != nil {
+		source = code
+	}
+	for _, class := range strings.Fields(attr(source, "class")) {
+		if language, ok := strings.CutPrefix(class, "language-"); ok {
+			block.Language = language
+		}
+	}
+	return block
+}
+
+func figureBlock(node *nethtml.Node) models.ContentBlock {
+	var images []*nethtml.Node
+	var iframe *nethtml.Node
+	caption := ""
+	var walk func(*nethtml.Node)
+	walk = func(n *nethtml.Node) {
+		for _, child := range children(n) {
+			switch child.DataAtom {
+			case atom.Img:
+				images = append(images, child)
+			case atom.Iframe:
+				iframe = child
+			case atom.Figcaption:
+				caption = StripHTML(innerHTML(child))
+			default:
+				walk(child)
+			}
+		}
+	}
+	walk(node)
+
+	switch {
+	case iframe != nil:
+		return models.ContentBlock{Type: models.BlockEmbed, URL: attr(iframe, "src"), Caption: caption}
+	case len(images) > 1 || hasClass(node, "block-gallery"):
+		block := models.ContentBlock{Type: models.BlockGallery, Caption: caption}
+		for _, img := range images {
+			if id, err := primitive.ObjectIDFromHex(attr(img, "data-media-id")); err == nil {
+				block.MediaIDs = append(block.MediaIDs, id)
+			}
+		}
+		return block
+	}
+	return imageBlock(images, caption)
+}
+
+// imageBlock builds an image block from the first img element; medya kimliği yoksa adres URL alanına yazılır
+func imageBlock(images []*nethtml.Node, caption string) models.ContentBlock {
+	block := models.ContentBlock{Type: models.BlockImage, Caption: caption}
+	if len(images) == 0 {
+		return block
+	}
+	block.Alt = attr(images[0], "alt")
+	if id, err := primitive.ObjectIDFromHex(attr(images[0], "data-media-id")); err == nil {
+		block.MediaID = &id
+	} else {
+		block.URL = attr(images[0], "src")
+	}
+	return block
+}
+
+func ctaBlock(node *nethtml.Node) models.ContentBlock {
+	block := models.ContentBlock{Type: models.BlockCTA}
+	var text []string
+	for _, child := range children(node) {
+		if link := findElement(child, atom.A); link != nil && block.URL == "" {
+			block.URL = attr(link, "href")
+			block.Label = StripHTML(innerHTML(link))
+			continue
+		}
+		if child.DataAtom == atom.P {
+			text = append(text, innerHTML(child))
+		}
+	}
+	block.Text = strings.Join(text, " ")
+	return block
+}
+
+func writeImage(b *strings.Builder, id primitive.ObjectID, mediaURLs map[primitive.ObjectID]string, alt string) {
+	b.WriteString(`<img src="` + html.EscapeString(mediaURLs[id]) + `" alt="` + html.EscapeString(alt) + `" data-media-id="` + id.Hex() + `">`)
+}
+
+func writeCaption(b *strings.Builder, caption string) {
+	if caption != "" {
+		b.WriteString("<figcaption>" + html.EscapeString(caption) + "</figcaption>")
+	}
+}
+
+func isAbsoluteURL(raw string) bool {
+	parsed, err := url.Parse(raw)
+	return err == nil && (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != ""
+}
+
+// isSitePath reports whether raw is a path on this site; "//evil.com" ve "/\evil.com" tarayıcıda başka bir
+// siteye gider
+func isSitePath(raw string) bool {
+	if !strings.HasPrefix(raw, "/") || strings.HasPrefix(raw, "//") || strings.HasPrefix(raw, "/\\") {
+		return false
+	}
+	parsed, err := url.Parse(raw)
+	return err == nil && parsed.Scheme == "" && parsed.Host == ""
+}
+
+func isInlineElement(a atom.Atom) bool {
+	switch a {
+	case atom.A, atom.Abbr, atom.B, atom.Code, atom.Em, atom.I, atom.Mark, atom.S, atom.Small,
+		atom.Span, atom.Strong, atom.Sub, atom.Sup, atom.U, atom.Del, atom.Ins, atom.Kbd:
+		return true
+	}
+	return false
+}
+
+func children(node *nethtml.Node) []*nethtml.Node {
+	var nodes []*nethtml.Node
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		nodes = append(nodes, child)
+	}
+	return nodes
+}
+
+func firstChild(node *nethtml.Node, a atom.Atom) *nethtml.Node {
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		if child.DataAtom == a {
+			return child
+		}
+	}
+	return nil
+}
+
+func findElement(node *nethtml.Node, a atom.Atom) *nethtml.Node {
+	if node.Type == nethtml.ElementNode && node.DataAtom == a {
+		return node
+	}
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		if found := findElement(child, a); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+func attr(node *nethtml.Node, key string) string {
+	for _, a := range node.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+func hasClass(node *nethtml.Node, class string) bool {
+	for _, c := range strings.Fields(attr(node, "class")) {
+		if c == class {
+			return true
+		}
+	}
+	return false
+}
+
+func innerHTML(node *nethtml.Node) string {
+	return strings.TrimSpace(renderNodes(children(node)))
+}
+
+func renderNodes(nodes []*nethtml.Node) string {
+	var buf bytes.Buffer
+	for _, node := range nodes {
+		_ = nethtml.Render(&buf, node)
+	}
+	return buf.String()
+}
+
+func textContent(node *nethtml.Node) string {
+	var b strings.Builder
+	var walk func(*nethtml.Node)
+	walk = func(n *nethtml.Node) {
+		if n.Type == nethtml.TextNode {
+			b.WriteString(n.Data)
+		}
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+	}
+	walk(node)
+	return b.String()
+}
@@ -0,0 +1,101 @@
+package utils
+
+import (
+	"admin-panel/models"
+	"reflect"
+	"strings"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestValidateBlocks(t *testing.T) {
+	blocks := []models.ContentBlock{
+		{Type: models.BlockHeading, Text: "Title", Level: 7},
+		{Type: models.BlockEmbed, URL: "javascript:alert(1)"},
+		{Type: models.BlockCTA, Label: "Join", URL: "/register"},
+		{Type: "video"},
+	}
+	errs := ValidateBlocks(blocks, "blocks")
+
+	fields := []string{}
+	for _, err := range errs {
+		fields = append(fields, err.Field)
+	}
+	expected := []string{"blocks[0].level", "blocks[1].url", "blocks[3].type"}
+	if !reflect.DeepEqual(fields, expected) {
+		t.Errorf("ValidateBlocks failed: expected %v, got %v", expected, fields)
+	}
+}
+
+func TestValidateBlocksCTAURL(t *testing.T) {
+	for _, address := range []string{"/register", "/kampanya?ref=cta#form", "https://example.com/join"} {
+		if errs := ValidateBlocks([]models.ContentBlock{{Type: models.BlockCTA, Label: "Join", URL: address}}, "blocks"); len(errs) > 0 {
+			t.Errorf("expected %q to be accepted, got %v", address, errs)
+		}
+	}
+	for _, address := range []string{"//evil.com", "//evil.com/path", `/\evil.com`, "javascript:alert(1)", "register"} {
+		if errs := ValidateBlocks([]models.ContentBlock{{Type: models.BlockCTA, Label: "Join", URL: address}}, "blocks"); len(errs) == 0 {
+			t.Errorf("expected %q to be rejected", address)
+		}
+	}
+}
+
+func TestBlocksRoundTrip(t *testing.T) {
+	mediaID := primitive.NewObjectID()
+	sliderID := primitive.NewObjectID()
+	blocks := []models.ContentBlock{
+		{Type: models.BlockHeading, Level: 2, Text: "Başlık"},
+		{Type: models.BlockParagraph, Text: "Merhaba <strong>dünya</strong>"},
+		{Type: models.BlockImage, MediaID: &mediaID, Alt: "Logo", Caption: "Şirket logosu"},
+		{Type: models.BlockQuote, Text: "Az olsun, öz olsun", Citation: "Atasözü"},
+		{Type: models.BlockCode, Language: "go", Text: "if a < b {\n}"},
+		{Type: models.BlockCTA, Text: "Hemen katılın", URL: "/register", Label: "Kayıt ol"},
+		{Type: models.BlockSlider, SliderID: &sliderID},
+	}
+
+	rendered := RenderBlocksHTML(blocks, map[primitive.ObjectID]string{mediaID: "/uploads/logo.png"})
+	if !strings.Contains(rendered, `<img src="/uploads/logo.png" alt="Logo"`) || !strings.Contains(rendered, "if a &lt; b") {
+		t.Errorf("RenderBlocksHTML failed: got %s", rendered)
+	}
+
+	converted, warnings := HTMLToBlocks(rendered)
+	if len(warnings) > 0 {
+		t.Errorf("HTMLToBlocks returned warnings: %v", warnings)
+	}
+	if !reflect.DeepEqual(converted, blocks) {
+		t.Errorf("HTMLToBlocks failed:\nexpected %+v\ngot      %+v", blocks, converted)
+	}
+}
+
+func TestHTMLToBlocks(t *testing.T) {
+	blocks, warnings := HTMLToBlocks(`Giriş <em>metni</em><div><h3>Alt</h3><img src="/uploads/a.png" alt="A"></div><script>alert(1)</script><ul><li>Bir</li><li>İki</li></ul>`)
+
+	if len(blocks) != 4 {
+		t.Fatalf("HTMLToBlocks failed: expected 4 blocks, got %+v", blocks)
+	}
+	if blocks[0].Type != models.BlockParagraph || blocks[0].Text != "Giriş <em>metni</em>" {
+		t.Errorf("expected inline paragraph, got %+v", blocks[0])
+	}
+	if blocks[1].Type != models.BlockHeading || blocks[1].Level != 3 {
+		t.Errorf("expected heading level 3, got %+v", blocks[1])
+	}
+	if blocks[2].Type != models.BlockImage || blocks[2].MediaID != nil || blocks[2].URL != "/uploads/a.png" {
+		t.Errorf("expected unresolved image, got %+v", blocks[2])
+	}
+	if blocks[3].Text != "Bir İki" || len(warnings) != 2 {
+		t.Errorf("expected list paragraph and 2 warnings, got %+v %v", blocks[3], warnings)
+	}
+}
+
+func TestBlocksToText(t *testing.T) {
+	blocks := []models.ContentBlock{
+		{Type: models.BlockHeading, Level: 1, Text: "Go"},
+		{Type: models.BlockParagraph, Text: "Hızlı <b>ve</b> basit"},
+		{Type: models.BlockQuote, Text: "Less is more", Citation: "Rob Pike"},
+	}
+	expected := "Go\n\nHızlı ve basit\n\nLess is more — Rob Pike"
+	if result := BlocksToText(blocks); result != expected {
+		t.Errorf("BlocksToText failed: expected %q, got %q", expected, result)
+	}
+}
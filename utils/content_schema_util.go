@@ -0,0 +1,389 @@
+package utils
+
+import (
+	"admin-panel/models"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// İçerik tipi ve alan anahtarları: küçük harfle başlar, harf/rakam/alt çizgi
+var contentKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,63}$`)
+
+var contentFieldTypes = map[string]bool{
+	models.FieldText: true, models.FieldRichText: true, models.FieldNumber: true, models.FieldDate: true,
+	models.FieldBoolean: true, models.FieldMedia: true, models.FieldRelation: true, models.FieldRepeater: true,
+}
+
+// ValidationErrors collects the field errors of a schema or an entry
+type ValidationErrors []models.FieldError
+
+func (e ValidationErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, fieldError := range e {
+		messages = append(messages, fieldError.Field+": "+fieldError.Message)
+	}
+	return "validation failed: " + strings.Join(messages, "; ")
+}
+
+func (e *ValidationErrors) add(field, format string, args ...interface{}) {
+	*e = append(*e, models.FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+// EntryReference is a media or relation reference found while normalizing entry values
+type EntryReference struct {
+	Field string
+	Kind  string // media, relation
+	Type  string // relation: hedef içerik tipi
+	ID    primitive.ObjectID
+}
+
+// IsValidContentKey reports whether key can be used as a content type or field key
+func IsValidContentKey(key string) bool {
+	return contentKeyPattern.MatchString(key)
+}
+
+// ValidateContentType checks the key and the field definitions of a content type
+func ValidateContentType(contentType models.ContentType) ValidationErrors {
+	var errs ValidationErrors
+	if !IsValidContentKey(contentType.Key) {
+		errs.add("key", "must start with a lowercase letter and contain only a-z, 0-9 and _")
+	}
+	if len(contentType.Fields) == 0 {
+		errs.add("fields", "at least one field is required")
+	}
+	validateSchemaFields(contentType.Fields, "fields", &errs)
+	return errs
+}
+
+func validateSchemaFields(fields []models.ContentField, prefix string, errs *ValidationErrors) {
+	seen := map[string]bool{}
+	for i, field := range fields {
+		path := fmt.Sprintf("%s[%d]", prefix, i)
+		if !IsValidContentKey(field.Key) {
+			errs.add(path+".key", "must start with a lowercase letter and contain only a-z, 0-9 and _")
+		} else if seen[field.Key] {
+			errs.add(path+".key", "duplicate field key %q", field.Key)
+		}
+		seen[field.Key] = true
+
+		if !contentFieldTypes[field.Type] {
+			errs.add(path+".type", "unknown field type %q", field.Type)
+			continue
+		}
+
+		rules := field.Validation
+		if field.Multiple && field.Type != models.FieldMedia && field.Type != models.FieldRelation {
+			errs.add(path+".multiple", "only media and relation fields can hold multiple values")
+		}
+		if field.Type == models.FieldRelation && !IsValidContentKey(field.Relation) {
+			errs.add(path+".relation", "relation fields need the key of the target content type")
+		}
+		if field.Type == models.FieldRepeater {
+			if len(field.Fields) == 0 {
+				errs.add(path+".fields", "repeater fields need at least one sub-field")
+			}
+			validateSchemaFields(field.Fields, path+".fields", errs)
+		}
+		if rules.Pattern != "" {
+			if field.Type != models.FieldText {
+				errs.add(path+".validation.pattern", "pattern is only supported for text fields")
+			} else if _, err := regexp.Compile(rules.Pattern); err != nil {
+				errs.add(path+".validation.pattern", "invalid regular expression: %v", err)
+			}
+		}
+		if len(rules.Options) > 0 && field.Type != models.FieldText {
+			errs.add(path+".validation.options", "options are only supported for text fields")
+		}
+		if rules.MinLength != nil && rules.MaxLength != nil && *rules.MinLength > *rules.MaxLength {
+			errs.add(path+".validation", "min_length is greater than max_length")
+		}
+		if rules.Min != nil && rules.Max != nil && *rules.Min > *rules.Max {
+			errs.add(path+".validation", "min is greater than max")
+		}
+		if rules.MinItems != nil && rules.MaxItems != nil && *rules.MinItems > *rules.MaxItems {
+			errs.add(path+".validation", "min_items is greater than max_items")
+		}
+	}
+}
+
+// SplitContentFields separates the shared fields from the per-language fields of a schema
+func SplitContentFields(fields []models.ContentField) (shared, localized []models.ContentField) {
+	for _, field := range fields {
+		if field.Localized {
+			localized = append(localized, field)
+		} else {
+			shared = append(shared, field)
+		}
+	}
+	return shared, localized
+}
+
+// NormalizeEntryValues validates values against fields and converts them to their stored types
+// (tarih -> time.Time, referans -> ObjectID). Şemada olmayan alanlar reddedilir.
+func NormalizeEntryValues(fields []models.ContentField, values map[string]interface{}, prefix string) (map[string]interface{}, []EntryReference, ValidationErrors) {
+	var errs ValidationErrors
+	var refs []EntryReference
+	normalized := map[string]interface{}{}
+
+	known := map[string]bool{}
+	for _, field := range fields {
+		known[field.Key] = true
+	}
+	for key := range values {
+		if !known[key] {
+			errs.add(prefix+"."+key, "unknown field")
+		}
+	}
+
+	for _, field := range fields {
+		path := prefix + "." + field.Key
+		value, ok := values[field.Key]
+		if !ok || value == nil || value == "" {
+			if field.Required {
+				errs.add(path, "is required")
+			}
+			continue
+		}
+
+		result, fieldRefs, fieldErrs := normalizeFieldValue(field, value, path)
+		errs = append(errs, fieldErrs...)
+		refs = append(refs, fieldRefs...)
+		if len(fieldErrs) == 0 {
+			normalized[field.Key] = result
+		}
+	}
+	return normalized, refs, errs
+}
+
+func normalizeFieldValue(field models.ContentField, value interface{}, path string) (interface{}, []EntryReference, ValidationErrors) {
+	var errs ValidationErrors
+	rules := field.Validation
+
+	switch field.Type {
+	case models.FieldText, models.FieldRichText:
+		s, ok := value.(string)
+		if !ok {
+			errs.add(path, "must be a string")
+			return nil, nil, errs
+		}
+		length := utf8.RuneCountInString(s)
+		if rules.MinLength != nil && length < *rules.MinLength {
+			errs.add(path, "must be at least %d characters", *rules.MinLength)
+		}
+		if rules.MaxLength != nil && length > *rules.MaxLength {
+			errs.add(path, "must be at most %d characters", *rules.MaxLength)
+		}
+		if rules.Pattern != "" {
+			if re, err := regexp.Compile(rules.Pattern); err == nil && !re.MatchString(s) {
+				errs.add(path, "does not match the required pattern")
+			}
+		}
+		if len(rules.Options) > 0 && !containsValue(rules.Options, s) {
+			errs.add(path, "must be one of %s", strings.Join(rules.Options, ", "))
+		}
+		return s, nil, errs
+
+	case models.FieldNumber:
+		n, ok := toFloat(value)
+		if !ok {
+			errs.add(path, "must be a number")
+			return nil, nil, errs
+		}
+		if rules.Min != nil && n < *rules.Min {
+			errs.add(path, "must be at least %v", *rules.Min)
+		}
+		if rules.Max != nil && n > *rules.Max {
+			errs.add(path, "must be at most %v", *rules.Max)
+		}
+		return n, nil, errs
+
+	case models.FieldDate:
+		t, ok := toTime(value)
+		if !ok {
+			errs.add(path, "must be a date (YYYY-MM-DD or RFC 3339)")
+			return nil, nil, errs
+		}
+		return t, nil, errs
+
+	case models.FieldBoolean:
+		b, ok := value.(bool)
+		if !ok {
+			errs.add(path, "must be a boolean")
+			return nil, nil, errs
+		}
+		return b, nil, errs
+
+	case models.FieldMedia, models.FieldRelation:
+		var ids []primitive.ObjectID
+		var refs []EntryReference
+		items := []interface{}{value}
+		if field.Multiple {
+			list, ok := toList(value)
+			if !ok {
+				errs.add(path, "must be a list of IDs")
+				return nil, nil, errs
+			}
+			items = list
+			checkItemCount(rules, len(list), path, &errs)
+		}
+		for i, item := range items {
+			itemPath := path
+			if field.Multiple {
+				itemPath = fmt.Sprintf("%s[%d]", path, i)
+			}
+			id, ok := toObjectID(item)
+			if !ok {
+				errs.add(itemPath, "must be a valid ID")
+				continue
+			}
+			ids = append(ids, id)
+			refs = append(refs, EntryReference{Field: itemPath, Kind: field.Type, Type: field.Relation, ID: id})
+		}
+		if len(errs) > 0 {
+			return nil, nil, errs
+		}
+		if field.Multiple {
+			return ids, refs, errs
+		}
+		return ids[0], refs, errs
+
+	case models.FieldRepeater:
+		list, ok := toList(value)
+		if !ok {
+			errs.add(path, "must be a list of objects")
+			return nil, nil, errs
+		}
+		checkItemCount(rules, len(list), path, &errs)
+
+		var refs []EntryReference
+		items := make([]map[string]interface{}, 0, len(list))
+		for i, item := range list {
+			itemPath := fmt.Sprintf("%s[%d]", path, i)
+			values, ok := toMap(item)
+			if !ok {
+				errs.add(itemPath, "must be an object")
+				continue
+			}
+			normalized, itemRefs, itemErrs := NormalizeEntryValues(field.Fields, values, itemPath)
+			errs = append(errs, itemErrs...)
+			refs = append(refs, itemRefs...)
+			items = append(items, normalized)
+		}
+		return items, refs, errs
+	}
+
+	errs.add(path, "unknown field type %q", field.Type)
+	return nil, nil, errs
+}
+
+func checkItemCount(rules models.FieldValidation, count int, path string, errs *ValidationErrors) {
+	if rules.MinItems != nil && count < *rules.MinItems {
+		errs.add(path, "must contain at least %d items", *rules.MinItems)
+	}
+	if rules.MaxItems != nil && count > *rules.MaxItems {
+		errs.add(path, "must contain at most %d items", *rules.MaxItems)
+	}
+}
+
+// ParseContentFilterValue converts a query string value to the stored type of a field
+func ParseContentFilterValue(field models.ContentField, raw string) (interface{}, bool) {
+	switch field.Type {
+	case models.FieldText, models.FieldRichText:
+		return raw, true
+	case models.FieldNumber:
+		var n float64
+		if _, err := fmt.Sscan(raw, &n); err != nil {
+			return nil, false
+		}
+		return n, true
+	case models.FieldDate:
+		return toTime(raw)
+	case models.FieldBoolean:
+		switch raw {
+		case "true", "1":
+			return true, true
+		case "false", "0":
+			return false, true
+		}
+	case models.FieldMedia, models.FieldRelation:
+		return toObjectID(raw)
+	}
+	return nil, false
+}
+
+func containsValue(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	switch n := value.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	}
+	return 0, false
+}
+
+func toTime(value interface{}) (time.Time, bool) {
+	switch t := value.(type) {
+	case time.Time:
+		return t.UTC(), true
+	case primitive.DateTime:
+		return t.Time().UTC(), true
+	case string:
+		for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+			if parsed, err := time.Parse(layout, t); err == nil {
+				return parsed.UTC(), true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+func toObjectID(value interface{}) (primitive.ObjectID, bool) {
+	switch id := value.(type) {
+	case primitive.ObjectID:
+		return id, !id.IsZero()
+	case string:
+		parsed, err := primitive.ObjectIDFromHex(id)
+		return parsed, err == nil
+	}
+	return primitive.NilObjectID, false
+}
+
+func toList(value interface{}) ([]interface{}, bool) {
+	switch list := value.(type) {
+	case []interface{}:
+		return list, true
+	case primitive.A:
+		return list, true
+	}
+	return nil, false
+}
+
+func toMap(value interface{}) (map[string]interface{}, bool) {
+	switch m := value.(type) {
+	case map[string]interface{}:
+		return m, true
+	case primitive.M:
+		return m, true
+	}
+	return nil, false
+}
@@ -0,0 +1,75 @@
+package utils
+
+import (
+	"admin-panel/models"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestValidateContentType(t *testing.T) {
+	valid := models.ContentType{Key: "events", Fields: []models.ContentField{
+		{Key: "title", Type: models.FieldText, Localized: true, Required: true},
+		{Key: "speakers", Type: models.FieldRepeater, Fields: []models.ContentField{
+			{Key: "name", Type: models.FieldText},
+			{Key: "member", Type: models.FieldRelation, Relation: "team_members"},
+		}},
+	}}
+	if errs := ValidateContentType(valid); len(errs) > 0 {
+		t.Errorf("ValidateContentType failed: unexpected errors %v", errs)
+	}
+
+	invalid := models.ContentType{Key: "Events", Fields: []models.ContentField{
+		{Key: "title", Type: models.FieldText},
+		{Key: "title", Type: "color"},
+		{Key: "author", Type: models.FieldRelation},
+		{Key: "tags", Type: models.FieldRepeater},
+	}}
+	if errs := ValidateContentType(invalid); len(errs) != 5 {
+		t.Errorf("ValidateContentType failed: expected 5 errors, got %v", errs)
+	}
+}
+
+func TestNormalizeEntryValues(t *testing.T) {
+	maxLength, minItems := 10, 1
+	fields := []models.ContentField{
+		{Key: "title", Type: models.FieldText, Required: true, Validation: models.FieldValidation{MaxLength: &maxLength}},
+		{Key: "seats", Type: models.FieldNumber},
+		{Key: "starts_at", Type: models.FieldDate},
+		{Key: "online", Type: models.FieldBoolean},
+		{Key: "cover", Type: models.FieldMedia},
+		{Key: "speakers", Type: models.FieldRepeater, Validation: models.FieldValidation{MinItems: &minItems}, Fields: []models.ContentField{
+			{Key: "member", Type: models.FieldRelation, Relation: "team_members", Required: true},
+		}},
+	}
+	mediaID, memberID := primitive.NewObjectID(), primitive.NewObjectID()
+
+	values, refs, errs := NormalizeEntryValues(fields, map[string]interface{}{
+		"title":     "Go Meetup",
+		"seats":     float64(40),
+		"starts_at": "2024-05-01",
+		"online":    false,
+		"cover":     mediaID.Hex(),
+		"speakers":  []interface{}{map[string]interface{}{"member": memberID.Hex()}},
+	}, "data")
+	if len(errs) > 0 {
+		t.Fatalf("NormalizeEntryValues failed: unexpected errors %v", errs)
+	}
+	if values["starts_at"] != time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC) || values["cover"] != mediaID {
+		t.Errorf("NormalizeEntryValues failed: values not converted, got %v", values)
+	}
+	if len(refs) != 2 || refs[1].Kind != models.FieldRelation || refs[1].Type != "team_members" || refs[1].ID != memberID {
+		t.Errorf("NormalizeEntryValues failed: unexpected references %v", refs)
+	}
+
+	_, _, errs = NormalizeEntryValues(fields, map[string]interface{}{
+		"title":    "A very long title",
+		"seats":    "forty",
+		"speakers": []interface{}{},
+		"extra":    true,
+	}, "data")
+	if len(errs) != 4 {
+		t.Errorf("NormalizeEntryValues failed: expected 4 errors, got %v", errs)
+	}
+}
@@ -0,0 +1,50 @@
+package utils
+
+import (
+	"encoding/base64"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// ErrInvalidCursor is returned when a pagination cursor cannot be decoded
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Cursor represents the position after the last item of a page
+type Cursor struct {
+	Sort  string      // Sıralama anahtarı (örn: "-created_at"); farklı sıralamayla kullanılamaz
+	Value interface{} // Son öğenin sıralama alanı değeri
+	ID    interface{} // Son öğenin _id değeri (eşitlikleri ayırmak için)
+}
+
+// EncodeCursor builds an opaque, URL-safe cursor token
+func EncodeCursor(cursor Cursor) (string, error) {
+	data, err := bson.MarshalExtJSON(bson.D{
+		{Key: "s", Value: cursor.Sort},
+		{Key: "v", Value: cursor.Value},
+		{Key: "id", Value: cursor.ID},
+	}, true, false)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// DecodeCursor parses a token produced by EncodeCursor, preserving BSON types (tarih, ObjectID)
+func DecodeCursor(token string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var doc bson.M
+	if err := bson.UnmarshalExtJSON(data, true, &doc); err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	sort, ok := doc["s"].(string)
+	if !ok || doc["id"] == nil {
+		return nil, ErrInvalidCursor
+	}
+	return &Cursor{Sort: sort, Value: doc["v"], ID: doc["id"]}, nil
+}
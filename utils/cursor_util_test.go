@@ -0,0 +1,30 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	id := primitive.NewObjectID()
+	createdAt := primitive.NewDateTimeFromTime(time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC))
+
+	token, err := EncodeCursor(Cursor{Sort: "-created_at", Value: createdAt, ID: id})
+	if err != nil {
+		t.Fatalf("EncodeCursor failed: %v", err)
+	}
+
+	cursor, err := DecodeCursor(token)
+	if err != nil {
+		t.Fatalf("DecodeCursor failed: %v", err)
+	}
+	if cursor.Sort != "-created_at" || cursor.Value != createdAt || cursor.ID != id {
+		t.Errorf("DecodeCursor failed: got %+v", cursor)
+	}
+
+	if _, err := DecodeCursor("not-a-cursor"); err != ErrInvalidCursor {
+		t.Errorf("DecodeCursor should reject invalid tokens, got %v", err)
+	}
+}
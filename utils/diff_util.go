@@ -0,0 +1,90 @@
+package utils
+
+import (
+	"admin-panel/models"
+	"reflect"
+	"sort"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// FlattenDocument converts a nested document into dotted paths (örnek: localizations.en.title)
+func FlattenDocument(doc map[string]interface{}) map[string]interface{} {
+	flat := make(map[string]interface{})
+	flattenInto(flat, "", doc)
+	return flat
+}
+
+func flattenInto(flat map[string]interface{}, prefix string, value interface{}) {
+	var nested map[string]interface{}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		nested = v
+	case bson.M:
+		nested = v
+	case bson.D:
+		nested = make(map[string]interface{}, len(v))
+		for _, elem := range v {
+			nested[elem.Key] = elem.Value
+		}
+	}
+
+	if nested == nil {
+		flat[prefix] = value
+		return
+	}
+
+	for key, child := range nested {
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+		flattenInto(flat, path, child)
+	}
+}
+
+// DiffDocuments returns field-level changes between two documents, sorted by path
+func DiffDocuments(from, to map[string]interface{}) []models.FieldChange {
+	oldFields := FlattenDocument(from)
+	newFields := FlattenDocument(to)
+
+	changes := []models.FieldChange{}
+	for path, oldValue := range oldFields {
+		newValue, exists := newFields[path]
+		if !exists {
+			changes = append(changes, models.FieldChange{Path: path, Kind: "removed", From: oldValue})
+			continue
+		}
+		if !reflect.DeepEqual(oldValue, newValue) {
+			changes = append(changes, models.FieldChange{Path: path, Kind: "changed", From: oldValue, To: newValue})
+		}
+	}
+	for path, newValue := range newFields {
+		if _, exists := oldFields[path]; !exists {
+			changes = append(changes, models.FieldChange{Path: path, Kind: "added", To: newValue})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	return changes
+}
+
+// ChangedLanguages extracts the language codes touched by the given changes
+func ChangedLanguages(changes []models.FieldChange) []string {
+	seen := map[string]bool{}
+	langs := []string{}
+	for _, change := range changes {
+		parts := strings.SplitN(change.Path, ".", 3)
+		if len(parts) < 2 || (parts[0] != "localizations" && parts[0] != "meta_tags") {
+			continue
+		}
+		if !seen[parts[1]] {
+			seen[parts[1]] = true
+			langs = append(langs, parts[1])
+		}
+	}
+	sort.Strings(langs)
+	return langs
+}
@@ -0,0 +1,41 @@
+package utils
+
+import "testing"
+
+func TestDiffDocuments(t *testing.T) {
+	from := map[string]interface{}{
+		"status": "draft",
+		"localizations": map[string]interface{}{
+			"en": map[string]interface{}{"title": "Hello", "slug": "hello"},
+			"tr": map[string]interface{}{"title": "Merhaba"},
+		},
+	}
+	to := map[string]interface{}{
+		"status": "published",
+		"localizations": map[string]interface{}{
+			"en": map[string]interface{}{"title": "Hello", "slug": "hello"},
+			"de": map[string]interface{}{"title": "Hallo"},
+		},
+	}
+
+	changes := DiffDocuments(from, to)
+	expected := []struct{ path, kind string }{
+		{"localizations.de.title", "added"},
+		{"localizations.tr.title", "removed"},
+		{"status", "changed"},
+	}
+
+	if len(changes) != len(expected) {
+		t.Fatalf("DiffDocuments failed: expected %d changes, got %d (%v)", len(expected), len(changes), changes)
+	}
+	for i, e := range expected {
+		if changes[i].Path != e.path || changes[i].Kind != e.kind {
+			t.Errorf("DiffDocuments failed: expected %s/%s, got %s/%s", e.path, e.kind, changes[i].Path, changes[i].Kind)
+		}
+	}
+
+	langs := ChangedLanguages(changes)
+	if len(langs) != 2 || langs[0] != "de" || langs[1] != "tr" {
+		t.Errorf("ChangedLanguages failed: expected [de tr], got %v", langs)
+	}
+}
@@ -0,0 +1,113 @@
+package utils
+
+import (
+	"admin-panel/models"
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testFeed() *models.Feed {
+	published := time.Date(2025, 3, 1, 9, 30, 0, 0, time.UTC)
+	return &models.Feed{
+		Title:       "Blog & Haberler",
+		Description: "Son yazılar",
+		Lang:        "tr",
+		SiteURL:     "https://example.com",
+		FeedURL:     "https://example.com/feeds/tr/rss.xml",
+		Updated:     published.Add(time.Hour),
+		Items: []models.FeedItem{{
+			ID:         "tag:example.com,2025:posts/1",
+			Title:      "İlk <yazı>",
+			URL:        "https://example.com/tr/ilk-yazi",
+			Summary:    "Kısa özet",
+			Author:     "Ada Yılmaz",
+			Categories: []string{"Duyurular"},
+			Published:  published,
+			Updated:    published.Add(time.Hour),
+		}},
+	}
+}
+
+func TestRenderRSS(t *testing.T) {
+	body, err := RenderRSS(testFeed())
+	if err != nil {
+		t.Fatalf("RenderRSS failed: %v", err)
+	}
+
+	output := string(body)
+	for _, expected := range []string{
+		`<rss version="2.0" xmlns:atom="http://www.w3.org/2005/Atom" xmlns:dc="http://purl.org/dc/elements/1.1/">`,
+		`<title>Blog &amp; Haberler</title>`,
+		`<atom:link href="https://example.com/feeds/tr/rss.xml" rel="self" type="application/rss+xml"></atom:link>`,
+		`<title>İlk &lt;yazı&gt;</title>`,
+		`<guid isPermaLink="false">tag:example.com,2025:posts/1</guid>`,
+		`<dc:creator>Ada Yılmaz</dc:creator>`,
+		`<pubDate>Sat, 01 Mar 2025 09:30:00 +0000</pubDate>`,
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("RenderRSS output is missing %q:\n%s", expected, output)
+		}
+	}
+
+	var document struct {
+		Items []struct {
+			Title string `xml:"title"`
+		} `xml:"channel>item"`
+	}
+	if err := xml.Unmarshal(body, &document); err != nil || len(document.Items) != 1 || document.Items[0].Title != "İlk <yazı>" {
+		t.Errorf("RenderRSS output is not valid XML: %v %+v", err, document)
+	}
+}
+
+func TestRenderAtom(t *testing.T) {
+	body, err := RenderAtom(testFeed())
+	if err != nil {
+		t.Fatalf("RenderAtom failed: %v", err)
+	}
+
+	var document struct {
+		XMLName xml.Name `xml:"http://www.w3.org/2005/Atom feed"`
+		Updated string   `xml:"updated"`
+		Entries []struct {
+			ID        string `xml:"id"`
+			Published string `xml:"published"`
+			Author    string `xml:"author>name"`
+		} `xml:"entry"`
+	}
+	if err := xml.Unmarshal(body, &document); err != nil {
+		t.Fatalf("RenderAtom output is not valid XML: %v", err)
+	}
+	if document.Updated != "2025-03-01T10:30:00Z" || len(document.Entries) != 1 {
+		t.Fatalf("RenderAtom produced an unexpected feed: %+v", document)
+	}
+	entry := document.Entries[0]
+	if entry.ID != "tag:example.com,2025:posts/1" || entry.Published != "2025-03-01T09:30:00Z" || entry.Author != "Ada Yılmaz" {
+		t.Errorf("RenderAtom produced an unexpected entry: %+v", entry)
+	}
+}
+
+func TestRenderJSONFeed(t *testing.T) {
+	body, err := RenderJSONFeed(testFeed())
+	if err != nil {
+		t.Fatalf("RenderJSONFeed failed: %v", err)
+	}
+
+	var document map[string]interface{}
+	if err := json.Unmarshal(body, &document); err != nil {
+		t.Fatalf("RenderJSONFeed output is not valid JSON: %v", err)
+	}
+	if document["version"] != "https://jsonfeed.org/version/1.1" || document["feed_url"] != "https://example.com/feeds/tr/rss.xml" {
+		t.Errorf("RenderJSONFeed produced an unexpected feed: %v", document)
+	}
+	items, _ := document["items"].([]interface{})
+	if len(items) != 1 {
+		t.Fatalf("RenderJSONFeed expected 1 item, got %d", len(items))
+	}
+	item := items[0].(map[string]interface{})
+	if item["date_published"] != "2025-03-01T09:30:00Z" || item["url"] != "https://example.com/tr/ilk-yazi" {
+		t.Errorf("RenderJSONFeed produced an unexpected item: %v", item)
+	}
+}
@@ -0,0 +1,283 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"strings"
+)
+
+var (
+	ErrCorruptImage        = errors.New("image file structure is corrupt")
+	ErrImageMetadataFormat = errors.New("metadata cannot be removed from this image type")
+)
+
+// Meta verisi taşımayan ya da yalnızca açıkça izin verilince kabul edilen görsel tipleri olduğu gibi saklanır
+var imageTypesWithoutMetadata = map[string]bool{
+	"image/bmp":                true,
+	"image/x-icon":             true,
+	"image/vnd.microsoft.icon": true,
+	"image/svg+xml":            true, // EXIF taşımaz; betik riski nedeniyle yalnızca açıkça listelenirse yüklenir
+}
+
+// Exif yönlendirme etiketi (0x0112)
+const exifOrientationTag = 0x0112
+
+// StripImageMetadata removes EXIF, XMP, IPTC and text metadata (kamera, konum/GPS, yazılım bilgisi vb.)
+// from JPEG, PNG, WebP and GIF files without re-encoding them. JPEG'lerde görselin doğru dönmesi için
+// yalnızca yönlendirme etiketi korunur. Meta verisi temizlenemeyen görsel tiplerinde (HEIC, AVIF, TIFF vb.)
+// ErrImageMetadataFormat döner; görsel olmayan tipler olduğu gibi döner.
+func StripImageMetadata(data []byte, mimeType string) ([]byte, error) {
+	switch mimeType {
+	case "image/jpeg":
+		return stripJPEGMetadata(data)
+	case "image/png":
+		return stripPNGMetadata(data)
+	case "image/webp":
+		return stripWebPMetadata(data)
+	case "image/gif":
+		return stripGIFMetadata(data)
+	}
+	if strings.HasPrefix(mimeType, "image/") && !imageTypesWithoutMetadata[mimeType] {
+		return nil, ErrImageMetadataFormat
+	}
+	return data, nil
+}
+
+// stripJPEGMetadata drops APP1 (Exif/XMP), APP13 (IPTC/Photoshop) and comment segments before the image data
+func stripJPEGMetadata(data []byte) ([]byte, error) {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return nil, ErrCorruptImage
+	}
+	out := bytes.NewBuffer(make([]byte, 0, len(data)))
+	out.Write(data[:2])
+
+	orientationWritten := false
+	for i := 2; ; {
+		if i+4 > len(data) || data[i] != 0xFF {
+			return nil, ErrCorruptImage
+		}
+		marker := data[i+1]
+		// Dolgu baytları
+		if marker == 0xFF {
+			i++
+			continue
+		}
+		// Uzunluğu olmayan işaretler
+		if marker == 0x01 || (marker >= 0xD0 && marker <= 0xD7) {
+			out.Write(data[i : i+2])
+			i += 2
+			continue
+		}
+		length := int(binary.BigEndian.Uint16(data[i+2:]))
+		end := i + 2 + length
+		if length < 2 || end > len(data) {
+			return nil, ErrCorruptImage
+		}
+		segment := data[i:end]
+
+		switch marker {
+		case 0xDA: // SOS: sıkıştırılmış görüntü verisi dosya sonuna kadar aynen kopyalanır
+			out.Write(data[i:])
+			return out.Bytes(), nil
+		case 0xE1:
+			payload := segment[4:]
+			if !orientationWritten && bytes.HasPrefix(payload, []byte("Exif\x00\x00")) {
+				if orientation := exifOrientation(payload[6:]); orientation > 1 {
+					out.Write(orientationSegment(orientation))
+					orientationWritten = true
+				}
+			}
+		case 0xED, 0xFE:
+		default:
+			out.Write(segment)
+		}
+		i = end
+	}
+}
+
+// exifOrientation reads the orientation tag from IFD0 of a TIFF structure; bulunamazsa 0 döner
+func exifOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 0
+	}
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 0
+	}
+	offset := int(order.Uint32(tiff[4:]))
+	if offset < 8 || offset+2 > len(tiff) {
+		return 0
+	}
+	count := int(order.Uint16(tiff[offset:]))
+	for n := 0; n < count; n++ {
+		entry := offset + 2 + n*12
+		if entry+12 > len(tiff) {
+			return 0
+		}
+		if order.Uint16(tiff[entry:]) == exifOrientationTag && order.Uint16(tiff[entry+2:]) == 3 {
+			value := int(order.Uint16(tiff[entry+8:]))
+			if value >= 1 && value <= 8 {
+				return value
+			}
+			return 0
+		}
+	}
+	return 0
+}
+
+// orientationSegment builds an APP1 segment whose Exif data contains only the orientation tag
+func orientationSegment(orientation int) []byte {
+	tiff := []byte{
+		'M', 'M', 0x00, 0x2A, 0x00, 0x00, 0x00, 0x08, // Başlık, IFD0 ofseti 8
+		0x00, 0x01, // Bir kayıt
+		0x01, 0x12, 0x00, 0x03, 0x00, 0x00, 0x00, 0x01, byte(orientation >> 8), byte(orientation), 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00, // Sonraki IFD yok
+	}
+	payload := append([]byte("Exif\x00\x00"), tiff...)
+	segment := []byte{0xFF, 0xE1, 0x00, 0x00}
+	binary.BigEndian.PutUint16(segment[2:], uint16(len(payload)+2))
+	return append(segment, payload...)
+}
+
+// stripPNGMetadata drops the eXIf, text and modification time chunks
+func stripPNGMetadata(data []byte) ([]byte, error) {
+	const signature = "\x89PNG\r\n\x1a\n"
+	if !bytes.HasPrefix(data, []byte(signature)) {
+		return nil, ErrCorruptImage
+	}
+	out := bytes.NewBuffer(make([]byte, 0, len(data)))
+	out.WriteString(signature)
+
+	for i := len(signature); i < len(data); {
+		if i+12 > len(data) {
+			return nil, ErrCorruptImage
+		}
+		length := int(binary.BigEndian.Uint32(data[i:]))
+		end := i + 12 + length
+		if end > len(data) {
+			return nil, ErrCorruptImage
+		}
+		switch string(data[i+4 : i+8]) {
+		case "eXIf", "tEXt", "zTXt", "iTXt", "tIME":
+		default:
+			out.Write(data[i:end])
+		}
+		i = end
+	}
+	return out.Bytes(), nil
+}
+
+// stripWebPMetadata drops the EXIF and XMP chunks and clears their flags in the VP8X header
+func stripWebPMetadata(data []byte) ([]byte, error) {
+	if len(data) < 12 || string(data[:4]) != "RIFF" || string(data[8:12]) != "WEBP" {
+		return nil, ErrCorruptImage
+	}
+	out := make([]byte, 12, len(data))
+	copy(out, data[:12])
+
+	for i := 12; i < len(data); {
+		if i+8 > len(data) {
+			return nil, ErrCorruptImage
+		}
+		size := int(binary.LittleEndian.Uint32(data[i+4:]))
+		end := i + 8 + size + size&1 // Parçalar çift uzunluğa tamamlanır
+		if end > len(data) {
+			return nil, ErrCorruptImage
+		}
+		chunk := data[i:end]
+		switch string(chunk[:4]) {
+		case "EXIF", "XMP ":
+		case "VP8X":
+			start := len(out)
+			out = append(out, chunk...)
+			if size > 0 {
+				out[start+8] &^= 0x08 | 0x04 // EXIF ve XMP bayrakları
+			}
+		default:
+			out = append(out, chunk...)
+		}
+		i = end
+	}
+	binary.LittleEndian.PutUint32(out[4:], uint32(len(out)-8))
+	return out, nil
+}
+
+// stripGIFMetadata drops the comment extensions and the application extensions other than the animation loop (XMP vb.)
+func stripGIFMetadata(data []byte) ([]byte, error) {
+	if len(data) < 13 || (string(data[:6]) != "GIF87a" && string(data[:6]) != "GIF89a") {
+		return nil, ErrCorruptImage
+	}
+	start := 13
+	if data[10]&0x80 != 0 { // Genel renk tablosu
+		start += 3 << (data[10]&0x07 + 1)
+	}
+	if start > len(data) {
+		return nil, ErrCorruptImage
+	}
+	out := bytes.NewBuffer(make([]byte, 0, len(data)))
+	out.Write(data[:start])
+
+	for i := start; i < len(data); {
+		switch data[i] {
+		case 0x3B: // Dosya sonu
+			out.WriteByte(0x3B)
+			return out.Bytes(), nil
+		case 0x21: // Uzantı: etiket ve alt bloklar
+			if i+2 > len(data) {
+				return nil, ErrCorruptImage
+			}
+			end, err := gifSubBlocksEnd(data, i+2)
+			if err != nil {
+				return nil, err
+			}
+			keep := true
+			switch data[i+1] {
+			case 0xFE: // Yorum
+				keep = false
+			case 0xFF: // Uygulama; yalnızca döngü bilgisi korunur
+				identifier := data[i+3 : min(i+14, end)]
+				keep = bytes.HasPrefix(identifier, []byte("NETSCAPE2.0")) || bytes.HasPrefix(identifier, []byte("ANIMEXTS1.0"))
+			}
+			if keep {
+				out.Write(data[i:end])
+			}
+			i = end
+		case 0x2C: // Görüntü tanımlayıcı, yerel renk tablosu ve LZW verisi
+			if i+11 > len(data) {
+				return nil, ErrCorruptImage
+			}
+			next := i + 10
+			if data[i+9]&0x80 != 0 {
+				next += 3 << (data[i+9]&0x07 + 1)
+			}
+			end, err := gifSubBlocksEnd(data, next+1)
+			if err != nil {
+				return nil, err
+			}
+			out.Write(data[i:end])
+			i = end
+		default:
+			return nil, ErrCorruptImage
+		}
+	}
+	return nil, ErrCorruptImage
+}
+
+// gifSubBlocksEnd returns the position after the sub-block chain starting at i (0 uzunluklu blokla biter)
+func gifSubBlocksEnd(data []byte, i int) (int, error) {
+	for i < len(data) {
+		size := int(data[i])
+		i++
+		if size == 0 {
+			return i, nil
+		}
+		i += size
+	}
+	return 0, ErrCorruptImage
+}
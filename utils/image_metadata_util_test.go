@@ -0,0 +1,203 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"testing"
+)
+
+func testMetadataImage() image.Image {
+	img := image.NewNRGBA(image.Rect(0, 0, 8, 4))
+	for x := 0; x < 8; x++ {
+		img.Set(x, 1, color.NRGBA{R: 255, A: 255})
+	}
+	return img
+}
+
+func TestStripJPEGMetadata(t *testing.T) {
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, testMetadataImage(), nil); err != nil {
+		t.Fatal(err)
+	}
+	original := buf.Bytes()
+
+	// Küçük sıralı (II) Exif: yönlendirme 6 ve kamera bilgisi yerine geçen bir etiket
+	tiff := []byte{'I', 'I', 0x2A, 0x00, 0x08, 0x00, 0x00, 0x00, 0x02, 0x00,
+		0x12, 0x01, 0x03, 0x00, 0x01, 0x00, 0x00, 0x00, 0x06, 0x00, 0x00, 0x00,
+		0x25, 0x88, 0x04, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00}
+	exif := append([]byte("Exif\x00\x00"), tiff...)
+	exif = append(exif, []byte("GPS 41.0082N 28.9784E")...)
+	segment := func(marker byte, payload []byte) []byte {
+		s := []byte{0xFF, marker, 0, 0}
+		binary.BigEndian.PutUint16(s[2:], uint16(len(payload)+2))
+		return append(s, payload...)
+	}
+
+	var input []byte
+	input = append(input, original[:2]...)
+	input = append(input, segment(0xE1, exif)...)
+	input = append(input, segment(0xFE, []byte("Camera XYZ"))...)
+	input = append(input, original[2:]...)
+
+	stripped, err := StripImageMetadata(input, "image/jpeg")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if bytes.Contains(stripped, []byte("GPS")) || bytes.Contains(stripped, []byte("Camera")) {
+		t.Error("expected metadata to be removed")
+	}
+	if !bytes.Contains(stripped, orientationSegment(6)) {
+		t.Error("expected the orientation to be kept")
+	}
+	if _, err := jpeg.Decode(bytes.NewReader(stripped)); err != nil {
+		t.Errorf("stripped file does not decode: %v", err)
+	}
+
+	// Meta verisi olmayan dosya değişmez
+	if same, _ := StripImageMetadata(original, "image/jpeg"); !bytes.Equal(same, original) {
+		t.Error("expected a file without metadata to stay the same")
+	}
+	if _, err := StripImageMetadata(original[:len(original)/2][:20], "image/jpeg"); err == nil {
+		t.Error("expected an error for a truncated file")
+	}
+}
+
+func TestStripPNGMetadata(t *testing.T) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, testMetadataImage()); err != nil {
+		t.Fatal(err)
+	}
+	original := buf.Bytes()
+
+	text := []byte("Author\x00Jane")
+	chunk := make([]byte, 8, 12+len(text))
+	binary.BigEndian.PutUint32(chunk, uint32(len(text)))
+	copy(chunk[4:], "tEXt")
+	chunk = append(append(chunk, text...), 0, 0, 0, 0)
+
+	// IHDR'den (8 + 25 bayt) sonra eklenir
+	input := append(append(append([]byte{}, original[:33]...), chunk...), original[33:]...)
+	stripped, err := StripImageMetadata(input, "image/png")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !bytes.Equal(stripped, original) {
+		t.Error("expected the tEXt chunk to be removed")
+	}
+	if _, err := StripImageMetadata([]byte("not a png"), "image/png"); err == nil {
+		t.Error("expected an error for an invalid file")
+	}
+}
+
+func TestStripWebPMetadata(t *testing.T) {
+	var buf bytes.Buffer
+	if err := EncodeImage(&buf, testMetadataImage(), "webp", 0); err != nil {
+		t.Fatal(err)
+	}
+	simple := buf.Bytes()
+
+	chunk := func(fourCC string, payload []byte) []byte {
+		c := make([]byte, 8, 9+len(payload))
+		copy(c, fourCC)
+		binary.LittleEndian.PutUint32(c[4:], uint32(len(payload)))
+		c = append(c, payload...)
+		if len(payload)%2 == 1 {
+			c = append(c, 0)
+		}
+		return c
+	}
+	// Genişletilmiş biçim: VP8X (EXIF ve XMP bayrakları açık), görüntü, EXIF, XMP
+	vp8x := []byte{0x0C, 0, 0, 0, 7, 0, 0, 3, 0, 0}
+	body := []byte("WEBP")
+	body = append(body, chunk("VP8X", vp8x)...)
+	body = append(body, simple[12:]...)
+	body = append(body, chunk("EXIF", []byte("Exif GPS"))...)
+	body = append(body, chunk("XMP ", []byte("<x:xmpmeta/>"))...)
+	input := append([]byte("RIFF\x00\x00\x00\x00"), body...)
+	binary.LittleEndian.PutUint32(input[4:], uint32(len(body)))
+
+	stripped, err := StripImageMetadata(input, "image/webp")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if bytes.Contains(stripped, []byte("GPS")) || bytes.Contains(stripped, []byte("xmpmeta")) {
+		t.Error("expected metadata chunks to be removed")
+	}
+	if stripped[20] != 0 {
+		t.Errorf("expected the VP8X flags to be cleared, got %#x", stripped[20])
+	}
+	if size := binary.LittleEndian.Uint32(stripped[4:]); int(size) != len(stripped)-8 {
+		t.Errorf("unexpected RIFF size %d for %d bytes", size, len(stripped))
+	}
+	if config, format, err := image.DecodeConfig(bytes.NewReader(stripped)); err != nil || format != "webp" || config.Width != 8 {
+		t.Errorf("stripped file does not decode: %s %v", format, err)
+	}
+}
+
+func TestStripGIFMetadata(t *testing.T) {
+	palette := color.Palette{color.Black, color.White}
+	frame := image.NewPaletted(image.Rect(0, 0, 4, 4), palette)
+	var buf bytes.Buffer
+	if err := gif.EncodeAll(&buf, &gif.GIF{Image: []*image.Paletted{frame, frame}, Delay: []int{10, 10}, LoopCount: 0}); err != nil {
+		t.Fatal(err)
+	}
+	original := buf.Bytes()
+	start := 13
+	if original[10]&0x80 != 0 {
+		start += 3 << (original[10]&0x07 + 1)
+	}
+
+	comment := append([]byte{0x21, 0xFE, 10}, []byte("Camera XYZ")...)
+	comment = append(comment, 0)
+	xmp := append([]byte{0x21, 0xFF, 11}, []byte("XMP DataXMP")...)
+	xmp = append(xmp, 9)
+	xmp = append(xmp, []byte("GPS 41.0N")...)
+	xmp = append(xmp, 0)
+
+	var input []byte
+	input = append(input, original[:start]...)
+	input = append(input, comment...)
+	input = append(input, xmp...)
+	input = append(input, original[start:]...)
+
+	stripped, err := StripImageMetadata(input, "image/gif")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if bytes.Contains(stripped, []byte("Camera")) || bytes.Contains(stripped, []byte("GPS")) {
+		t.Error("expected metadata to be removed")
+	}
+	if !bytes.Contains(stripped, []byte("NETSCAPE2.0")) {
+		t.Error("expected the animation loop to be kept")
+	}
+	decoded, err := gif.DecodeAll(bytes.NewReader(stripped))
+	if err != nil || len(decoded.Image) != 2 {
+		t.Errorf("stripped file does not decode: %v", err)
+	}
+	if _, err := StripImageMetadata(input[:len(input)-5], "image/gif"); err == nil {
+		t.Error("expected a truncated file to be rejected")
+	}
+}
+
+func TestStripImageMetadataOtherTypes(t *testing.T) {
+	data := []byte("%PDF-1.7")
+	if out, err := StripImageMetadata(data, "application/pdf"); err != nil || !bytes.Equal(out, data) {
+		t.Error("expected other types to be returned unchanged")
+	}
+	svg := []byte("<svg/>")
+	if out, err := StripImageMetadata(svg, "image/svg+xml"); err != nil || !bytes.Equal(out, svg) {
+		t.Error("expected SVG to be returned unchanged")
+	}
+	for _, mimeType := range []string{"image/heic", "image/avif", "image/tiff"} {
+		if _, err := StripImageMetadata([]byte("data"), mimeType); !errors.Is(err, ErrImageMetadataFormat) {
+			t.Errorf("expected %v for %s, got %v", ErrImageMetadataFormat, mimeType, err)
+		}
+	}
+}
@@ -0,0 +1,274 @@
+package utils
+
+import (
+	"admin-panel/models"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/gif" // GIF çözümleyici
+	"image/jpeg"
+	"image/png"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/HugoSmits86/nativewebp"
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp" // WebP çözümleyici
+)
+
+// Görsel işleme sınırları
+const (
+	MaxImageTransformSize = 4000             // İsteğe bağlı boyutlandırmada azami genişlik/yükseklik
+	MaxImagePixels        = 50 * 1000 * 1000 // Bundan büyük görseller işlenmez (sıkıştırma bombalarına karşı)
+	DefaultImageQuality   = 85               // JPEG kalitesi
+)
+
+var ErrInvalidImageTransform = errors.New("invalid image transform")
+
+// ImageOutputFormat returns the format a decoded image is written in: jpg -> jpeg, gif -> png (ilk kare)
+func ImageOutputFormat(format string) string {
+	switch strings.ToLower(format) {
+	case "jpeg", "jpg":
+		return "jpeg"
+	case "webp":
+		return "webp"
+	default:
+		return "png"
+	}
+}
+
+// ImageExtension returns the file extension of an output format
+func ImageExtension(format string) string {
+	if format == "jpeg" {
+		return ".jpg"
+	}
+	return "." + format
+}
+
+// ImageContentType returns the MIME type of an output format
+func ImageContentType(format string) string {
+	return "image/" + format
+}
+
+// ParseImageTransform parses a parameter set such as "w_800,h_600,fit_cover,f_webp,q_80".
+// Sonuç kanonik hale getirilir (jpg -> jpeg, varsayılan fit_contain atılır).
+func ParseImageTransform(value string) (models.ImageTransform, error) {
+	var transform models.ImageTransform
+	seen := map[string]bool{}
+	for _, part := range strings.Split(value, ",") {
+		key, arg, ok := strings.Cut(strings.TrimSpace(part), "_")
+		if !ok || arg == "" {
+			return transform, fmt.Errorf("%w: %q", ErrInvalidImageTransform, part)
+		}
+		if seen[key] {
+			return transform, fmt.Errorf("%w: %s is given more than once", ErrInvalidImageTransform, key)
+		}
+		seen[key] = true
+
+		switch key {
+		case "w", "h", "q":
+			n, err := strconv.Atoi(arg)
+			limit := MaxImageTransformSize
+			if key == "q" {
+				limit = 100
+			}
+			if err != nil || n < 1 || n > limit {
+				return transform, fmt.Errorf("%w: %s must be between 1 and %d", ErrInvalidImageTransform, key, limit)
+			}
+			switch key {
+			case "w":
+				transform.Width = n
+			case "h":
+				transform.Height = n
+			default:
+				transform.Quality = n
+			}
+		case "fit":
+			switch arg {
+			case models.ImageFitContain:
+			case models.ImageFitCover, models.ImageFitFill:
+				transform.Fit = arg
+			default:
+				return transform, fmt.Errorf("%w: fit must be contain, cover or fill", ErrInvalidImageTransform)
+			}
+		case "f":
+			switch arg {
+			case "jpeg", "jpg", "png", "webp":
+				transform.Format = ImageOutputFormat(arg)
+			default:
+				return transform, fmt.Errorf("%w: format must be jpeg, png or webp", ErrInvalidImageTransform)
+			}
+		default:
+			return transform, fmt.Errorf("%w: unknown parameter %q", ErrInvalidImageTransform, key)
+		}
+	}
+
+	if transform.Width == 0 && transform.Height == 0 {
+		return transform, fmt.Errorf("%w: w or h is required", ErrInvalidImageTransform)
+	}
+	return transform, nil
+}
+
+// ParseImageRenditions parses rendition definitions such as "thumbnail:150x150:cover,medium:800x0,large:1600x0".
+// 0 olan boyut orantılı hesaplanır; fit belirtilmezse contain kullanılır.
+func ParseImageRenditions(value string) ([]models.ImageRenditionSpec, error) {
+	var specs []models.ImageRenditionSpec
+	names := map[string]bool{}
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		parts := strings.Split(item, ":")
+		if len(parts) < 2 || len(parts) > 3 || parts[0] == "" || names[parts[0]] {
+			return nil, fmt.Errorf("invalid rendition %q", item)
+		}
+		width, height, ok := strings.Cut(parts[1], "x")
+		w, errW := strconv.Atoi(width)
+		h, errH := strconv.Atoi(height)
+		if !ok || errW != nil || errH != nil || w < 0 || h < 0 || w+h == 0 || w > MaxImageTransformSize || h > MaxImageTransformSize {
+			return nil, fmt.Errorf("invalid rendition size %q", item)
+		}
+		spec := models.ImageRenditionSpec{Name: parts[0], Width: w, Height: h, Fit: models.ImageFitContain}
+		if len(parts) == 3 {
+			switch parts[2] {
+			case models.ImageFitContain, models.ImageFitCover, models.ImageFitFill:
+				spec.Fit = parts[2]
+			default:
+				return nil, fmt.Errorf("invalid rendition fit %q", item)
+			}
+		}
+		names[spec.Name] = true
+		specs = append(specs, spec)
+	}
+	return specs, nil
+}
+
+// FitImageSize calculates the output size of a resize and the area of the source that is used.
+// Görseller büyütülmez; kutudan küçük kaynaklarda kutu oranı korunarak küçültülür.
+func FitImageSize(srcWidth, srcHeight, width, height int, fit string) (int, int, image.Rectangle) {
+	source := image.Rect(0, 0, srcWidth, srcHeight)
+	if srcWidth <= 0 || srcHeight <= 0 {
+		return 0, 0, source
+	}
+	scaled := func(value, num, den int) int {
+		return max(1, int(float64(value)*float64(num)/float64(den)+0.5))
+	}
+
+	switch {
+	case width == 0 && height == 0:
+		return srcWidth, srcHeight, source
+	case height == 0:
+		width = min(width, srcWidth)
+		return width, scaled(srcHeight, width, srcWidth), source
+	case width == 0:
+		height = min(height, srcHeight)
+		return scaled(srcWidth, height, srcHeight), height, source
+	}
+
+	switch fit {
+	case models.ImageFitFill:
+		return width, height, source
+	case models.ImageFitCover:
+		// Kaynak kutudan küçükse kutu aynı oranda küçültülür
+		if width > srcWidth || height > srcHeight {
+			ratio := min(float64(srcWidth)/float64(width), float64(srcHeight)/float64(height))
+			width = max(1, int(float64(width)*ratio))
+			height = max(1, int(float64(height)*ratio))
+		}
+		// Kutu oranındaki en büyük alan ortadan kırpılır
+		cropWidth, cropHeight := srcWidth, scaled(srcWidth, height, width)
+		if cropHeight > srcHeight {
+			cropWidth, cropHeight = scaled(srcHeight, width, height), srcHeight
+		}
+		x, y := (srcWidth-cropWidth)/2, (srcHeight-cropHeight)/2
+		return width, height, image.Rect(x, y, x+cropWidth, y+cropHeight)
+	default:
+		ratio := min(float64(width)/float64(srcWidth), float64(height)/float64(srcHeight), 1)
+		return max(1, int(float64(srcWidth)*ratio+0.5)), max(1, int(float64(srcHeight)*ratio+0.5)), source
+	}
+}
+
+// ResizeImage scales an image to a box with the given fit (contain, cover, fill)
+func ResizeImage(img image.Image, width, height int, fit string) image.Image {
+	bounds := img.Bounds()
+	dstWidth, dstHeight, crop := FitImageSize(bounds.Dx(), bounds.Dy(), width, height, fit)
+	crop = crop.Add(bounds.Min)
+	if dstWidth == bounds.Dx() && dstHeight == bounds.Dy() && crop == bounds {
+		return img
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dstWidth, dstHeight))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, crop, draw.Src, nil)
+	return dst
+}
+
+// DominantColor returns the most common colour of an image as "#rrggbb".
+// Görsel küçültülür, renkler kovalara ayrılır ve en kalabalık kovanın ortalaması alınır; saydam pikseller sayılmaz.
+func DominantColor(img image.Image) string {
+	sample := ResizeImage(img, 64, 64, models.ImageFitContain)
+	type bucket struct {
+		count   int
+		r, g, b int
+	}
+	buckets := map[int]*bucket{}
+	var best *bucket
+	bounds := sample.Bounds()
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			c := color.NRGBAModel.Convert(sample.At(x, y)).(color.NRGBA)
+			if c.A < 128 {
+				continue
+			}
+			key := int(c.R>>4)<<8 | int(c.G>>4)<<4 | int(c.B>>4)
+			item := buckets[key]
+			if item == nil {
+				item = &bucket{}
+				buckets[key] = item
+			}
+			item.count++
+			item.r += int(c.R)
+			item.g += int(c.G)
+			item.b += int(c.B)
+			if best == nil || item.count > best.count {
+				best = item
+			}
+		}
+	}
+	if best == nil {
+		return ""
+	}
+	return fmt.Sprintf("#%02x%02x%02x", best.r/best.count, best.g/best.count, best.b/best.count)
+}
+
+// EncodeImage writes an image as jpeg, png or webp (kayıpsız). JPEG'de saydam alanlar beyaz zemine yerleştirilir.
+func EncodeImage(w io.Writer, img image.Image, format string, quality int) error {
+	switch format {
+	case "jpeg":
+		if quality <= 0 {
+			quality = DefaultImageQuality
+		}
+		if !isOpaque(img) {
+			flat := image.NewRGBA(img.Bounds())
+			draw.Draw(flat, flat.Bounds(), image.White, image.Point{}, draw.Src)
+			draw.Draw(flat, flat.Bounds(), img, img.Bounds().Min, draw.Over)
+			img = flat
+		}
+		return jpeg.Encode(w, img, &jpeg.Options{Quality: quality})
+	case "png":
+		return png.Encode(w, img)
+	case "webp":
+		return nativewebp.Encode(w, img, nil)
+	default:
+		return fmt.Errorf("unsupported image format %q", format)
+	}
+}
+
+func isOpaque(img image.Image) bool {
+	if opaque, ok := img.(interface{ Opaque() bool }); ok {
+		return opaque.Opaque()
+	}
+	return false
+}
@@ -0,0 +1,107 @@
+package utils
+
+import (
+	"admin-panel/models"
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"strings"
+	"testing"
+)
+
+func TestParseImageTransform(t *testing.T) {
+	transform, err := ParseImageTransform("fit_cover,h_600,w_800,f_jpg")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := transform.String(); got != "w_800,h_600,fit_cover,f_jpeg" {
+		t.Errorf("unexpected canonical form: %s", got)
+	}
+	if transform, _ := ParseImageTransform("w_300,fit_contain"); transform.String() != "w_300" {
+		t.Errorf("expected the default fit to be dropped, got %s", transform.String())
+	}
+
+	for _, value := range []string{"", "fit_cover", "w_0", "w_5000", "w_10,w_20", "q_101", "fit_stretch", "f_gif", "x_1", "w800"} {
+		if _, err := ParseImageTransform(value); !errors.Is(err, ErrInvalidImageTransform) {
+			t.Errorf("%q: expected ErrInvalidImageTransform, got %v", value, err)
+		}
+	}
+}
+
+func TestParseImageRenditions(t *testing.T) {
+	specs, err := ParseImageRenditions("thumbnail:150x150:cover, medium:800x0")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []models.ImageRenditionSpec{
+		{Name: "thumbnail", Width: 150, Height: 150, Fit: models.ImageFitCover},
+		{Name: "medium", Width: 800, Fit: models.ImageFitContain},
+	}
+	if len(specs) != len(expected) || specs[0] != expected[0] || specs[1] != expected[1] {
+		t.Errorf("unexpected specs: %+v", specs)
+	}
+
+	for _, value := range []string{"a:0x0", "a:10", "a:10x10:zoom", "a:10x10,a:20x20", ":10x10"} {
+		if _, err := ParseImageRenditions(value); err == nil {
+			t.Errorf("%q: expected an error", value)
+		}
+	}
+}
+
+func TestFitImageSize(t *testing.T) {
+	cases := []struct {
+		name                string
+		width, height       int
+		fit                 string
+		dstWidth, dstHeight int
+		crop                image.Rectangle
+	}{
+		{"width only", 800, 0, "", 800, 450, image.Rect(0, 0, 1600, 900)},
+		{"no upscale", 3200, 0, "", 1600, 900, image.Rect(0, 0, 1600, 900)},
+		{"contain", 400, 400, models.ImageFitContain, 400, 225, image.Rect(0, 0, 1600, 900)},
+		{"cover", 300, 300, models.ImageFitCover, 300, 300, image.Rect(350, 0, 1250, 900)},
+		{"cover small source", 1800, 1200, models.ImageFitCover, 1350, 900, image.Rect(125, 0, 1475, 900)},
+		{"fill", 100, 100, models.ImageFitFill, 100, 100, image.Rect(0, 0, 1600, 900)},
+	}
+	for _, tc := range cases {
+		w, h, crop := FitImageSize(1600, 900, tc.width, tc.height, tc.fit)
+		if w != tc.dstWidth || h != tc.dstHeight || crop != tc.crop {
+			t.Errorf("%s: got %dx%d %v", tc.name, w, h, crop)
+		}
+	}
+}
+
+func TestResizeAndEncodeImage(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 200, 100))
+	for y := 0; y < 100; y++ {
+		for x := 0; x < 200; x++ {
+			c := color.NRGBA{R: 200, G: 30, B: 30, A: 255}
+			if x < 40 {
+				c = color.NRGBA{R: 20, G: 40, B: 220, A: 255}
+			}
+			img.Set(x, y, c)
+		}
+	}
+
+	// Küçültme kenarlardaki pikselleri karıştırdığından yalnızca kırmızı kova beklenir
+	if got := DominantColor(img); !strings.HasPrefix(got, "#c81e") {
+		t.Errorf("unexpected dominant colour: %s", got)
+	}
+
+	resized := ResizeImage(img, 50, 50, models.ImageFitCover)
+	if resized.Bounds().Dx() != 50 || resized.Bounds().Dy() != 50 {
+		t.Fatalf("unexpected size: %v", resized.Bounds())
+	}
+
+	for _, format := range []string{"jpeg", "png", "webp"} {
+		var buf bytes.Buffer
+		if err := EncodeImage(&buf, resized, format, 0); err != nil {
+			t.Fatalf("%s: %v", format, err)
+		}
+		config, decoded, err := image.DecodeConfig(&buf)
+		if err != nil || decoded != format || config.Width != 50 || config.Height != 50 {
+			t.Errorf("%s: decoded %s %dx%d (%v)", format, decoded, config.Width, config.Height, err)
+		}
+	}
+}
@@ -0,0 +1,747 @@
+package utils
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var (
+	mdATXHeading   = regexp.MustCompile(`^(#{1,6})(?:[ \t]+(.*?))?(?:[ \t]+#+)?[ \t]*$`)
+	mdSetextH1     = regexp.MustCompile(`^=+[ \t]*$`)
+	mdSetextH2     = regexp.MustCompile(`^-+[ \t]*$`)
+	mdRule         = regexp.MustCompile(`^(?:(?:\*[ \t]*){3,}|(?:-[ \t]*){3,}|(?:_[ \t]*){3,})$`)
+	mdFence        = regexp.MustCompile("^(`{3,}|~{3,})[ \t]*([^`]*)$")
+	mdListItem     = regexp.MustCompile(`^( {0,3})([-*+]|(\d{1,9})[.)])( +|$)(.*)$`)
+	mdTableDivider = regexp.MustCompile(`^\|?[ \t]*:?-+:?[ \t]*(\|[ \t]*:?-+:?[ \t]*)*\|?[ \t]*$`)
+	mdHTMLBlock    = regexp.MustCompile(`^</?(?i:address|article|aside|blockquote|details|dialog|div|dl|figure|figcaption|footer|h[1-6]|header|hr|iframe|nav|ol|p|pre|section|summary|table|ul)(?:\s|/?>|$)`)
+	mdInlineTag    = regexp.MustCompile(`^(?:<!--[\s\S]*?-->|</?[A-Za-z][A-Za-z0-9-]*(?:\s+[A-Za-z_:][\w:.-]*(?:\s*=\s*(?:"[^"]*"|'[^']*'|[^\s"'=<>` + "`" + `]+))?)*\s*/?>)`)
+	mdAutolink     = regexp.MustCompile(`^<([A-Za-z][A-Za-z0-9+.-]{1,31}:[^\s<>]*)>`)
+	mdEmailLink    = regexp.MustCompile(`^<([^\s@<>]+@[^\s@<>]+\.[^\s@<>]+)>`)
+	mdEntity       = regexp.MustCompile(`^&(?:#[0-9]{1,7}|#[xX][0-9a-fA-F]{1,6}|[A-Za-z][A-Za-z0-9]{1,31});`)
+)
+
+// RenderMarkdown renders Markdown (CommonMark'ın yaygın alt kümesi, GFM tabloları ve üstü çizili metin) to HTML.
+// Başlıklar bağlantı verilebilsin diye tekil id alır. Ham HTML aynen geçer; çıktı kaydedilmeden önce temizlenmelidir.
+func RenderMarkdown(source string) string {
+	source = strings.ReplaceAll(source, "\r\n", "\n")
+	source = strings.ReplaceAll(source, "\t", "    ")
+
+	r := &markdownRenderer{anchors: map[string]int{}}
+	var b strings.Builder
+	r.blocks(&b, strings.Split(source, "\n"), false)
+	return strings.TrimSpace(b.String())
+}
+
+type markdownRenderer struct {
+	anchors map[string]int // Başlık id'si -> kullanım sayısı
+}
+
+// blocks renders block level Markdown; tight listelerde paragraflar <p> olmadan yazılır
+func (r *markdownRenderer) blocks(b *strings.Builder, lines []string, tight bool) {
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+		indent := leadingSpaces(line)
+
+		switch {
+		case trimmed == "":
+			i++
+		case indent >= 4:
+			i = r.indentedCode(b, lines, i)
+		case mdFence.MatchString(trimmed):
+			i = r.fencedCode(b, lines, i)
+		case mdATXHeading.MatchString(trimmed):
+			m := mdATXHeading.FindStringSubmatch(trimmed)
+			r.heading(b, len(m[1]), m[2])
+			i++
+		case mdRule.MatchString(trimmed):
+			b.WriteString("<hr>\n")
+			i++
+		case strings.HasPrefix(trimmed, ">"):
+			i = r.blockquote(b, lines, i)
+		case mdListItem.MatchString(line):
+			i = r.list(b, lines, i)
+		case i+1 < len(lines) && isTableStart(line, lines[i+1]):
+			i = r.table(b, lines, i)
+		case mdHTMLBlock.MatchString(trimmed):
+			// Ham HTML bloğu boş satıra kadar aynen yazılır
+			for ; i < len(lines) && strings.TrimSpace(lines[i]) != ""; i++ {
+				b.WriteString(lines[i] + "\n")
+			}
+		default:
+			i = r.paragraph(b, lines, i, tight)
+		}
+	}
+}
+
+func (r *markdownRenderer) paragraph(b *strings.Builder, lines []string, i int, tight bool) int {
+	var text []string
+	for ; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			break
+		}
+		// Paragrafın altındaki === / --- satırı başlık yapar
+		if len(text) > 0 && leadingSpaces(line) < 4 && (mdSetextH1.MatchString(trimmed) || mdSetextH2.MatchString(trimmed)) {
+			level := 2
+			if trimmed[0] == '=' {
+				level = 1
+			}
+			r.heading(b, level, strings.Join(text, "\n"))
+			return i + 1
+		}
+		if len(text) > 0 && interruptsParagraph(line) {
+			break
+		}
+		text = append(text, strings.TrimLeft(line, " "))
+	}
+
+	content := r.inline(strings.TrimRight(strings.Join(text, "\n"), " "))
+	if tight {
+		b.WriteString(content + "\n")
+	} else {
+		b.WriteString("<p>" + content + "</p>\n")
+	}
+	return i
+}
+
+// interruptsParagraph reports whether line starts a block that ends a running paragraph
+func interruptsParagraph(line string) bool {
+	if leadingSpaces(line) >= 4 {
+		return false
+	}
+	trimmed := strings.TrimSpace(line)
+	if mdFence.MatchString(trimmed) || mdATXHeading.MatchString(trimmed) || mdRule.MatchString(trimmed) ||
+		strings.HasPrefix(trimmed, ">") || mdHTMLBlock.MatchString(trimmed) {
+		return true
+	}
+	// Paragrafı yalnızca madde işaretli veya 1 ile başlayan, boş olmayan liste öğeleri keser
+	if m := mdListItem.FindStringSubmatch(line); m != nil && strings.TrimSpace(m[5]) != "" {
+		return m[3] == "" || m[3] == "1"
+	}
+	return false
+}
+
+func (r *markdownRenderer) heading(b *strings.Builder, level int, text string) {
+	content := r.inline(strings.TrimSpace(text))
+	fmt.Fprintf(b, "<h%d id=\"%s\">%s</h%d>\n", level, r.anchor(StripHTML(content)), content, level)
+}
+
+// anchor returns a unique heading id derived from the heading text
+func (r *markdownRenderer) anchor(text string) string {
+	id := GenerateSlug(text)
+	if id == "default-slug" {
+		id = "section"
+	}
+	r.anchors[id]++
+	if n := r.anchors[id]; n > 1 {
+		return fmt.Sprintf("%s-%d", id, n)
+	}
+	return id
+}
+
+func (r *markdownRenderer) fencedCode(b *strings.Builder, lines []string, i int) int {
+	indent := leadingSpaces(lines[i])
+	m := mdFence.FindStringSubmatch(strings.TrimSpace(lines[i]))
+	fence, info := m[1], strings.Fields(m[2])
+
+	var code []string
+	for i++; i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i])
+		if strings.HasPrefix(trimmed, fence[:1]) && strings.Trim(trimmed, fence[:1]) == "" && len(trimmed) >= len(fence) {
+			i++
+			break
+		}
+		// Açılış çitinin girintisi içerikten de düşülür
+		line := lines[i]
+		if n := leadingSpaces(line); n > 0 {
+			line = line[min(n, indent):]
+		}
+		code = append(code, line)
+	}
+
+	language := ""
+	if len(info) > 0 {
+		language = info[0]
+	}
+	writeCode(b, strings.Join(code, "\n"), language)
+	return i
+}
+
+func (r *markdownRenderer) indentedCode(b *strings.Builder, lines []string, i int) int {
+	var code []string
+	for ; i < len(lines); i++ {
+		line := lines[i]
+		if strings.TrimSpace(line) == "" {
+			code = append(code, "")
+			continue
+		}
+		if leadingSpaces(line) < 4 {
+			break
+		}
+		code = append(code, line[4:])
+	}
+	writeCode(b, strings.TrimRight(strings.Join(code, "\n"), "\n"), "")
+	return i
+}
+
+func writeCode(b *strings.Builder, code, language string) {
+	b.WriteString("<pre><code")
+	if language != "" {
+		b.WriteString(` class="language-` + html.EscapeString(language) + `"`)
+	}
+	b.WriteString(">" + html.EscapeString(code))
+	if code != "" {
+		b.WriteString("\n")
+	}
+	b.WriteString("</code></pre>\n")
+}
+
+func (r *markdownRenderer) blockquote(b *strings.Builder, lines []string, i int) int {
+	var quoted []string
+	for ; i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i])
+		if !strings.HasPrefix(trimmed, ">") {
+			// Tembel devam satırı: alıntıdaki paragraf sürüyor
+			if trimmed == "" || len(quoted) == 0 || strings.TrimSpace(quoted[len(quoted)-1]) == "" || interruptsParagraph(lines[i]) {
+				break
+			}
+			quoted = append(quoted, trimmed)
+			continue
+		}
+		rest := strings.TrimPrefix(strings.TrimLeft(lines[i], " "), ">")
+		quoted = append(quoted, strings.TrimPrefix(rest, " "))
+	}
+
+	b.WriteString("<blockquote>\n")
+	r.blocks(b, quoted, false)
+	b.WriteString("</blockquote>\n")
+	return i
+}
+
+func (r *markdownRenderer) list(b *strings.Builder, lines []string, i int) int {
+	first := mdListItem.FindStringSubmatch(lines[i])
+	ordered := first[3] != ""
+	marker := first[2][len(first[2])-1:] // -, *, + veya . / )
+
+	var items [][]string
+	loose := false
+	contentIndent := 0
+
+	for i < len(lines) {
+		line := lines[i]
+		if m := mdListItem.FindStringSubmatch(line); m != nil && (len(items) == 0 || leadingSpaces(line) < contentIndent) {
+			if (m[3] != "") != ordered || m[2][len(m[2])-1:] != marker {
+				break // Farklı işaretli öğe yeni bir liste başlatır
+			}
+			padding := len(m[4])
+			if padding > 4 || m[5] == "" {
+				padding = 1 // İçerik kod bloğu gibi girintili başlıyor
+			}
+			contentIndent = len(m[1]) + len(m[2]) + padding
+			items = append(items, []string{strings.Repeat(" ", max(len(m[4])-padding, 0)) + m[5]})
+			i++
+			continue
+		}
+
+		item := &items[len(items)-1]
+		if strings.TrimSpace(line) == "" {
+			// Boş satırdan sonra liste, girintili içerik veya yeni öğeyle sürer
+			next := i + 1
+			for next < len(lines) && strings.TrimSpace(lines[next]) == "" {
+				next++
+			}
+			if next == len(lines) {
+				break
+			}
+			nextLine := lines[next]
+			if leadingSpaces(nextLine) >= contentIndent {
+				*item = append(*item, "")
+				loose = true
+				i = next
+				continue
+			}
+			if m := mdListItem.FindStringSubmatch(nextLine); m != nil && (m[3] != "") == ordered && m[2][len(m[2])-1:] == marker {
+				loose = true
+				i = next
+				continue
+			}
+			break
+		}
+		if leadingSpaces(line) >= contentIndent {
+			*item = append(*item, line[contentIndent:])
+			i++
+			continue
+		}
+		// Tembel devam satırı
+		if last := (*item)[len(*item)-1]; strings.TrimSpace(last) != "" && !interruptsParagraph(line) && !mdListItem.MatchString(line) {
+			*item = append(*item, strings.TrimSpace(line))
+			i++
+			continue
+		}
+		break
+	}
+
+	tag := "ul"
+	if ordered {
+		tag = "ol"
+		if start, _ := strconv.Atoi(first[3]); start != 1 {
+			b.WriteString(fmt.Sprintf("<ol start=\"%d\">\n", start))
+		} else {
+			b.WriteString("<ol>\n")
+		}
+	} else {
+		b.WriteString("<ul>\n")
+	}
+	for _, item := range items {
+		var content strings.Builder
+		r.blocks(&content, item, !loose)
+		b.WriteString("<li>" + strings.TrimSuffix(content.String(), "\n") + "</li>\n")
+	}
+	b.WriteString("</" + tag + ">\n")
+	return i
+}
+
+// isTableStart reports whether header and divider start a GFM table
+func isTableStart(header, divider string) bool {
+	if !strings.Contains(header, "|") || leadingSpaces(header) >= 4 || !mdTableDivider.MatchString(strings.TrimSpace(divider)) {
+		return false
+	}
+	return len(splitTableRow(header)) == len(splitTableRow(divider))
+}
+
+func (r *markdownRenderer) table(b *strings.Builder, lines []string, i int) int {
+	header := splitTableRow(lines[i])
+	var aligns []string
+	for _, cell := range splitTableRow(lines[i+1]) {
+		left, right := strings.HasPrefix(cell, ":"), strings.HasSuffix(cell, ":")
+		switch {
+		case left && right:
+			aligns = append(aligns, "center")
+		case right:
+			aligns = append(aligns, "right")
+		case left:
+			aligns = append(aligns, "left")
+		default:
+			aligns = append(aligns, "")
+		}
+	}
+
+	writeRow := func(cells []string, tag string) {
+		b.WriteString("<tr>")
+		for j, align := range aligns {
+			b.WriteString("<" + tag)
+			if align != "" {
+				b.WriteString(` align="` + align + `"`)
+			}
+			b.WriteString(">")
+			if j < len(cells) {
+				b.WriteString(r.inline(cells[j]))
+			}
+			b.WriteString("</" + tag + ">")
+		}
+		b.WriteString("</tr>\n")
+	}
+
+	b.WriteString("<table>\n<thead>\n")
+	writeRow(header, "th")
+	b.WriteString("</thead>\n")
+
+	var rows [][]string
+	for i += 2; i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i])
+		if trimmed == "" || interruptsParagraph(lines[i]) {
+			break
+		}
+		rows = append(rows, splitTableRow(lines[i]))
+	}
+	if len(rows) > 0 {
+		b.WriteString("<tbody>\n")
+		for _, row := range rows {
+			writeRow(row, "td")
+		}
+		b.WriteString("</tbody>\n")
+	}
+	b.WriteString("</table>\n")
+	return i
+}
+
+// splitTableRow splits a table row on unescaped pipes
+func splitTableRow(line string) []string {
+	line = strings.TrimSpace(line)
+	line = strings.TrimPrefix(line, "|")
+	if strings.HasSuffix(line, "|") && !strings.HasSuffix(line, `\|`) {
+		line = line[:len(line)-1]
+	}
+
+	var cells []string
+	var cell strings.Builder
+	for i := 0; i < len(line); i++ {
+		switch {
+		case line[i] == '\\' && i+1 < len(line) && line[i+1] == '|':
+			cell.WriteByte('|')
+			i++
+		case line[i] == '|':
+			cells = append(cells, strings.TrimSpace(cell.String()))
+			cell.Reset()
+		default:
+			cell.WriteByte(line[i])
+		}
+	}
+	return append(cells, strings.TrimSpace(cell.String()))
+}
+
+// inline renders the inline Markdown of a paragraph, heading or table cell
+func (r *markdownRenderer) inline(text string) string {
+	var out []byte
+	for i := 0; i < len(text); {
+		c := text[i]
+		switch {
+		case c == '\\' && i+1 < len(text) && text[i+1] == '\n':
+			out = append(out, "<br>\n"...)
+			i += 2
+		case c == '\\' && i+1 < len(text) && isASCIIPunct(text[i+1]):
+			out = append(out, html.EscapeString(text[i+1:i+2])...)
+			i += 2
+		case c == '\n':
+			// Satır sonundaki iki boşluk satır kırılımıdır
+			trimmed := strings.TrimRight(string(out), " ")
+			if len(out)-len(trimmed) >= 2 {
+				out = append([]byte(trimmed), "<br>\n"...)
+			} else {
+				out = append([]byte(trimmed), '\n')
+			}
+			i++
+			for i < len(text) && text[i] == ' ' {
+				i++
+			}
+		case c == '`':
+			run := runLength(text, i, '`')
+			end := findCodeSpanEnd(text, i+run, run)
+			if end < 0 {
+				out = append(out, text[i:i+run]...)
+				i += run
+				continue
+			}
+			code := strings.ReplaceAll(text[i+run:end], "\n", " ")
+			if len(code) > 2 && code[0] == ' ' && code[len(code)-1] == ' ' && strings.TrimSpace(code) != "" {
+				code = code[1 : len(code)-1]
+			}
+			out = append(out, "<code>"+html.EscapeString(code)+"</code>"...)
+			i = end + run
+		case c == '!' && i+1 < len(text) && text[i+1] == '[':
+			if rendered, next, ok := r.link(text, i+1, true); ok {
+				out = append(out, rendered...)
+				i = next
+				continue
+			}
+			out = append(out, '!')
+			i++
+		case c == '[':
+			if rendered, next, ok := r.link(text, i, false); ok {
+				out = append(out, rendered...)
+				i = next
+				continue
+			}
+			out = append(out, '[')
+			i++
+		case c == '<':
+			rest := text[i:]
+			if m := mdAutolink.FindStringSubmatch(rest); m != nil {
+				out = append(out, `<a href="`+html.EscapeString(m[1])+`">`+html.EscapeString(m[1])+"</a>"...)
+				i += len(m[0])
+			} else if m := mdEmailLink.FindStringSubmatch(rest); m != nil {
+				out = append(out, `<a href="mailto:`+html.EscapeString(m[1])+`">`+html.EscapeString(m[1])+"</a>"...)
+				i += len(m[0])
+			} else if tag := mdInlineTag.FindString(rest); tag != "" {
+				out = append(out, tag...) // Ham HTML, temizleyici tarafından süzülür
+				i += len(tag)
+			} else {
+				out = append(out, "&lt;"...)
+				i++
+			}
+		case c == '&':
+			if entity := mdEntity.FindString(text[i:]); entity != "" {
+				out = append(out, entity...)
+				i += len(entity)
+			} else {
+				out = append(out, "&amp;"...)
+				i++
+			}
+		case c == '*' || c == '_' || c == '~':
+			if rendered, next, ok := r.emphasis(text, i); ok {
+				out = append(out, rendered...)
+				i = next
+				continue
+			}
+			run := runLength(text, i, c)
+			out = append(out, text[i:i+run]...)
+			i += run
+		default:
+			out = append(out, html.EscapeString(text[i:i+1])...)
+			i++
+		}
+	}
+	return string(out)
+}
+
+// emphasis renders *em*, **strong**, ***both*** and ~~del~~ starting at i
+func (r *markdownRenderer) emphasis(text string, i int) (string, int, bool) {
+	c := text[i]
+	run := runLength(text, i, c)
+	if c == '~' && run != 2 {
+		return "", 0, false
+	}
+	// Açılış ayracından sonra boşluk gelemez; _ kelime içinde vurgu açmaz
+	if i+run >= len(text) || isSpaceByte(text[i+run]) {
+		return "", 0, false
+	}
+	if c == '_' && i > 0 && isWordByte(text[i-1]) {
+		return "", 0, false
+	}
+
+	for size := min(run, 3); size >= 1; size-- {
+		end := findEmphasisEnd(text, i+size, c, size)
+		if end < 0 {
+			continue
+		}
+		inner := r.inline(text[i+size : end])
+		var rendered string
+		switch {
+		case c == '~':
+			rendered = "<del>" + inner + "</del>"
+		case size == 3:
+			rendered = "<em><strong>" + inner + "</strong></em>"
+		case size == 2:
+			rendered = "<strong>" + inner + "</strong>"
+		default:
+			rendered = "<em>" + inner + "</em>"
+		}
+		// Kullanılmayan açılış ayraçları metin olarak kalır
+		return text[i:i+run-size] + rendered, end + size, true
+	}
+	return "", 0, false
+}
+
+// findEmphasisEnd finds the closing delimiter run of exactly size characters
+func findEmphasisEnd(text string, from int, c byte, size int) int {
+	for j := from; j < len(text); {
+		switch text[j] {
+		case '\\':
+			j += 2
+			continue
+		case '`':
+			run := runLength(text, j, '`')
+			if end := findCodeSpanEnd(text, j+run, run); end >= 0 {
+				j = end + run
+				continue
+			}
+			j += run
+			continue
+		case c:
+			run := runLength(text, j, c)
+			closes := j > from && !isSpaceByte(text[j-1]) && (c != '_' || j+run >= len(text) || !isWordByte(text[j+run]))
+			if closes && (run == size || (run > size && size == 3)) {
+				return j
+			}
+			// Daha kısa/uzun ayraç iç içe vurgudur; atlanır
+			if closes && run > size && size < 3 && (j+run >= len(text) || !isWordByte(text[j+run])) {
+				return j + run - size
+			}
+			j += run
+			continue
+		}
+		j++
+	}
+	return -1
+}
+
+// link renders [text](url "title") or ![alt](src "title") starting at the opening bracket
+func (r *markdownRenderer) link(text string, open int, image bool) (string, int, bool) {
+	closeBracket := -1
+	depth := 0
+	for j := open; j < len(text); j++ {
+		switch text[j] {
+		case '\\':
+			j++
+		case '`':
+			run := runLength(text, j, '`')
+			if end := findCodeSpanEnd(text, j+run, run); end >= 0 {
+				j = end + run - 1
+			}
+		case '[':
+			depth++
+		case ']':
+			depth--
+		}
+		if depth == 0 {
+			closeBracket = j
+			break
+		}
+	}
+	if closeBracket < 0 || closeBracket+1 >= len(text) || text[closeBracket+1] != '(' {
+		return "", 0, false
+	}
+
+	label := text[open+1 : closeBracket]
+	destination, title, next, ok := parseLinkTarget(text, closeBracket+2)
+	if !ok {
+		return "", 0, false
+	}
+
+	attrs := ""
+	if title != "" {
+		attrs = ` title="` + html.EscapeString(title) + `"`
+	}
+	if image {
+		alt := StripHTML(r.inline(label))
+		return `<img src="` + html.EscapeString(destination) + `" alt="` + html.EscapeString(alt) + `"` + attrs + ">", next, true
+	}
+	return `<a href="` + html.EscapeString(destination) + `"` + attrs + ">" + r.inline(label) + "</a>", next, true
+}
+
+// parseLinkTarget parses `url "title")` and returns the position after the closing parenthesis
+func parseLinkTarget(text string, i int) (string, string, int, bool) {
+	skipSpaces := func() {
+		for i < len(text) && isSpaceByte(text[i]) {
+			i++
+		}
+	}
+	skipSpaces()
+
+	var destination string
+	if i < len(text) && text[i] == '<' {
+		end := strings.IndexAny(text[i+1:], ">\n")
+		if end < 0 || text[i+1+end] != '>' {
+			return "", "", 0, false
+		}
+		destination = text[i+1 : i+1+end]
+		i += end + 2
+	} else {
+		start, depth := i, 0
+		for ; i < len(text) && !isSpaceByte(text[i]); i++ {
+			if text[i] == '\\' && i+1 < len(text) {
+				i++
+				continue
+			}
+			if text[i] == '(' {
+				depth++
+			}
+			if text[i] == ')' {
+				if depth == 0 {
+					break
+				}
+				depth--
+			}
+		}
+		destination = unescapeMarkdown(text[start:i])
+	}
+
+	skipSpaces()
+	title := ""
+	if i < len(text) && (text[i] == '"' || text[i] == '\'' || text[i] == '(') {
+		closing := text[i]
+		if closing == '(' {
+			closing = ')'
+		}
+		end := strings.IndexByte(text[i+1:], closing)
+		if end < 0 {
+			return "", "", 0, false
+		}
+		title = unescapeMarkdown(text[i+1 : i+1+end])
+		i += end + 2
+		skipSpaces()
+	}
+	if i >= len(text) || text[i] != ')' {
+		return "", "", 0, false
+	}
+	return destination, title, i + 1, true
+}
+
+func findCodeSpanEnd(text string, from, run int) int {
+	for j := from; j < len(text); {
+		if text[j] != '`' {
+			j++
+			continue
+		}
+		n := runLength(text, j, '`')
+		if n == run {
+			return j
+		}
+		j += n
+	}
+	return -1
+}
+
+func unescapeMarkdown(text string) string {
+	var b strings.Builder
+	for i := 0; i < len(text); i++ {
+		if text[i] == '\\' && i+1 < len(text) && isASCIIPunct(text[i+1]) {
+			i++
+		}
+		b.WriteByte(text[i])
+	}
+	return html.UnescapeString(b.String())
+}
+
+func runLength(text string, i int, c byte) int {
+	n := 0
+	for i+n < len(text) && text[i+n] == c {
+		n++
+	}
+	return n
+}
+
+func leadingSpaces(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+func isASCIIPunct(c byte) bool {
+	return strings.IndexByte("!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~", c) >= 0
+}
+
+func isSpaceByte(c byte) bool {
+	return c == ' ' || c == '\n' || c == '\t'
+}
+
+func isWordByte(c byte) bool {
+	return c >= utf8.RuneSelf || c == '_' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// Okuma süresi hesabında dakikadaki kelime sayısı
+const wordsPerMinute = 200
+
+// Excerpt shortens plain text to at most maxRunes characters, kelime sınırında keserek
+func Excerpt(text string, maxRunes int) string {
+	text = strings.Join(strings.Fields(text), " ")
+	if utf8.RuneCountInString(text) <= maxRunes {
+		return text
+	}
+
+	runes := []rune(text)
+	cut := maxRunes
+	for j := maxRunes; j > maxRunes/2; j-- {
+		if unicode.IsSpace(runes[j]) {
+			cut = j
+			break
+		}
+	}
+	return strings.TrimRightFunc(string(runes[:cut]), func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.IsPunct(r)
+	}) + "…"
+}
+
+// ReadingTime estimates the reading time of plain text in minutes; metin varsa en az 1 dakika
+func ReadingTime(text string) int {
+	words := len(strings.Fields(text))
+	if words == 0 {
+		return 0
+	}
+	return (words + wordsPerMinute - 1) / wordsPerMinute
+}
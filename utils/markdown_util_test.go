@@ -0,0 +1,99 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderMarkdown(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"heading anchors", "# Giriş\n\n## Giriş", `<h1 id="giris">Giriş</h1>` + "\n" + `<h2 id="giris-2">Giriş</h2>`},
+		{"setext heading", "Başlık\n===", `<h1 id="baslik">Başlık</h1>`},
+		{"emphasis", "**kalın**, *italik*, ~~eski~~ ve `a < b`", "<p><strong>kalın</strong>, <em>italik</em>, <del>eski</del> ve <code>a &lt; b</code></p>"},
+		{"intraword underscore", "snake_case_name", "<p>snake_case_name</p>"},
+		{"link and image", `[Site](https://example.com "Ana sayfa") ![Logo](/uploads/logo.png)`,
+			`<p><a href="https://example.com" title="Ana sayfa">Site</a> <img src="/uploads/logo.png" alt="Logo"></p>`},
+		{"autolink", "<https://example.com>", `<p><a href="https://example.com">https://example.com</a></p>`},
+		{"escaping", `1 \* 2 & 3 <4`, "<p>1 * 2 &amp; 3 &lt;4</p>"},
+		{"hard break", "satır  \nsonraki", "<p>satır<br>\nsonraki</p>"},
+		{"fenced code", "```go\nif a < b {\n}\n```", `<pre><code class="language-go">if a &lt; b {` + "\n}\n</code></pre>"},
+		{"tight list", "- bir\n- iki\n  - iç", "<ul>\n<li>bir</li>\n<li>iki\n<ul>\n<li>iç</li>\n</ul></li>\n</ul>"},
+		{"ordered list", "3. üç\n4. dört", "<ol start=\"3\">\n<li>üç</li>\n<li>dört</li>\n</ol>"},
+		{"loose list", "- bir\n\n- iki", "<ul>\n<li><p>bir</p></li>\n<li><p>iki</p></li>\n</ul>"},
+		{"blockquote", "> alıntı\ndevam", "<blockquote>\n<p>alıntı\ndevam</p>\n</blockquote>"},
+		{"rule", "önce\n\n---\n\nsonra", "<p>önce</p>\n<hr>\n<p>sonra</p>"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if result := RenderMarkdown(test.input); result != test.expected {
+				t.Errorf("RenderMarkdown(%q) failed:\nexpected %q\ngot      %q", test.input, test.expected, result)
+			}
+		})
+	}
+}
+
+func TestRenderMarkdownTable(t *testing.T) {
+	input := "| Ad | Fiyat |\n|:---|---:|\n| Çay | 5 \\| 10 |\n| *Kahve* | 20 |"
+	expected := "<table>\n<thead>\n<tr><th align=\"left\">Ad</th><th align=\"right\">Fiyat</th></tr>\n</thead>\n<tbody>\n" +
+		"<tr><td align=\"left\">Çay</td><td align=\"right\">5 | 10</td></tr>\n" +
+		"<tr><td align=\"left\"><em>Kahve</em></td><td align=\"right\">20</td></tr>\n</tbody>\n</table>"
+
+	if result := RenderMarkdown(input); result != expected {
+		t.Errorf("RenderMarkdown table failed:\nexpected %q\ngot      %q", expected, result)
+	}
+}
+
+func TestRenderMarkdownSanitized(t *testing.T) {
+	// Ham HTML ve tehlikeli bağlantılar temizleyiciden geçince kaldırılır
+	rendered := RenderMarkdown("[tıkla](javascript:alert(1)) <script>alert(1)</script>\n\n| a |\n|---|\n| b |")
+	cleaned, removed := SanitizeHTML(rendered, DefaultSanitizerPolicies()["rich_text"])
+
+	if strings.Contains(cleaned, "javascript") || strings.Contains(cleaned, "script") {
+		t.Errorf("Sanitized markdown still contains unsafe markup: %q", cleaned)
+	}
+	if !strings.Contains(cleaned, "<td>b</td>") {
+		t.Errorf("Sanitized markdown lost the table: %q", cleaned)
+	}
+	if len(removed) == 0 {
+		t.Errorf("Expected removed markup to be reported")
+	}
+}
+
+func TestExcerpt(t *testing.T) {
+	tests := []struct {
+		input    string
+		max      int
+		expected string
+	}{
+		{"Kısa metin", 20, "Kısa metin"},
+		{"Bir  iki\n\nüç dört beş", 100, "Bir iki üç dört beş"},
+		{"Öğrenmek için okumak, okumak için öğrenmek gerekir.", 25, "Öğrenmek için okumak…"},
+	}
+
+	for _, test := range tests {
+		if result := Excerpt(test.input, test.max); result != test.expected {
+			t.Errorf("Excerpt(%q, %d) failed: expected %q, got %q", test.input, test.max, test.expected, result)
+		}
+	}
+}
+
+func TestReadingTime(t *testing.T) {
+	tests := map[string]int{
+		"":                              0,
+		"tek kelime":                    1,
+		strings.Repeat("kelime ", 200):  1,
+		strings.Repeat("kelime ", 201):  2,
+		strings.Repeat("kelime ", 1000): 5,
+	}
+
+	for input, expected := range tests {
+		if result := ReadingTime(input); result != expected {
+			t.Errorf("ReadingTime(%d words) failed: expected %d, got %d", len(strings.Fields(input)), expected, result)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package utils
+
+import (
+	"admin-panel/models"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Medya düzenleme alanlarının sınırları
+const (
+	MaxMediaTags          = 50
+	maxMediaTagLength     = 64
+	maxMediaFolderName    = 100
+	maxMediaAltLength     = 500
+	maxMediaTitleLength   = 250
+	maxMediaCaptionLength = 2000
+)
+
+// MIME tipi gruplarının düzenli ifadeleri (medya filtresi)
+var mediaMimeGroups = map[string]string{
+	"image":    `^image/`,
+	"video":    `^video/`,
+	"audio":    `^audio/`,
+	"document": `^(text/|application/(pdf|msword|rtf|vnd\.ms-|vnd\.openxmlformats-officedocument\.|vnd\.oasis\.opendocument\.))`,
+	"archive":  `^application/(zip|gzip|x-gzip|x-tar|x-7z-compressed|x-rar-compressed|vnd\.rar)$`,
+}
+
+// MediaMimeGroupPattern returns the MIME type pattern of a group (image, video, audio, document, archive)
+func MediaMimeGroupPattern(group string) (string, bool) {
+	pattern, ok := mediaMimeGroups[group]
+	return pattern, ok
+}
+
+// MediaMimeGroups returns the names of the MIME groups in alphabetical order
+func MediaMimeGroups() []string {
+	groups := make([]string, 0, len(mediaMimeGroups))
+	for group := range mediaMimeGroups {
+		groups = append(groups, group)
+	}
+	sort.Strings(groups)
+	return groups
+}
+
+// NormalizeMediaTag trims, lowercases and collapses the spaces of a tag; geçersizse boş döner
+func NormalizeMediaTag(tag string) string {
+	tag = strings.ToLower(strings.Join(strings.Fields(norm.NFC.String(tag)), " "))
+	tag = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) || r == ',' {
+			return -1
+		}
+		return r
+	}, tag)
+	return strings.TrimSpace(tag)
+}
+
+// ValidateMediaMetadata normalizes a metadata update and checks its values.
+// Yerelleştirmeler yalnızca languages içindeki dil kodlarıyla kabul edilir; boş diller çıkarılır.
+func ValidateMediaMetadata(update *models.MediaMetadataUpdate, languages []string) ValidationErrors {
+	var errs ValidationErrors
+
+	if update.Localizations != nil {
+		localizations := map[string]models.MediaLocalization{}
+		for lang, localization := range *update.Localizations {
+			field := "localizations." + lang
+			if !containsValue(languages, lang) {
+				errs.add(field, "is not an active language")
+				continue
+			}
+			localization.Alt = strings.TrimSpace(localization.Alt)
+			localization.Caption = strings.TrimSpace(localization.Caption)
+			localization.Title = strings.TrimSpace(localization.Title)
+			checkMediaTextLength(&errs, field+".alt", localization.Alt, maxMediaAltLength)
+			checkMediaTextLength(&errs, field+".caption", localization.Caption, maxMediaCaptionLength)
+			checkMediaTextLength(&errs, field+".title", localization.Title, maxMediaTitleLength)
+			if localization != (models.MediaLocalization{}) {
+				localizations[lang] = localization
+			}
+		}
+		update.Localizations = &localizations
+	}
+
+	if update.Tags != nil {
+		tags := []string{}
+		for i, tag := range *update.Tags {
+			tag = NormalizeMediaTag(tag)
+			switch {
+			case tag == "":
+				continue
+			case utf8.RuneCountInString(tag) > maxMediaTagLength:
+				errs.add(fmt.Sprintf("tags[%d]", i), "must be at most %d characters", maxMediaTagLength)
+			case !containsValue(tags, tag):
+				tags = append(tags, tag)
+			}
+		}
+		if len(tags) > MaxMediaTags {
+			errs.add("tags", "at most %d tags are allowed", MaxMediaTags)
+		}
+		update.Tags = &tags
+	}
+
+	if point := update.FocalPoint; point != nil {
+		if point.X < 0 || point.X > 1 {
+			errs.add("focal_point.x", "must be between 0 and 1")
+		}
+		if point.Y < 0 || point.Y > 1 {
+			errs.add("focal_point.y", "must be between 0 and 1")
+		}
+		if update.ClearFocalPoint {
+			errs.add("clear_focal_point", "cannot be combined with focal_point")
+		}
+	}
+	return errs
+}
+
+// NormalizeMediaFolderName trims a folder name and checks it; klasör yolları "/" ile ayrıldığından ad "/" içeremez
+func NormalizeMediaFolderName(name string) (string, ValidationErrors) {
+	var errs ValidationErrors
+	name = strings.TrimSpace(strings.Join(strings.Fields(norm.NFC.String(name)), " "))
+	switch {
+	case name == "" || name == "." || name == "..":
+		errs.add("name", "is required")
+	case strings.ContainsAny(name, "/\\"):
+		errs.add("name", "must not contain / or \\")
+	case utf8.RuneCountInString(name) > maxMediaFolderName:
+		errs.add("name", "must be at most %d characters", maxMediaFolderName)
+	}
+	return name, errs
+}
+
+// MediaFolderPath returns the full path of a folder under its parent's path ("" kök dizin)
+func MediaFolderPath(parentPath, name string) string {
+	return parentPath + "/" + name
+}
+
+func checkMediaTextLength(errs *ValidationErrors, field, value string, max int) {
+	if utf8.RuneCountInString(value) > max {
+		errs.add(field, "must be at most %d characters", max)
+	}
+}
@@ -0,0 +1,93 @@
+package utils
+
+import (
+	"admin-panel/models"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestValidateMediaMetadata(t *testing.T) {
+	localizations := map[string]models.MediaLocalization{
+		"en": {Alt: "  Beach at sunset "},
+		"tr": {},
+	}
+	tags := []string{" Kapak ", "kapak", "Yaz  Kampanyası", "", "a,b"}
+	update := models.MediaMetadataUpdate{Localizations: &localizations, Tags: &tags}
+	if errs := ValidateMediaMetadata(&update, []string{"en", "tr"}); len(errs) > 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if got := (*update.Localizations)["en"].Alt; got != "Beach at sunset" {
+		t.Errorf("expected trimmed alt text, got %q", got)
+	}
+	if _, ok := (*update.Localizations)["tr"]; ok {
+		t.Error("expected the empty localization to be dropped")
+	}
+	if got := strings.Join(*update.Tags, "|"); got != "kapak|yaz kampanyası|ab" {
+		t.Errorf("unexpected tags %q", got)
+	}
+}
+
+func TestValidateMediaMetadataErrors(t *testing.T) {
+	localizations := map[string]models.MediaLocalization{
+		"de": {Alt: "Strand"},
+		"en": {Title: strings.Repeat("x", maxMediaTitleLength+1)},
+	}
+	update := models.MediaMetadataUpdate{
+		Localizations:   &localizations,
+		FocalPoint:      &models.MediaFocalPoint{X: 1.2, Y: 0.5},
+		ClearFocalPoint: true,
+	}
+	errs := ValidateMediaMetadata(&update, []string{"en", "tr"})
+	fields := map[string]bool{}
+	for _, err := range errs {
+		fields[err.Field] = true
+	}
+	for _, field := range []string{"localizations.de", "localizations.en.title", "focal_point.x", "clear_focal_point"} {
+		if !fields[field] {
+			t.Errorf("expected an error for %s, got %v", field, errs)
+		}
+	}
+	if fields["focal_point.y"] {
+		t.Error("unexpected error for a valid focal_point.y")
+	}
+}
+
+func TestNormalizeMediaFolderName(t *testing.T) {
+	if name, errs := NormalizeMediaFolderName("  Yaz   2024 "); len(errs) > 0 || name != "Yaz 2024" {
+		t.Errorf("unexpected result %q, %v", name, errs)
+	}
+	for _, name := range []string{"", " ", "..", "a/b", `a\b`, strings.Repeat("x", maxMediaFolderName+1)} {
+		if _, errs := NormalizeMediaFolderName(name); len(errs) == 0 {
+			t.Errorf("expected %q to be rejected", name)
+		}
+	}
+	if got := MediaFolderPath(MediaFolderPath("", "Kampanyalar"), "2024"); got != "/Kampanyalar/2024" {
+		t.Errorf("unexpected path %q", got)
+	}
+}
+
+func TestMediaMimeGroupPattern(t *testing.T) {
+	cases := map[string][]string{
+		"image":    {"image/png", "image/svg+xml"},
+		"document": {"application/pdf", "text/csv", "application/vnd.openxmlformats-officedocument.wordprocessingml.document"},
+		"archive":  {"application/zip", "application/x-7z-compressed"},
+	}
+	for group, types := range cases {
+		pattern, ok := MediaMimeGroupPattern(group)
+		if !ok {
+			t.Fatalf("expected group %s", group)
+		}
+		for _, mimeType := range types {
+			if !regexp.MustCompile(pattern).MatchString(mimeType) {
+				t.Errorf("expected %s to be in %s", mimeType, group)
+			}
+		}
+	}
+	if pattern, _ := MediaMimeGroupPattern("archive"); regexp.MustCompile(pattern).MatchString("application/zipx") {
+		t.Error("expected application/zipx not to be an archive")
+	}
+	if _, ok := MediaMimeGroupPattern("spreadsheet"); ok {
+		t.Error("expected an unknown group to be rejected")
+	}
+}
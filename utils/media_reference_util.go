@@ -0,0 +1,128 @@
+package utils
+
+import (
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	nethtml "golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// İsteğe bağlı boyutlandırma adresleri (/media/<id>/<dönüşüm>) medya kimliğini taşır
+var mediaPathPattern = regexp.MustCompile(`^/media/([0-9a-f]{24})(/|$)`)
+
+// Dosya adresi taşıyabilen öznitelikler
+var mediaLinkAttributes = []string{"src", "href", "poster", "data-src"}
+
+// MediaLinksInHTML returns the media IDs (data-media-id, /media/<id>/... adresleri) and the other
+// file addresses an HTML body links to. Adresler NormalizeMediaAddress ile sadeleştirilir.
+func MediaLinksInHTML(content string) ([]primitive.ObjectID, []string) {
+	if !strings.Contains(content, "<") {
+		return nil, nil
+	}
+	nodes, err := nethtml.ParseFragment(strings.NewReader(content), &nethtml.Node{Type: nethtml.ElementNode, Data: "body", DataAtom: atom.Body})
+	if err != nil {
+		return nil, nil
+	}
+
+	links := mediaLinks{seen: map[string]bool{}}
+	var walk func(node *nethtml.Node)
+	walk = func(node *nethtml.Node) {
+		if node.Type == nethtml.ElementNode {
+			if id, err := primitive.ObjectIDFromHex(attr(node, "data-media-id")); err == nil {
+				links.addID(id)
+			}
+			for _, key := range mediaLinkAttributes {
+				links.add(attr(node, key))
+			}
+			// srcset: "a.jpg 1x, b.jpg 2x"
+			for _, candidate := range strings.Split(attr(node, "srcset"), ",") {
+				if fields := strings.Fields(candidate); len(fields) > 0 {
+					links.add(fields[0])
+				}
+			}
+		}
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+	}
+	for _, node := range nodes {
+		walk(node)
+	}
+	return links.ids, links.addresses
+}
+
+// MediaLinksInURLs sorts single addresses (örn: logo_url) into media IDs and file addresses like MediaLinksInHTML
+func MediaLinksInURLs(addresses ...string) ([]primitive.ObjectID, []string) {
+	links := mediaLinks{seen: map[string]bool{}}
+	for _, address := range addresses {
+		links.add(address)
+	}
+	return links.ids, links.addresses
+}
+
+// MediaIDFromAddress returns the media ID of an on-demand resize address such as /media/<id>/w_800
+func MediaIDFromAddress(address string) (primitive.ObjectID, bool) {
+	match := mediaPathPattern.FindStringSubmatch(address)
+	if match == nil {
+		return primitive.NilObjectID, false
+	}
+	id, err := primitive.ObjectIDFromHex(match[1])
+	return id, err == nil
+}
+
+// NormalizeMediaAddress drops the query and fragment of a link; site içi yollar temizlenir, http(s) dışındaki adresler için boş döner
+func NormalizeMediaAddress(raw string) string {
+	raw = strings.TrimSpace(raw)
+	if raw == "" || strings.HasPrefix(raw, "#") {
+		return ""
+	}
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return ""
+	}
+	switch {
+	case parsed.Scheme == "" && parsed.Host == "":
+		if parsed.Path == "" {
+			return ""
+		}
+		return path.Clean(parsed.Path)
+	case parsed.Scheme == "http" || parsed.Scheme == "https" || parsed.Scheme == "":
+		return (&url.URL{Scheme: parsed.Scheme, Host: parsed.Host, Path: parsed.Path}).String()
+	}
+	return ""
+}
+
+// mediaLinks collects unique media IDs and addresses
+type mediaLinks struct {
+	ids       []primitive.ObjectID
+	addresses []string
+	seen      map[string]bool
+}
+
+func (l *mediaLinks) addID(id primitive.ObjectID) {
+	if !l.seen[id.Hex()] {
+		l.seen[id.Hex()] = true
+		l.ids = append(l.ids, id)
+	}
+}
+
+func (l *mediaLinks) add(raw string) {
+	address := NormalizeMediaAddress(raw)
+	if address == "" {
+		return
+	}
+	if parsed, err := url.Parse(address); err == nil {
+		if id, ok := MediaIDFromAddress(parsed.Path); ok {
+			l.addID(id)
+			return
+		}
+	}
+	if !l.seen[address] {
+		l.seen[address] = true
+		l.addresses = append(l.addresses, address)
+	}
+}
@@ -0,0 +1,52 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestMediaLinksInHTML(t *testing.T) {
+	id := primitive.NewObjectID()
+	other := primitive.NewObjectID()
+	content := `<p><img src="/uploads/2026/10/a-logo.png?v=2" data-media-id="` + id.Hex() + `">` +
+		`<img srcset="/uploads/b.jpg 1x, https://cdn.example.com/b@2x.jpg 2x" src="/uploads/b.jpg">` +
+		`<a href="/media/` + other.Hex() + `/w_800?s=abc">resized</a>` +
+		`<a href="mailto:info@example.com">mail</a><a href="#top">top</a>` +
+		`<video poster="/uploads/../uploads/poster.jpg"></video></p>`
+
+	ids, addresses := MediaLinksInHTML(content)
+	if !reflect.DeepEqual(ids, []primitive.ObjectID{id, other}) {
+		t.Errorf("unexpected ids: %v", ids)
+	}
+	expected := []string{"/uploads/2026/10/a-logo.png", "/uploads/b.jpg", "https://cdn.example.com/b@2x.jpg", "/uploads/poster.jpg"}
+	if !reflect.DeepEqual(addresses, expected) {
+		t.Errorf("unexpected addresses: %v", addresses)
+	}
+
+	if ids, addresses := MediaLinksInHTML("plain text"); ids != nil || addresses != nil {
+		t.Error("expected no links in plain text")
+	}
+
+	ids, addresses = MediaLinksInURLs("", "https://example.com/uploads/logo.png", "/media/"+id.Hex()+"/w_64")
+	if !reflect.DeepEqual(ids, []primitive.ObjectID{id}) || !reflect.DeepEqual(addresses, []string{"https://example.com/uploads/logo.png"}) {
+		t.Errorf("unexpected links: %v %v", ids, addresses)
+	}
+}
+
+func TestNormalizeMediaAddress(t *testing.T) {
+	cases := map[string]string{
+		"https://cdn.example.com/a.png?X-Amz-Signature=1#x": "https://cdn.example.com/a.png",
+		"//cdn.example.com/a.png":                           "//cdn.example.com/a.png",
+		"/uploads//a.png":                                   "/uploads/a.png",
+		"javascript:alert(1)":                               "",
+		"data:image/png;base64,AAAA":                        "",
+		"":                                                  "",
+	}
+	for input, expected := range cases {
+		if got := NormalizeMediaAddress(input); got != expected {
+			t.Errorf("%q: got %q", input, got)
+		}
+	}
+}
@@ -0,0 +1,298 @@
+package utils
+
+import (
+	"admin-panel/models"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CSV dışa/içe aktarım sütunları; hits ve last_hit_at içe aktarımda yok sayılır
+var redirectCSVHeader = []string{"source", "match_type", "target", "status_code", "lang", "active", "note", "hits", "last_hit_at"}
+
+// RedirectMatcher holds the compiled active redirect rules
+type RedirectMatcher struct {
+	exact  map[string][]*models.RedirectRule // Yol -> kurallar (dile özel kurallar önce)
+	prefix []*models.RedirectRule            // En uzun önek önce
+	regex  []redirectPattern                 // Oluşturulma sırasıyla
+}
+
+type redirectPattern struct {
+	rule    *models.RedirectRule
+	pattern *regexp.Regexp
+}
+
+// ValidateRedirectRule normalizes a rule (varsayılanlar: exact, 301) and checks its values
+func ValidateRedirectRule(rule *models.RedirectRule) ValidationErrors {
+	var errs ValidationErrors
+	rule.Source = strings.TrimSpace(rule.Source)
+	rule.Target = strings.TrimSpace(rule.Target)
+	rule.Lang = strings.TrimSpace(rule.Lang)
+	if rule.MatchType == "" {
+		rule.MatchType = models.RedirectMatchExact
+	}
+	if rule.StatusCode == 0 {
+		rule.StatusCode = http.StatusMovedPermanently
+	}
+
+	switch rule.MatchType {
+	case models.RedirectMatchExact, models.RedirectMatchPrefix:
+		if !strings.HasPrefix(rule.Source, "/") {
+			errs.add("source", "must be a path starting with /")
+		}
+		if rule.MatchType == models.RedirectMatchExact {
+			rule.Source = normalizeRedirectPath(rule.Source)
+		}
+	case models.RedirectMatchRegex:
+		if rule.Source == "" {
+			errs.add("source", "is required")
+		} else if _, err := regexp.Compile(rule.Source); err != nil {
+			errs.add("source", "invalid regular expression: %v", err)
+		}
+	default:
+		errs.add("match_type", "must be exact, prefix or regex")
+	}
+
+	switch rule.StatusCode {
+	case http.StatusGone:
+		if rule.Target != "" {
+			errs.add("target", "must be empty for 410 rules")
+		}
+	case http.StatusMovedPermanently, http.StatusFound:
+		switch {
+		case rule.Target == "":
+			errs.add("target", "is required")
+		case !isSitePath(rule.Target) && !isAbsoluteURL(rule.Target):
+			errs.add("target", "must be an absolute http(s) URL or a site path")
+		case rule.MatchType == models.RedirectMatchExact && normalizeRedirectPath(rule.Target) == rule.Source:
+			errs.add("target", "must differ from the source")
+		}
+	default:
+		errs.add("status_code", "must be 301, 302 or 410")
+	}
+
+	if rule.Lang != "" && !IsValidLanguageCode(rule.Lang) {
+		errs.add("lang", "invalid language code")
+	}
+	return errs
+}
+
+// CompileRedirectRules builds a matcher from the active rules; geçersiz düzenli ifadeler atlanır
+func CompileRedirectRules(rules []models.RedirectRule) *RedirectMatcher {
+	matcher := &RedirectMatcher{exact: map[string][]*models.RedirectRule{}}
+	for i := range rules {
+		rule := &rules[i]
+		if !rule.Active {
+			continue
+		}
+		switch rule.MatchType {
+		case models.RedirectMatchExact:
+			path := normalizeRedirectPath(rule.Source)
+			matcher.exact[path] = append(matcher.exact[path], rule)
+		case models.RedirectMatchPrefix:
+			matcher.prefix = append(matcher.prefix, rule)
+		case models.RedirectMatchRegex:
+			if pattern, err := regexp.Compile(rule.Source); err == nil {
+				matcher.regex = append(matcher.regex, redirectPattern{rule: rule, pattern: pattern})
+			}
+		}
+	}
+
+	for _, candidates := range matcher.exact {
+		sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].Lang != "" && candidates[j].Lang == "" })
+	}
+	sort.SliceStable(matcher.prefix, func(i, j int) bool {
+		a, b := matcher.prefix[i], matcher.prefix[j]
+		if len(a.Source) != len(b.Source) {
+			return len(a.Source) > len(b.Source)
+		}
+		return a.Lang != "" && b.Lang == ""
+	})
+	return matcher
+}
+
+// Match finds the rule for a request path in a language and returns it with the resolved target.
+// Öncelik: tam eşleşme, en uzun önek, düzenli ifadeler.
+func (m *RedirectMatcher) Match(path, lang string) (*models.RedirectRule, string) {
+	applies := func(rule *models.RedirectRule) bool {
+		return rule.Lang == "" || rule.Lang == lang
+	}
+
+	for _, rule := range m.exact[normalizeRedirectPath(path)] {
+		if applies(rule) {
+			return rule, rule.Target
+		}
+	}
+
+	for _, rule := range m.prefix {
+		if !applies(rule) || !hasPathPrefix(path, rule.Source) {
+			continue
+		}
+		// Önekten sonra kalan yol hedefe eklenir: /eski/a -> /yeni/a
+		rest := strings.TrimPrefix(path, rule.Source)
+		if rule.Target == "" || rest == "" {
+			return rule, rule.Target
+		}
+		return rule, siteRedirectTarget(strings.TrimSuffix(rule.Target, "/") + "/" + strings.TrimPrefix(rest, "/"))
+	}
+
+	for _, item := range m.regex {
+		if !applies(item.rule) {
+			continue
+		}
+		match := item.pattern.FindStringSubmatchIndex(path)
+		if match == nil {
+			continue
+		}
+		return item.rule, siteRedirectTarget(string(item.pattern.ExpandString(nil, item.rule.Target, path, match)))
+	}
+	return nil, ""
+}
+
+// siteRedirectTarget collapses the leading slashes of a target built from the request path;
+// "/eski//evil.com" gibi yollar "//evil.com" hedefiyle başka bir siteye yönlendiremez
+func siteRedirectTarget(target string) string {
+	if !strings.HasPrefix(target, "/") && !strings.HasPrefix(target, "\\") {
+		return target
+	}
+	return "/" + strings.TrimLeft(target, "/\\")
+}
+
+// WriteRedirectCSV writes the rules as CSV with a header row
+func WriteRedirectCSV(w io.Writer, rules []models.RedirectRule) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(redirectCSVHeader); err != nil {
+		return err
+	}
+	for _, rule := range rules {
+		lastHit := ""
+		if rule.LastHitAt != nil {
+			lastHit = rule.LastHitAt.UTC().Format(time.RFC3339)
+		}
+		record := []string{
+			rule.Source, rule.MatchType, rule.Target, strconv.Itoa(rule.StatusCode), rule.Lang,
+			strconv.FormatBool(rule.Active), rule.Note, strconv.FormatInt(rule.Hits, 10), lastHit,
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// ParseRedirectCSV reads redirect rules from CSV. İlk satır başlıktır; yalnızca source sütunu zorunludur.
+// Hatalı satırlar "rows[<satır no>].<alan>" biçiminde raporlanır ve hiçbir kural döndürülmez.
+func ParseRedirectCSV(r io.Reader) ([]models.RedirectRule, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, ValidationErrors{{Field: "file", Message: "is empty"}}
+	}
+	if err != nil {
+		return nil, err
+	}
+	columns := map[string]int{}
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		columns[name] = i
+	}
+	if _, ok := columns["source"]; !ok {
+		return nil, ValidationErrors{{Field: "header", Message: "source column is required"}}
+	}
+
+	var rules []models.RedirectRule
+	var errs ValidationErrors
+	seen := map[string]int{}
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := reader.FieldPos(0)
+		value := func(column string) string {
+			if i, ok := columns[column]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		if strings.Join(record, "") == "" {
+			continue // Boş satır
+		}
+
+		prefix := fmt.Sprintf("rows[%d]", line)
+		rule := models.RedirectRule{
+			Source:    value("source"),
+			MatchType: value("match_type"),
+			Target:    value("target"),
+			Lang:      value("lang"),
+			Note:      value("note"),
+			Active:    true,
+		}
+		if status := value("status_code"); status != "" {
+			code, err := strconv.Atoi(status)
+			if err != nil {
+				errs.add(prefix+".status_code", "must be a number")
+				continue
+			}
+			rule.StatusCode = code
+		}
+		if active := value("active"); active != "" {
+			parsed, err := strconv.ParseBool(active)
+			if err != nil {
+				errs.add(prefix+".active", "must be true or false")
+				continue
+			}
+			rule.Active = parsed
+		}
+
+		for _, fieldError := range ValidateRedirectRule(&rule) {
+			errs.add(prefix+"."+fieldError.Field, "%s", fieldError.Message)
+		}
+		key := RedirectRuleKey(rule)
+		if first, ok := seen[key]; ok {
+			errs.add(prefix+".source", "duplicates row %d", first)
+			continue
+		}
+		seen[key] = line
+		rules = append(rules, rule)
+	}
+
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	return rules, nil
+}
+
+// RedirectRuleKey identifies a rule by its source, match type and language
+func RedirectRuleKey(rule models.RedirectRule) string {
+	return rule.MatchType + " " + rule.Lang + " " + rule.Source
+}
+
+// normalizeRedirectPath removes the trailing slash so /eski and /eski/ match the same rule
+func normalizeRedirectPath(path string) string {
+	if len(path) > 1 {
+		return strings.TrimSuffix(path, "/")
+	}
+	return path
+}
+
+// hasPathPrefix reports whether path starts with prefix at a segment boundary (/blog, /blog/x; /blogger değil)
+func hasPathPrefix(path, prefix string) bool {
+	if !strings.HasPrefix(path, prefix) {
+		return false
+	}
+	return len(path) == len(prefix) || strings.HasSuffix(prefix, "/") || path[len(prefix)] == '/'
+}
@@ -0,0 +1,134 @@
+package utils
+
+import (
+	"admin-panel/models"
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidateRedirectRule(t *testing.T) {
+	rule := models.RedirectRule{Source: " /eski/ ", Target: "/yeni"}
+	if errs := ValidateRedirectRule(&rule); len(errs) > 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if rule.Source != "/eski" || rule.MatchType != models.RedirectMatchExact || rule.StatusCode != 301 {
+		t.Errorf("unexpected defaults: %+v", rule)
+	}
+
+	cases := map[string]models.RedirectRule{
+		"source":      {Source: "eski", Target: "/yeni"},
+		"match_type":  {Source: "/eski", MatchType: "glob", Target: "/yeni"},
+		"status_code": {Source: "/eski", Target: "/yeni", StatusCode: 307},
+		"target":      {Source: "/eski", Target: "/yeni", StatusCode: 410},
+		"lang":        {Source: "/eski", Target: "/yeni", Lang: "not a lang"},
+	}
+	for field, rule := range cases {
+		errs := ValidateRedirectRule(&rule)
+		if len(errs) == 0 || errs[0].Field != field {
+			t.Errorf("%s: unexpected errors: %v", field, errs)
+		}
+	}
+
+	for _, rule := range []models.RedirectRule{
+		{Source: "/eski", Target: "/eski/"},
+		{Source: "/eski", Target: "javascript:alert(1)"},
+		{Source: "/eski", Target: "//evil.com"},
+		{Source: "/eski", Target: `/\evil.com`},
+		{Source: "^/(", MatchType: models.RedirectMatchRegex, Target: "/yeni"},
+	} {
+		if errs := ValidateRedirectRule(&rule); len(errs) == 0 {
+			t.Errorf("expected errors for %+v", rule)
+		}
+	}
+}
+
+func TestRedirectMatcher(t *testing.T) {
+	matcher := CompileRedirectRules([]models.RedirectRule{
+		{Source: "/blog", MatchType: models.RedirectMatchPrefix, Target: "/yazilar", Active: true},
+		{Source: "/blog/eski", MatchType: models.RedirectMatchPrefix, Target: "https://arsiv.example.com", Active: true},
+		{Source: "/blog/ozel", MatchType: models.RedirectMatchExact, Target: "/ozel", Active: true},
+		{Source: "/blog/ozel", MatchType: models.RedirectMatchExact, Target: "/en/special", Lang: "en", Active: true},
+		{Source: `^/urun/(\d+)$`, MatchType: models.RedirectMatchRegex, Target: "/magaza/$1", Active: true},
+		{Source: "/old", MatchType: models.RedirectMatchPrefix, Target: "/", Active: true},
+		{Source: `^/go/(.*)$`, MatchType: models.RedirectMatchRegex, Target: "/$1", Active: true},
+		{Source: "/kaldirildi", StatusCode: 410, MatchType: models.RedirectMatchExact, Active: true},
+		{Source: "/pasif", MatchType: models.RedirectMatchExact, Target: "/x", Active: false},
+	})
+
+	cases := []struct {
+		path, lang, target string
+	}{
+		{"/blog/ozel/", "tr", "/ozel"},
+		{"/blog/ozel", "en", "/en/special"},
+		{"/blog/a/b", "", "/yazilar/a/b"},
+		{"/blog", "", "/yazilar"},
+		{"/blog/eski/2019", "", "https://arsiv.example.com/2019"},
+		{"/urun/42", "", "/magaza/42"},
+		{"/old//evil.com", "", "/evil.com"},
+		{"/go//evil.com", "", "/evil.com"},
+		{`/go/\evil.com`, "", "/evil.com"},
+		{"/go/yeni", "", "/yeni"},
+		{"/kaldirildi", "", ""},
+	}
+	for _, tc := range cases {
+		rule, target := matcher.Match(tc.path, tc.lang)
+		if rule == nil || target != tc.target {
+			t.Errorf("%s (%s): expected %q, got %q (%v)", tc.path, tc.lang, tc.target, target, rule)
+		}
+	}
+
+	for _, path := range []string{"/blogger", "/urun/abc", "/pasif", "/"} {
+		if rule, _ := matcher.Match(path, ""); rule != nil {
+			t.Errorf("%s: expected no match, got %+v", path, rule)
+		}
+	}
+}
+
+func TestRedirectCSVRoundTrip(t *testing.T) {
+	rules := []models.RedirectRule{
+		{Source: "/eski", MatchType: models.RedirectMatchExact, Target: "/yeni", StatusCode: 301, Active: true, Note: "a, b", Hits: 7},
+		{Source: "^/p/(.*)$", MatchType: models.RedirectMatchRegex, Target: "/posts/$1", StatusCode: 302, Lang: "en"},
+	}
+	var buf bytes.Buffer
+	if err := WriteRedirectCSV(&buf, rules); err != nil {
+		t.Fatal(err)
+	}
+
+	parsed, err := ParseRedirectCSV(strings.NewReader("\ufeff" + buf.String()))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(parsed) != 2 {
+		t.Fatalf("expected 2 rules, got %d", len(parsed))
+	}
+	if parsed[0].Note != "a, b" || parsed[0].Hits != 0 || !parsed[0].Active {
+		t.Errorf("unexpected first rule: %+v", parsed[0])
+	}
+	if parsed[1].Lang != "en" || parsed[1].StatusCode != 302 || parsed[1].Active {
+		t.Errorf("unexpected second rule: %+v", parsed[1])
+	}
+}
+
+func TestParseRedirectCSVErrors(t *testing.T) {
+	input := "source,target,status_code\n/a,/b,301\n\n/a,/c,301\n/x,/y,abc\nrel,/z,\n"
+	_, err := ParseRedirectCSV(strings.NewReader(input))
+	var errs ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("expected validation errors, got %v", err)
+	}
+	fields := map[string]bool{}
+	for _, fieldError := range errs {
+		fields[fieldError.Field] = true
+	}
+	for _, field := range []string{"rows[4].source", "rows[5].status_code", "rows[6].source"} {
+		if !fields[field] {
+			t.Errorf("missing %s in %v", field, errs)
+		}
+	}
+
+	if _, err := ParseRedirectCSV(strings.NewReader("target\n/b\n")); err == nil {
+		t.Error("expected error for a missing source column")
+	}
+}
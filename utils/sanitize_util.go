@@ -0,0 +1,219 @@
+package utils
+
+import (
+	"admin-panel/models"
+	"fmt"
+	"html"
+	"io"
+	"net/url"
+	"sort"
+	"strings"
+
+	nethtml "golang.org/x/net/html"
+)
+
+// İçeriğiyle birlikte kaldırılan etiketler; diğer izinsiz etiketlerin yalnızca kendisi kaldırılır
+var sanitizerDropContent = map[string]bool{
+	"script": true, "style": true, "noscript": true, "template": true, "object": true, "embed": true,
+	"applet": true, "frameset": true, "frame": true, "textarea": true, "select": true, "title": true, "head": true,
+	"svg": true, "math": true,
+}
+
+var sanitizerVoidElements = map[string]bool{
+	"br": true, "hr": true, "img": true, "wbr": true, "col": true, "source": true, "track": true,
+}
+
+// URL içeren öznitelikler şema kontrolünden geçer
+var sanitizerURLAttributes = map[string]bool{"href": true, "src": true, "cite": true, "poster": true}
+
+// DefaultSanitizerPolicies returns the built-in policies; kayıtlı politikalar bunların yerine geçer
+func DefaultSanitizerPolicies() map[string]models.SanitizerPolicy {
+	textElements := []string{"p", "br", "strong", "b", "em", "i", "u", "s", "code", "pre", "blockquote", "ul", "ol", "li"}
+
+	rich := models.SanitizerPolicy{
+		Name:              models.PolicyRichText,
+		Elements:          map[string][]string{},
+		GlobalAttributes:  []string{"class", "title", "lang", "dir"},
+		URLSchemes:        []string{"http", "https", "mailto", "tel"},
+		AllowRelativeURLs: true,
+	}
+	for _, element := range append(textElements,
+		"h1", "h2", "h3", "h4", "h5", "h6", "del", "ins", "mark", "sub", "sup", "small", "kbd", "cite", "q",
+		"dl", "dt", "dd", "hr", "span", "figure", "figcaption", "caption", "table", "thead", "tbody", "tfoot", "tr") {
+		rich.Elements[element] = nil
+	}
+	rich.Elements["a"] = []string{"href", "target", "rel"}
+	rich.Elements["img"] = []string{"src", "alt", "width", "height", "loading", "data-media-id"}
+	rich.Elements["iframe"] = []string{"src", "width", "height", "loading", "allowfullscreen"}
+	rich.Elements["div"] = []string{"data-slider-id"}
+	rich.Elements["th"] = []string{"colspan", "rowspan", "scope", "align"}
+	rich.Elements["td"] = []string{"colspan", "rowspan", "align"}
+	rich.Elements["ol"] = []string{"start", "reversed"}
+
+	comment := models.SanitizerPolicy{
+		Name:       models.PolicyComment,
+		Elements:   map[string][]string{"a": {"href"}},
+		URLSchemes: []string{"http", "https"},
+		LinkRel:    "nofollow ugc noopener",
+	}
+	for _, element := range textElements {
+		comment.Elements[element] = nil
+	}
+
+	plain := models.SanitizerPolicy{Name: models.PolicyPlainText, Elements: map[string][]string{}}
+
+	return map[string]models.SanitizerPolicy{rich.Name: rich, comment.Name: comment, plain.Name: plain}
+}
+
+// SanitizeHTML removes everything the policy does not allow and returns safe HTML together with
+// a description of what was removed. plain_text politikası tüm etiketleri kaldırır ve metni kaçışlamadan
+// düz metin olarak döndürür; bu alanlar gösterilirken kaçışlanmalıdır.
+func SanitizeHTML(input string, policy models.SanitizerPolicy) (string, []string) {
+	s := &htmlSanitizer{policy: policy, plain: policy.Name == models.PolicyPlainText, removed: map[string]int{}}
+	s.run(input)
+	return s.out.String(), s.report()
+}
+
+type htmlSanitizer struct {
+	policy  models.SanitizerPolicy
+	plain   bool // Çıktı HTML değil düz metindir
+	out     strings.Builder
+	open    []string       // Açık izinli etiketler, kapanışları dengelemek için
+	removed map[string]int // Kaldırılan öğe açıklaması -> adet
+}
+
+func (s *htmlSanitizer) run(input string) {
+	z := nethtml.NewTokenizer(strings.NewReader(input))
+	skipping, skipDepth := "", 0
+
+	for {
+		tokenType := z.Next()
+		if tokenType == nethtml.ErrorToken {
+			if z.Err() != io.EOF {
+				s.remove("malformed markup")
+			}
+			break
+		}
+		token := z.Token()
+
+		// İçeriği atılan bir etiketin içindeyiz
+		if skipping != "" {
+			switch {
+			case tokenType == nethtml.StartTagToken && token.Data == skipping:
+				skipDepth++
+			case tokenType == nethtml.EndTagToken && token.Data == skipping:
+				if skipDepth--; skipDepth == 0 {
+					skipping = ""
+				}
+			}
+			continue
+		}
+
+		switch tokenType {
+		case nethtml.TextToken:
+			if s.plain {
+				s.out.WriteString(token.Data)
+				continue
+			}
+			s.out.WriteString(html.EscapeString(token.Data))
+		case nethtml.CommentToken:
+			s.remove("comment")
+		case nethtml.DoctypeToken:
+			s.remove("doctype")
+		case nethtml.StartTagToken, nethtml.SelfClosingTagToken:
+			allowed, ok := s.policy.Elements[token.Data]
+			if !ok || s.plain {
+				s.remove("<" + token.Data + "> element")
+				if sanitizerDropContent[token.Data] && tokenType == nethtml.StartTagToken {
+					skipping, skipDepth = token.Data, 1
+				}
+				continue
+			}
+			s.writeStartTag(token, allowed)
+			if tokenType == nethtml.StartTagToken && !sanitizerVoidElements[token.Data] {
+				s.open = append(s.open, token.Data)
+			}
+		case nethtml.EndTagToken:
+			s.closeTag(token.Data)
+		}
+	}
+
+	for i := len(s.open) - 1; i >= 0; i-- {
+		s.out.WriteString("</" + s.open[i] + ">")
+	}
+}
+
+func (s *htmlSanitizer) writeStartTag(token nethtml.Token, allowed []string) {
+	s.out.WriteString("<" + token.Data)
+	for _, attr := range token.Attr {
+		name := strings.ToLower(attr.Key)
+		switch {
+		case attr.Namespace != "" || strings.HasPrefix(name, "on") || name == "style" ||
+			(!containsValue(allowed, name) && !containsValue(s.policy.GlobalAttributes, name)):
+			s.remove(name + " attribute on <" + token.Data + ">")
+			continue
+		case name == "rel" && token.Data == "a" && s.policy.LinkRel != "":
+			continue // Aşağıda politikadaki değerle yazılır
+		case sanitizerURLAttributes[name] && !s.allowedURL(attr.Val):
+			s.remove("unsafe URL in " + name + " on <" + token.Data + ">")
+			continue
+		}
+		s.out.WriteString(" " + name + `="` + html.EscapeString(attr.Val) + `"`)
+	}
+	if token.Data == "a" && s.policy.LinkRel != "" {
+		s.out.WriteString(` rel="` + html.EscapeString(s.policy.LinkRel) + `"`)
+	}
+	s.out.WriteString(">")
+}
+
+func (s *htmlSanitizer) closeTag(name string) {
+	for i := len(s.open) - 1; i >= 0; i-- {
+		if s.open[i] != name {
+			continue
+		}
+		// Arada kapanmamış etiketler de kapatılır
+		for j := len(s.open) - 1; j >= i; j-- {
+			s.out.WriteString("</" + s.open[j] + ">")
+		}
+		s.open = s.open[:i]
+		return
+	}
+}
+
+// allowedURL checks the scheme of a URL attribute against the policy
+func (s *htmlSanitizer) allowedURL(raw string) bool {
+	// Tarayıcıların yok saydığı boşluk ve kontrol karakterleri şemayı gizlemek için kullanılabilir
+	cleaned := strings.Map(func(r rune) rune {
+		if r <= ' ' || r == 0x7f {
+			return -1
+		}
+		return r
+	}, raw)
+	parsed, err := url.Parse(cleaned)
+	if err != nil {
+		return false
+	}
+	if parsed.Scheme == "" {
+		return s.policy.AllowRelativeURLs && !strings.HasPrefix(cleaned, "//")
+	}
+	return containsValue(s.policy.URLSchemes, strings.ToLower(parsed.Scheme))
+}
+
+func (s *htmlSanitizer) remove(description string) {
+	s.removed[description]++
+}
+
+func (s *htmlSanitizer) report() []string {
+	if len(s.removed) == 0 {
+		return nil
+	}
+	items := make([]string, 0, len(s.removed))
+	for description, count := range s.removed {
+		if count > 1 {
+			description = fmt.Sprintf("%s (%d)", description, count)
+		}
+		items = append(items, description)
+	}
+	sort.Strings(items)
+	return items
+}
@@ -0,0 +1,52 @@
+package utils
+
+import (
+	"admin-panel/models"
+	"reflect"
+	"testing"
+)
+
+func TestSanitizeHTMLRichText(t *testing.T) {
+	policy := DefaultSanitizerPolicies()[models.PolicyRichText]
+	input := `<p onclick="x()">Hi <b>there</b><script>alert(1)</script></p><a href=" java&#x09;script:alert(1)">x</a><img src="/uploads/a.png" style="color:red"><!-- note --><font>text</font>`
+
+	result, removed := SanitizeHTML(input, policy)
+	expected := `<p>Hi <b>there</b></p><a>x</a><img src="/uploads/a.png">text`
+	if result != expected {
+		t.Errorf("SanitizeHTML failed: expected %s, got %s", expected, result)
+	}
+
+	expectedRemoved := []string{
+		"<font> element", "<script> element", "comment", "onclick attribute on <p>",
+		"style attribute on <img>", "unsafe URL in href on <a>",
+	}
+	if !reflect.DeepEqual(removed, expectedRemoved) {
+		t.Errorf("SanitizeHTML report failed: expected %v, got %v", expectedRemoved, removed)
+	}
+}
+
+func TestSanitizeHTMLComment(t *testing.T) {
+	policy := DefaultSanitizerPolicies()[models.PolicyComment]
+
+	result, _ := SanitizeHTML(`<h1>Big</h1> <a href="https://example.com" rel="dofollow">link</a> <em>unclosed`, policy)
+	expected := `Big <a href="https://example.com" rel="nofollow ugc noopener">link</a> <em>unclosed</em>`
+	if result != expected {
+		t.Errorf("SanitizeHTML failed: expected %s, got %s", expected, result)
+	}
+}
+
+func TestSanitizeHTMLPlainText(t *testing.T) {
+	policy := DefaultSanitizerPolicies()[models.PolicyPlainText]
+
+	// Etiketler kaldırılır, metin kaçışlanmadan saklanır
+	result, removed := SanitizeHTML(`Fiyat < 5 &amp; <b>acil</b> & "hızlı"`, policy)
+	if result != `Fiyat < 5 & acil & "hızlı"` || len(removed) != 1 {
+		t.Errorf("SanitizeHTML failed: got %s %v", result, removed)
+	}
+
+	// Temizlenmiş içerik tekrar temizlendiğinde değişmez
+	again, removed := SanitizeHTML(result, policy)
+	if again != result || len(removed) != 0 {
+		t.Errorf("SanitizeHTML is not idempotent: got %s %v", again, removed)
+	}
+}
@@ -0,0 +1,141 @@
+package utils
+
+import (
+	"html"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+var htmlTagPattern = regexp.MustCompile(`<[^>]*>`)
+
+// StripHTML removes tags and collapses whitespace, returning plain text
+func StripHTML(content string) string {
+	text := htmlTagPattern.ReplaceAllString(content, " ")
+	text = html.UnescapeString(text)
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// SearchTerms splits a search query into lowercase terms, ignoring quotes and negations
+func SearchTerms(query string) []string {
+	terms := []string{}
+	seen := map[string]bool{}
+	for _, field := range strings.FieldsFunc(query, func(r rune) bool {
+		return unicode.IsSpace(r) || r == '"'
+	}) {
+		if strings.HasPrefix(field, "-") {
+			continue
+		}
+		term := string(lowerRunes(strings.Trim(field, ".,;:!?()[]{}")))
+		if term != "" && !seen[term] {
+			seen[term] = true
+			terms = append(terms, term)
+		}
+	}
+	return terms
+}
+
+// HighlightSnippet returns a window of text around the first matching term, with all terms wrapped in <mark>.
+// Metin HTML-escape edilir; yalnızca <mark> etiketleri eklenir.
+func HighlightSnippet(text string, terms []string, radius int) string {
+	runes := []rune(text)
+	lower := lowerRunes(text)
+
+	// Terimlerin ilk geçtiği yer
+	first := -1
+	for _, term := range terms {
+		if idx := indexRunes(lower, []rune(term)); idx >= 0 && (first < 0 || idx < first) {
+			first = idx
+		}
+	}
+
+	start, end := 0, len(runes)
+	if first >= 0 {
+		start = first - radius
+		end = first + radius
+	} else {
+		end = 2 * radius
+	}
+	if start < 0 {
+		start = 0
+	}
+	if end > len(runes) {
+		end = len(runes)
+	}
+
+	// Kelime ortasından başlamamak için boşluğa hizala
+	for start > 0 && !unicode.IsSpace(runes[start-1]) {
+		start--
+	}
+	for end < len(runes) && !unicode.IsSpace(runes[end]) {
+		end++
+	}
+
+	window := runes[start:end]
+	windowLower := lower[start:end]
+
+	// Eşleşen aralıkları işaretle
+	marked := make([]bool, len(window))
+	for _, term := range terms {
+		termRunes := []rune(term)
+		if len(termRunes) == 0 {
+			continue
+		}
+		for offset := 0; offset+len(termRunes) <= len(window); {
+			idx := indexRunes(windowLower[offset:], termRunes)
+			if idx < 0 {
+				break
+			}
+			for i := offset + idx; i < offset+idx+len(termRunes); i++ {
+				marked[i] = true
+			}
+			offset += idx + len(termRunes)
+		}
+	}
+
+	var b strings.Builder
+	if start > 0 {
+		b.WriteString("…")
+	}
+	for i := 0; i < len(window); i++ {
+		if marked[i] && (i == 0 || !marked[i-1]) {
+			b.WriteString("<mark>")
+		}
+		b.WriteString(html.EscapeString(string(window[i])))
+		if marked[i] && (i == len(window)-1 || !marked[i+1]) {
+			b.WriteString("</mark>")
+		}
+	}
+	if end < len(runes) {
+		b.WriteString("…")
+	}
+	return b.String()
+}
+
+// lowerRunes lowercases rune by rune so indexes stay aligned with the original text
+func lowerRunes(s string) []rune {
+	runes := []rune(s)
+	for i, r := range runes {
+		runes[i] = unicode.ToLower(r)
+	}
+	return runes
+}
+
+func indexRunes(haystack, needle []rune) int {
+	if len(needle) == 0 {
+		return -1
+	}
+	for i := 0; i+len(needle) <= len(haystack); i++ {
+		match := true
+		for j := range needle {
+			if haystack[i+j] != needle[j] {
+				match = false
+				break
+			}
+		}
+		if match {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,20 @@
+package utils
+
+import "testing"
+
+func TestSearchTerms(t *testing.T) {
+	terms := SearchTerms(`Go "MongoDB" -java go`)
+	if len(terms) != 2 || terms[0] != "go" || terms[1] != "mongodb" {
+		t.Errorf("SearchTerms failed: expected [go mongodb], got %v", terms)
+	}
+}
+
+func TestHighlightSnippet(t *testing.T) {
+	text := StripHTML("<p>Gin is a web framework written in <b>Go</b></p>")
+	expected := "Gin is a web framework written in <mark>Go</mark>"
+	result := HighlightSnippet(text, []string{"go"}, 100)
+
+	if result != expected {
+		t.Errorf("HighlightSnippet failed: expected %s, got %s", expected, result)
+	}
+}
@@ -0,0 +1,328 @@
+package utils
+
+import (
+	"admin-panel/models"
+	"fmt"
+	"math"
+	"net/url"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	nethtml "golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// SEO denetimi sınırları
+const (
+	SEOTitleMinLength       = 30
+	SEOTitleMaxLength       = 60
+	SEODescriptionMinLength = 120
+	SEOMinWordCount         = 300
+	SEOMaxSentenceLength    = 20 // Ortalama cümle uzunluğu (kelime)
+	seoLongSentence         = 25 // Bu sayıdan uzun cümleler uzun sayılır
+	seoMaxLongSentences     = 25 // Uzun cümlelerin azami yüzdesi
+)
+
+// Kontrollerin puandaki ağırlıkları
+var seoAuditWeights = map[string]int{
+	"title":           3,
+	"description":     3,
+	"meta_tags":       1,
+	"duplicate_title": 2,
+	"duplicate_slug":  2,
+	"headings":        2,
+	"image_alt":       2,
+	"links":           1,
+	"keyword":         3,
+	"content_length":  2,
+	"readability":     2,
+}
+
+// seoOutline is what the audit reads from an HTML body
+type seoOutline struct {
+	headings         []int
+	images           int
+	imagesWithoutAlt int
+	internalLinks    int
+	externalLinks    int
+	paragraphs       []string
+}
+
+// AuditSEO checks a localized post or page and returns the findings with a 0-100 score.
+// Her kontrol ağırlığı oranında puana katılır: good tam, warning yarım, error sıfır puan.
+func AuditSEO(input models.SEOAuditInput) models.SEOAudit {
+	outline := outlineHTML(input.Content, input.SiteURL)
+	text := strings.Join(outline.paragraphs, "\n")
+	words := len(strings.Fields(StripHTML(input.Content)))
+	avgSentence, longPercent := sentenceStats(outline.paragraphs)
+
+	audit := models.SEOAudit{
+		Lang:     input.Lang,
+		Keyword:  input.Keyword,
+		Findings: []models.SEOFinding{},
+		Stats: models.SEOAuditStats{
+			TitleLength:         utf8.RuneCountInString(input.Title),
+			DescriptionLength:   utf8.RuneCountInString(input.Description),
+			WordCount:           words,
+			Headings:            len(outline.headings),
+			Images:              outline.images,
+			ImagesWithoutAlt:    outline.imagesWithoutAlt,
+			InternalLinks:       outline.internalLinks,
+			ExternalLinks:       outline.externalLinks,
+			AvgSentenceLength:   avgSentence,
+			LongSentencePercent: longPercent,
+		},
+	}
+	add := func(check, status, format string, args ...interface{}) {
+		audit.Findings = append(audit.Findings, models.SEOFinding{Check: check, Status: status, Message: fmt.Sprintf(format, args...)})
+	}
+	stats := audit.Stats
+
+	switch {
+	case stats.TitleLength == 0:
+		add("title", models.AuditError, "Add a title")
+	case stats.TitleLength < SEOTitleMinLength:
+		add("title", models.AuditWarning, "Title is short (%d characters); use %d-%d characters", stats.TitleLength, SEOTitleMinLength, SEOTitleMaxLength)
+	case stats.TitleLength > SEOTitleMaxLength:
+		add("title", models.AuditWarning, "Title is long (%d characters) and may be truncated in search results; use at most %d", stats.TitleLength, SEOTitleMaxLength)
+	default:
+		add("title", models.AuditGood, "Title length is good (%d characters)", stats.TitleLength)
+	}
+
+	switch {
+	case stats.DescriptionLength == 0:
+		add("description", models.AuditError, "Add a meta description or an introduction paragraph")
+	case stats.DescriptionLength < SEODescriptionMinLength:
+		add("description", models.AuditWarning, "Description is short (%d characters); use %d-%d characters", stats.DescriptionLength, SEODescriptionMinLength, SEODescriptionLength)
+	case stats.DescriptionLength > SEODescriptionLength:
+		add("description", models.AuditWarning, "Description is long (%d characters) and will be truncated; use at most %d", stats.DescriptionLength, SEODescriptionLength)
+	default:
+		add("description", models.AuditGood, "Description length is good (%d characters)", stats.DescriptionLength)
+	}
+
+	var missing []string
+	if strings.TrimSpace(input.MetaTitle) == "" {
+		missing = append(missing, "meta title")
+	}
+	if strings.TrimSpace(input.MetaDescription) == "" {
+		missing = append(missing, "meta description")
+	}
+	if len(missing) > 0 {
+		add("meta_tags", models.AuditWarning, "Set the %s for this language; fallback values are used", strings.Join(missing, " and "))
+	} else {
+		add("meta_tags", models.AuditGood, "Meta title and description are set")
+	}
+
+	if len(input.DuplicateTitles) > 0 {
+		add("duplicate_title", models.AuditError, "Title is also used by %s; use a unique title", strings.Join(input.DuplicateTitles, ", "))
+	} else {
+		add("duplicate_title", models.AuditGood, "Title is unique")
+	}
+	if len(input.DuplicateSlugs) > 0 {
+		add("duplicate_slug", models.AuditError, "Slug is also used by %s; change the slug", strings.Join(input.DuplicateSlugs, ", "))
+	} else {
+		add("duplicate_slug", models.AuditGood, "Slug is unique")
+	}
+
+	auditHeadings(outline.headings, words, add)
+
+	switch {
+	case outline.imagesWithoutAlt > 0:
+		add("image_alt", models.AuditWarning, "%d of %d images have no alt text; describe them for accessibility and image search", outline.imagesWithoutAlt, outline.images)
+	case outline.images == 0:
+		add("image_alt", models.AuditGood, "No images to check")
+	default:
+		add("image_alt", models.AuditGood, "All images have alt text")
+	}
+
+	if outline.internalLinks == 0 {
+		add("links", models.AuditWarning, "Add links to related content on the site (%d external links)", outline.externalLinks)
+	} else {
+		add("links", models.AuditGood, "%d internal and %d external links", outline.internalLinks, outline.externalLinks)
+	}
+
+	auditKeyword(input, outline.paragraphs, add)
+
+	switch {
+	case words == 0:
+		add("content_length", models.AuditError, "Add body content")
+	case words < SEOMinWordCount:
+		add("content_length", models.AuditWarning, "Content is thin (%d words); aim for at least %d", words, SEOMinWordCount)
+	default:
+		add("content_length", models.AuditGood, "Content length is good (%d words)", words)
+	}
+
+	switch {
+	case text == "":
+		add("readability", models.AuditWarning, "No paragraphs to analyze")
+	case avgSentence > SEOMaxSentenceLength || longPercent > seoMaxLongSentences:
+		add("readability", models.AuditWarning, "Sentences are long (%.1f words on average, %.0f%% over %d words); split them up", avgSentence, longPercent, seoLongSentence)
+	default:
+		add("readability", models.AuditGood, "Sentences are easy to read (%.1f words on average)", avgSentence)
+	}
+
+	total, earned := 0.0, 0.0
+	for _, finding := range audit.Findings {
+		weight := float64(seoAuditWeights[finding.Check])
+		total += weight
+		switch finding.Status {
+		case models.AuditGood:
+			earned += weight
+		case models.AuditWarning:
+			earned += weight / 2
+		}
+	}
+	if total > 0 {
+		audit.Score = int(math.Round(earned / total * 100))
+	}
+	return audit
+}
+
+// auditHeadings checks the heading structure; başlık sayfada H1 olarak yazıldığından gövde H2 ile başlamalıdır
+func auditHeadings(headings []int, words int, add func(check, status, format string, args ...interface{})) {
+	h1 := 0
+	previous := 1
+	for _, level := range headings {
+		if level == 1 {
+			h1++
+		}
+		if level > previous+1 {
+			add("headings", models.AuditWarning, "Heading levels skip from H%d to H%d; do not skip levels", previous, level)
+			return
+		}
+		previous = level
+	}
+
+	switch {
+	case h1 > 0:
+		add("headings", models.AuditWarning, "Body contains %d H1 headings; the title is the H1, use H2-H6 in the body", h1)
+	case len(headings) == 0 && words >= SEOMinWordCount:
+		add("headings", models.AuditWarning, "Add subheadings to structure the content")
+	default:
+		add("headings", models.AuditGood, "Heading structure is good")
+	}
+}
+
+// auditKeyword checks the focus keyword in the title, the slug and the first paragraph
+func auditKeyword(input models.SEOAuditInput, paragraphs []string, add func(check, status, format string, args ...interface{})) {
+	keyword := strings.TrimSpace(input.Keyword)
+	if keyword == "" {
+		add("keyword", models.AuditWarning, "Set a focus keyword (the first meta keyword is used)")
+		return
+	}
+
+	firstParagraph := ""
+	if len(paragraphs) > 0 {
+		firstParagraph = paragraphs[0]
+	}
+	keywordSlug := input.KeywordSlug
+	if keywordSlug == "" {
+		keywordSlug = GenerateSlug(keyword)
+	}
+
+	var missing []string
+	if indexRunes(lowerRunes(input.Title), lowerRunes(keyword)) < 0 {
+		missing = append(missing, "title")
+	}
+	if !strings.Contains(input.Slug, keywordSlug) {
+		missing = append(missing, "slug")
+	}
+	if indexRunes(lowerRunes(firstParagraph), lowerRunes(keyword)) < 0 {
+		missing = append(missing, "first paragraph")
+	}
+
+	switch len(missing) {
+	case 0:
+		add("keyword", models.AuditGood, "Focus keyword %q appears in the title, slug and first paragraph", keyword)
+	case 3:
+		add("keyword", models.AuditError, "Focus keyword %q does not appear in the title, slug or first paragraph", keyword)
+	default:
+		add("keyword", models.AuditWarning, "Use the focus keyword %q in the %s", keyword, strings.Join(missing, " and "))
+	}
+}
+
+// outlineHTML collects headings, images, links and paragraph texts of an HTML body
+func outlineHTML(content, siteURL string) seoOutline {
+	var outline seoOutline
+	nodes, err := nethtml.ParseFragment(strings.NewReader(content), &nethtml.Node{Type: nethtml.ElementNode, Data: "body", DataAtom: atom.Body})
+	if err != nil {
+		return outline
+	}
+
+	siteHost := ""
+	if parsed, err := url.Parse(siteURL); err == nil {
+		siteHost = parsed.Host
+	}
+
+	var walk func(*nethtml.Node)
+	walk = func(node *nethtml.Node) {
+		if node.Type == nethtml.ElementNode {
+			switch node.DataAtom {
+			case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6:
+				outline.headings = append(outline.headings, int(node.Data[1]-'0'))
+			case atom.Img:
+				outline.images++
+				if strings.TrimSpace(attr(node, "alt")) == "" {
+					outline.imagesWithoutAlt++
+				}
+			case atom.A:
+				href := attr(node, "href")
+				parsed, err := url.Parse(href)
+				switch {
+				case href == "" || strings.HasPrefix(href, "#") || err != nil:
+				case parsed.Scheme == "mailto" || parsed.Scheme == "tel":
+				case parsed.Host == "" || parsed.Host == siteHost:
+					outline.internalLinks++
+				default:
+					outline.externalLinks++
+				}
+			case atom.P, atom.Li:
+				if text := strings.Join(strings.Fields(textContent(node)), " "); text != "" {
+					outline.paragraphs = append(outline.paragraphs, text)
+				}
+			}
+		}
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+	}
+	for _, node := range nodes {
+		walk(node)
+	}
+
+	// Paragraf etiketi olmayan düz metin gövdeler satır bazında okunur
+	if len(outline.paragraphs) == 0 {
+		for _, line := range strings.Split(StripHTML(content), "\n") {
+			if line = strings.Join(strings.Fields(line), " "); line != "" {
+				outline.paragraphs = append(outline.paragraphs, line)
+			}
+		}
+	}
+	return outline
+}
+
+// sentenceStats returns the average sentence length in words and the percentage of long sentences
+func sentenceStats(paragraphs []string) (float64, float64) {
+	sentences, words, long := 0, 0, 0
+	for _, paragraph := range paragraphs {
+		for _, sentence := range strings.FieldsFunc(paragraph, func(r rune) bool {
+			return r == '.' || r == '!' || r == '?' || r == '…' || r == ';'
+		}) {
+			count := len(strings.FieldsFunc(sentence, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsNumber(r) }))
+			if count == 0 {
+				continue
+			}
+			sentences++
+			words += count
+			if count > seoLongSentence {
+				long++
+			}
+		}
+	}
+	if sentences == 0 {
+		return 0, 0
+	}
+	round := func(value float64) float64 { return math.Round(value*10) / 10 }
+	return round(float64(words) / float64(sentences)), round(float64(long) / float64(sentences) * 100)
+}
@@ -0,0 +1,109 @@
+package utils
+
+import (
+	"admin-panel/models"
+	"strings"
+	"testing"
+)
+
+func auditFinding(audit models.SEOAudit, check string) models.SEOFinding {
+	for _, finding := range audit.Findings {
+		if finding.Check == check {
+			return finding
+		}
+	}
+	return models.SEOFinding{}
+}
+
+func TestAuditSEOGoodContent(t *testing.T) {
+	paragraph := "<p>" + strings.Repeat("Go ile hızlı bir site kurmak kolaydır. ", 22) + "</p>"
+	input := models.SEOAuditInput{
+		Lang:            "tr",
+		SiteURL:         "https://example.com",
+		Title:           "Go ile çok dilli site kurulumu rehberi",
+		Description:     strings.Repeat("Açıklama ", 15),
+		MetaTitle:       "Go ile çok dilli site kurulumu rehberi",
+		MetaDescription: "Açıklama",
+		Slug:            "go-ile-cok-dilli-site",
+		Content: `<p>Go ile başlamak için bu rehberi okuyun, <a href="/tr/kurulum">kurulum</a> ve <a href="https://go.dev">Go</a>.</p>` +
+			`<h2>Kurulum</h2>` + paragraph + `<h3>Ayrıntılar</h3>` + paragraph + `<img src="/uploads/a.png" alt="Ekran">`,
+		Keyword: "Go",
+	}
+	audit := AuditSEO(input)
+
+	for _, finding := range audit.Findings {
+		if finding.Status != models.AuditGood {
+			t.Errorf("%s: expected good, got %s (%s)", finding.Check, finding.Status, finding.Message)
+		}
+	}
+	if audit.Score != 100 {
+		t.Errorf("expected score 100, got %d", audit.Score)
+	}
+	if audit.Stats.InternalLinks != 1 || audit.Stats.ExternalLinks != 1 || audit.Stats.Images != 1 || audit.Stats.Headings != 2 {
+		t.Errorf("unexpected stats: %+v", audit.Stats)
+	}
+}
+
+func TestAuditSEOFindings(t *testing.T) {
+	long := strings.Repeat("kelime ", 30)
+	input := models.SEOAuditInput{
+		Lang:            "en",
+		SiteURL:         "https://example.com",
+		Title:           "Short",
+		Slug:            "short",
+		Content:         `<h1>Title</h1><h3>Skipped</h3><p>` + long + `.</p><img src="/a.png"><img src="/b.png" alt=" ">`,
+		Keyword:         "Gopher",
+		DuplicateTitles: []string{"pages:1"},
+		DuplicateSlugs:  []string{"posts:2"},
+	}
+	audit := AuditSEO(input)
+
+	expected := map[string]string{
+		"title":           models.AuditWarning,
+		"description":     models.AuditError,
+		"meta_tags":       models.AuditWarning,
+		"duplicate_title": models.AuditError,
+		"duplicate_slug":  models.AuditError,
+		"headings":        models.AuditWarning,
+		"image_alt":       models.AuditWarning,
+		"links":           models.AuditWarning,
+		"keyword":         models.AuditError,
+		"content_length":  models.AuditWarning,
+		"readability":     models.AuditWarning,
+	}
+	for check, status := range expected {
+		if got := auditFinding(audit, check); got.Status != status {
+			t.Errorf("%s: expected %s, got %s (%s)", check, status, got.Status, got.Message)
+		}
+	}
+	if !strings.Contains(auditFinding(audit, "image_alt").Message, "2 of 2") {
+		t.Errorf("unexpected alt message: %s", auditFinding(audit, "image_alt").Message)
+	}
+	if !strings.Contains(auditFinding(audit, "headings").Message, "H1 to H3") {
+		t.Errorf("unexpected heading message: %s", auditFinding(audit, "headings").Message)
+	}
+	if audit.Score <= 0 || audit.Score >= 50 {
+		t.Errorf("expected a low score, got %d", audit.Score)
+	}
+}
+
+func TestAuditSEOKeywordPartial(t *testing.T) {
+	audit := AuditSEO(models.SEOAuditInput{
+		Title:       "Çok dilli içerik yönetimi",
+		Slug:        "cok-dilli-icerik",
+		Content:     "<p>Bu yazı yönetim panelini anlatır.</p>",
+		Keyword:     "İçerik",
+		KeywordSlug: "icerik",
+	})
+	finding := auditFinding(audit, "keyword")
+	if finding.Status != models.AuditWarning || !strings.Contains(finding.Message, "first paragraph") || strings.Contains(finding.Message, "title") {
+		t.Errorf("unexpected keyword finding: %+v", finding)
+	}
+}
+
+func TestSentenceStats(t *testing.T) {
+	avg, long := sentenceStats([]string{"Bir iki üç. Dört beş!", strings.Repeat("a ", 30) + "."})
+	if avg != 11.7 || long != 33.3 {
+		t.Errorf("unexpected stats: %v %v", avg, long)
+	}
+}
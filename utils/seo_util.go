@@ -0,0 +1,234 @@
+package utils
+
+import (
+	"admin-panel/models"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	nethtml "golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// Arama motorlarının gösterdiği açıklama uzunluğu
+const SEODescriptionLength = 160
+
+const schemaContext = "https://schema.org"
+
+// BuildSEOMetadata builds the canonical URL, hreflang links, OpenGraph and Twitter Card tags and
+// schema.org JSON-LD (Article/WebPage, BreadcrumbList, Organization) of a resolved source
+func BuildSEOMetadata(source models.SEOSource) *models.SEOMetadata {
+	canonical := absoluteURL(source.SiteURL, source.Path)
+	description := Excerpt(strings.Join(strings.Fields(source.Description), " "), SEODescriptionLength)
+
+	meta := &models.SEOMetadata{
+		Lang:        source.Lang,
+		Title:       source.Title,
+		Description: description,
+		Keywords:    source.Keywords,
+		Canonical:   canonical,
+		Alternates:  []models.SEOAlternate{},
+		OpenGraph:   []models.SEOProperty{},
+		Twitter:     []models.SEOProperty{},
+	}
+	if source.Image != nil && source.Image.URL != "" {
+		image := *source.Image
+		image.URL = absoluteURL(source.SiteURL, image.URL)
+		meta.Image = &image
+	}
+
+	// Çıktı kararlı olsun diye diller sıralanır; x-default en sona yazılır
+	langs := make([]string, 0, len(source.Alternates))
+	for lang := range source.Alternates {
+		if lang != "x-default" {
+			langs = append(langs, lang)
+		}
+	}
+	sort.Strings(langs)
+	if _, ok := source.Alternates["x-default"]; ok {
+		langs = append(langs, "x-default")
+	}
+	for _, lang := range langs {
+		meta.Alternates = append(meta.Alternates, models.SEOAlternate{HrefLang: lang, Href: absoluteURL(source.SiteURL, source.Alternates[lang])})
+	}
+
+	og := func(property, content string) {
+		if content != "" {
+			meta.OpenGraph = append(meta.OpenGraph, models.SEOProperty{Property: property, Content: content})
+		}
+	}
+	ogType := "website"
+	if source.Type == models.SEOTypePost {
+		ogType = "article"
+	}
+	og("og:type", ogType)
+	og("og:title", meta.Title)
+	og("og:description", description)
+	og("og:url", canonical)
+	og("og:site_name", source.SiteName)
+	og("og:locale", openGraphLocale(source.Lang))
+	for _, lang := range langs {
+		if lang != source.Lang && lang != "x-default" {
+			og("og:locale:alternate", openGraphLocale(lang))
+		}
+	}
+	if meta.Image != nil {
+		og("og:image", meta.Image.URL)
+		og("og:image:type", meta.Image.Type)
+		og("og:image:alt", meta.Image.Alt)
+	}
+	if source.Type == models.SEOTypePost {
+		if source.Published != nil {
+			og("article:published_time", seoTime(*source.Published))
+		}
+		og("article:modified_time", seoTime(source.Modified))
+		og("article:section", source.Section)
+		for _, tag := range source.Tags {
+			og("article:tag", tag)
+		}
+	}
+
+	twitter := func(name, content string) {
+		if content != "" {
+			meta.Twitter = append(meta.Twitter, models.SEOProperty{Property: name, Content: content})
+		}
+	}
+	if meta.Image != nil {
+		twitter("twitter:card", "summary_large_image")
+	} else {
+		twitter("twitter:card", "summary")
+	}
+	twitter("twitter:site", source.TwitterSite)
+	twitter("twitter:title", meta.Title)
+	twitter("twitter:description", description)
+	if meta.Image != nil {
+		twitter("twitter:image", meta.Image.URL)
+		twitter("twitter:image:alt", meta.Image.Alt)
+	}
+
+	meta.JSONLD = seoJSONLD(source, meta)
+	return meta
+}
+
+// seoJSONLD builds the schema.org objects of the content
+func seoJSONLD(source models.SEOSource, meta *models.SEOMetadata) []map[string]interface{} {
+	organization := map[string]interface{}{
+		"@type": "Organization",
+		"name":  source.SiteName,
+		"url":   source.SiteURL,
+	}
+	if source.Logo != "" {
+		organization["logo"] = absoluteURL(source.SiteURL, source.Logo)
+	}
+	if len(source.SameAs) > 0 {
+		organization["sameAs"] = source.SameAs
+	}
+
+	var content map[string]interface{}
+	switch source.Type {
+	case models.SEOTypePost:
+		content = map[string]interface{}{
+			"@type":            "Article",
+			"headline":         meta.Title,
+			"mainEntityOfPage": meta.Canonical,
+			"publisher":        organization,
+		}
+		if source.Published != nil {
+			content["datePublished"] = seoTime(*source.Published)
+		}
+		if source.Author != "" {
+			content["author"] = map[string]interface{}{"@type": "Person", "name": source.Author}
+		}
+		if source.Section != "" {
+			content["articleSection"] = source.Section
+		}
+		if len(source.Keywords) > 0 {
+			content["keywords"] = strings.Join(source.Keywords, ", ")
+		}
+	case models.SEOTypeCategory:
+		content = map[string]interface{}{"@type": "CollectionPage", "name": meta.Title}
+	default:
+		content = map[string]interface{}{"@type": "WebPage", "name": meta.Title}
+	}
+	content["@context"] = schemaContext
+	content["url"] = meta.Canonical
+	content["inLanguage"] = source.Lang
+	if meta.Description != "" {
+		content["description"] = meta.Description
+	}
+	if !source.Modified.IsZero() {
+		content["dateModified"] = seoTime(source.Modified)
+	}
+	if meta.Image != nil {
+		content["image"] = meta.Image.URL
+	}
+
+	items := make([]map[string]interface{}, 0, len(source.Breadcrumbs))
+	for i, crumb := range source.Breadcrumbs {
+		items = append(items, map[string]interface{}{
+			"@type":    "ListItem",
+			"position": i + 1,
+			"name":     crumb.Name,
+			"item":     absoluteURL(source.SiteURL, crumb.Path),
+		})
+	}
+	breadcrumbs := map[string]interface{}{"@context": schemaContext, "@type": "BreadcrumbList", "itemListElement": items}
+
+	// Yayıncı nesnesi yazının içinde de kullanıldığından kopyalanır
+	site := map[string]interface{}{"@context": schemaContext}
+	for key, value := range organization {
+		site[key] = value
+	}
+	return []map[string]interface{}{content, breadcrumbs, site}
+}
+
+// FirstImage returns the source, alternative text and media ID (data-media-id) of the first image in an HTML body
+func FirstImage(content string) (src, alt, mediaID string) {
+	nodes, err := nethtml.ParseFragment(strings.NewReader(content), &nethtml.Node{Type: nethtml.ElementNode, Data: "body", DataAtom: atom.Body})
+	if err != nil {
+		return "", "", ""
+	}
+	for _, node := range nodes {
+		if img := findElement(node, atom.Img); img != nil {
+			return attr(img, "src"), attr(img, "alt"), attr(img, "data-media-id")
+		}
+	}
+	return "", "", ""
+}
+
+// TwitterHandle returns the @handle of a Twitter/X profile URL
+func TwitterHandle(profileURL string) string {
+	parsed, err := url.Parse(profileURL)
+	if err != nil {
+		return ""
+	}
+	handle := strings.Trim(parsed.Path, "/")
+	if handle == "" || strings.Contains(handle, "/") {
+		return ""
+	}
+	return "@" + strings.TrimPrefix(handle, "@")
+}
+
+// absoluteURL prefixes site paths with the site address; mutlak adresler olduğu gibi döner
+func absoluteURL(siteURL, path string) string {
+	if path == "" || strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://") {
+		return path
+	}
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	return siteURL + (&url.URL{Path: path}).EscapedPath()
+}
+
+// openGraphLocale converts a language code to the OpenGraph locale format (örn: de-AT -> de_AT)
+func openGraphLocale(lang string) string {
+	return strings.ReplaceAll(lang, "-", "_")
+}
+
+func seoTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,153 @@
+package utils
+
+import (
+	"admin-panel/models"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testSEOSource() models.SEOSource {
+	published := time.Date(2025, 3, 1, 9, 30, 0, 0, time.UTC)
+	return models.SEOSource{
+		Type:        models.SEOTypePost,
+		Lang:        "tr",
+		SiteURL:     "https://example.com",
+		SiteName:    "KWB",
+		Title:       "İlk yazı",
+		Description: "  Kısa   özet\n metni ",
+		Keywords:    []string{"go", "cms"},
+		Path:        "/tr/ilk-yazı",
+		Alternates:  map[string]string{"tr": "/tr/ilk-yazı", "en": "/en/first-post", "x-default": "/tr/ilk-yazı"},
+		Image:       &models.SEOImage{URL: "/uploads/kapak.jpg", Alt: "Kapak", Type: "image/jpeg"},
+		Logo:        "/uploads/logo.png",
+		SameAs:      []string{"https://x.com/kwb"},
+		TwitterSite: "@kwb",
+		Author:      "Ada Yılmaz",
+		Section:     "Duyurular",
+		Tags:        []string{"golang"},
+		Breadcrumbs: []models.SEOBreadcrumb{{Name: "KWB", Path: "/tr"}, {Name: "Duyurular", Path: "/tr/category/duyurular"}, {Name: "İlk yazı", Path: "/tr/ilk-yazı"}},
+		Published:   &published,
+		Modified:    published.Add(time.Hour),
+	}
+}
+
+func seoProperty(properties []models.SEOProperty, name string) []string {
+	var values []string
+	for _, property := range properties {
+		if property.Property == name {
+			values = append(values, property.Content)
+		}
+	}
+	return values
+}
+
+func TestBuildSEOMetadataPost(t *testing.T) {
+	meta := BuildSEOMetadata(testSEOSource())
+
+	if meta.Canonical != "https://example.com/tr/ilk-yaz%C4%B1" {
+		t.Errorf("unexpected canonical: %s", meta.Canonical)
+	}
+	if meta.Description != "Kısa özet metni" {
+		t.Errorf("description should be normalized, got %q", meta.Description)
+	}
+	if meta.Image == nil || meta.Image.URL != "https://example.com/uploads/kapak.jpg" {
+		t.Errorf("image should be absolute, got %+v", meta.Image)
+	}
+
+	var hreflangs []string
+	for _, alternate := range meta.Alternates {
+		hreflangs = append(hreflangs, alternate.HrefLang)
+	}
+	if strings.Join(hreflangs, ",") != "en,tr,x-default" {
+		t.Errorf("unexpected hreflang order: %v", hreflangs)
+	}
+
+	expected := map[string]string{
+		"og:type":                "article",
+		"og:url":                 meta.Canonical,
+		"og:locale":              "tr",
+		"og:locale:alternate":    "en",
+		"og:image":               "https://example.com/uploads/kapak.jpg",
+		"article:published_time": "2025-03-01T09:30:00Z",
+		"article:section":        "Duyurular",
+		"article:tag":            "golang",
+	}
+	for property, value := range expected {
+		if got := seoProperty(meta.OpenGraph, property); len(got) != 1 || got[0] != value {
+			t.Errorf("%s: expected %q, got %v", property, value, got)
+		}
+	}
+	if got := seoProperty(meta.Twitter, "twitter:card"); len(got) != 1 || got[0] != "summary_large_image" {
+		t.Errorf("unexpected twitter card: %v", got)
+	}
+	if got := seoProperty(meta.Twitter, "twitter:site"); len(got) != 1 || got[0] != "@kwb" {
+		t.Errorf("unexpected twitter site: %v", got)
+	}
+
+	if len(meta.JSONLD) != 3 {
+		t.Fatalf("expected Article, BreadcrumbList and Organization, got %d objects", len(meta.JSONLD))
+	}
+	article, breadcrumbs, organization := meta.JSONLD[0], meta.JSONLD[1], meta.JSONLD[2]
+	if article["@type"] != "Article" || article["datePublished"] != "2025-03-01T09:30:00Z" || article["keywords"] != "go, cms" {
+		t.Errorf("unexpected article: %v", article)
+	}
+	if _, ok := article["publisher"].(map[string]interface{})["@context"]; ok {
+		t.Error("nested publisher should not repeat @context")
+	}
+	items := breadcrumbs["itemListElement"].([]map[string]interface{})
+	if len(items) != 3 || items[1]["item"] != "https://example.com/tr/category/duyurular" || items[2]["position"] != 3 {
+		t.Errorf("unexpected breadcrumbs: %v", items)
+	}
+	if organization["@type"] != "Organization" || organization["logo"] != "https://example.com/uploads/logo.png" {
+		t.Errorf("unexpected organization: %v", organization)
+	}
+}
+
+func TestBuildSEOMetadataPageWithoutImage(t *testing.T) {
+	source := testSEOSource()
+	source.Type = models.SEOTypePage
+	source.Image = nil
+	source.Description = strings.Repeat("kelime ", 60)
+	meta := BuildSEOMetadata(source)
+
+	if meta.JSONLD[0]["@type"] != "WebPage" {
+		t.Errorf("expected WebPage, got %v", meta.JSONLD[0]["@type"])
+	}
+	if got := seoProperty(meta.OpenGraph, "og:type"); got[0] != "website" {
+		t.Errorf("expected website, got %v", got)
+	}
+	if len(seoProperty(meta.OpenGraph, "article:section")) != 0 {
+		t.Error("article properties should only be set for posts")
+	}
+	if got := seoProperty(meta.Twitter, "twitter:card"); got[0] != "summary" {
+		t.Errorf("expected summary card, got %v", got)
+	}
+	if len([]rune(meta.Description)) > SEODescriptionLength+1 {
+		t.Errorf("description should be shortened, got %d runes", len([]rune(meta.Description)))
+	}
+}
+
+func TestFirstImage(t *testing.T) {
+	src, alt, mediaID := FirstImage(`<p>Metin</p><figure><img src="/uploads/a.png" alt="A" data-media-id="65f0c0ffee0000000000abcd"></figure><img src="/b.png">`)
+	if src != "/uploads/a.png" || alt != "A" || mediaID != "65f0c0ffee0000000000abcd" {
+		t.Errorf("unexpected image: %q %q %q", src, alt, mediaID)
+	}
+	if src, _, _ := FirstImage("<p>Görsel yok</p>"); src != "" {
+		t.Errorf("expected no image, got %q", src)
+	}
+}
+
+func TestTwitterHandle(t *testing.T) {
+	cases := map[string]string{
+		"https://twitter.com/kwb":  "@kwb",
+		"https://x.com/@kwb/":      "@kwb",
+		"https://x.com/kwb/status": "",
+		"https://x.com":            "",
+	}
+	for input, expected := range cases {
+		if got := TwitterHandle(input); got != expected {
+			t.Errorf("TwitterHandle(%q) = %q, expected %q", input, got, expected)
+		}
+	}
+}
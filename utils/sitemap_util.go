@@ -0,0 +1,71 @@
+package utils
+
+import (
+	"admin-panel/models"
+	"encoding/xml"
+	"sort"
+	"time"
+)
+
+type sitemapIndex struct {
+	XMLName  xml.Name          `xml:"sitemapindex"`
+	Sitemaps []sitemapIndexRef `xml:"sitemap"`
+}
+
+type sitemapIndexRef struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+type sitemapURLSet struct {
+	XMLName xml.Name     `xml:"urlset"`
+	URLs    []sitemapURL `xml:"url"`
+}
+
+type sitemapURL struct {
+	Loc        string             `xml:"loc"`
+	LastMod    string             `xml:"lastmod,omitempty"`
+	Alternates []sitemapAlternate `xml:"xhtml:link"`
+}
+
+type sitemapAlternate struct {
+	Rel      string `xml:"rel,attr"`
+	HrefLang string `xml:"hreflang,attr"`
+	Href     string `xml:"href,attr"`
+}
+
+// RenderSitemapIndex renders a sitemap index listing the child sitemaps
+func RenderSitemapIndex(refs []models.SitemapRef) ([]byte, error) {
+	document := sitemapIndex{Sitemaps: []sitemapIndexRef{}}
+	for _, ref := range refs {
+		document.Sitemaps = append(document.Sitemaps, sitemapIndexRef{Loc: ref.Loc, LastMod: sitemapTime(ref.LastMod)})
+	}
+	return marshalXML(document, `<sitemapindex xmlns="http://www.sitemaps.org/schemas/sitemap/0.9"`)
+}
+
+// RenderSitemap renders a sitemap with hreflang alternates for every URL
+func RenderSitemap(urls []models.SitemapURL) ([]byte, error) {
+	document := sitemapURLSet{URLs: []sitemapURL{}}
+	for _, item := range urls {
+		element := sitemapURL{Loc: item.Loc, LastMod: sitemapTime(item.LastMod)}
+
+		// Çıktı kararlı olsun diye diller sıralanır
+		langs := make([]string, 0, len(item.Alternates))
+		for lang := range item.Alternates {
+			langs = append(langs, lang)
+		}
+		sort.Strings(langs)
+		for _, lang := range langs {
+			element.Alternates = append(element.Alternates, sitemapAlternate{Rel: "alternate", HrefLang: lang, Href: item.Alternates[lang]})
+		}
+		document.URLs = append(document.URLs, element)
+	}
+	return marshalXML(document, `<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9" xmlns:xhtml="http://www.w3.org/1999/xhtml"`)
+}
+
+func sitemapTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,73 @@
+package utils
+
+import (
+	"admin-panel/models"
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRenderSitemap(t *testing.T) {
+	body, err := RenderSitemap([]models.SitemapURL{{
+		Loc:     "https://example.com/tr/hakkimizda",
+		LastMod: time.Date(2025, 3, 1, 9, 30, 0, 0, time.FixedZone("TRT", 3*3600)),
+		Alternates: map[string]string{
+			"tr":        "https://example.com/tr/hakkimizda",
+			"en":        "https://example.com/en/about-us",
+			"x-default": "https://example.com/en/about-us",
+		},
+	}})
+	if err != nil {
+		t.Fatalf("RenderSitemap failed: %v", err)
+	}
+
+	output := string(body)
+	for _, expected := range []string{
+		`<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9" xmlns:xhtml="http://www.w3.org/1999/xhtml">`,
+		`<lastmod>2025-03-01T06:30:00Z</lastmod>`,
+		`<xhtml:link rel="alternate" hreflang="en" href="https://example.com/en/about-us"></xhtml:link>`,
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("RenderSitemap output is missing %q:\n%s", expected, output)
+		}
+	}
+	// Diller sıralı yazılır
+	if strings.Index(output, `hreflang="en"`) > strings.Index(output, `hreflang="tr"`) ||
+		strings.Index(output, `hreflang="tr"`) > strings.Index(output, `hreflang="x-default"`) {
+		t.Errorf("RenderSitemap alternates are not sorted:\n%s", output)
+	}
+
+	var document struct {
+		URLs []struct {
+			Loc string `xml:"loc"`
+		} `xml:"url"`
+	}
+	if err := xml.Unmarshal(body, &document); err != nil || len(document.URLs) != 1 || document.URLs[0].Loc != "https://example.com/tr/hakkimizda" {
+		t.Errorf("RenderSitemap output is not valid XML: %v %+v", err, document)
+	}
+}
+
+func TestRenderSitemapIndex(t *testing.T) {
+	body, err := RenderSitemapIndex([]models.SitemapRef{
+		{Loc: "https://example.com/sitemaps/posts-1.xml", LastMod: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{Loc: "https://example.com/sitemaps/tags-1.xml"},
+	})
+	if err != nil {
+		t.Fatalf("RenderSitemapIndex failed: %v", err)
+	}
+
+	var document struct {
+		XMLName  xml.Name `xml:"http://www.sitemaps.org/schemas/sitemap/0.9 sitemapindex"`
+		Sitemaps []struct {
+			Loc     string `xml:"loc"`
+			LastMod string `xml:"lastmod"`
+		} `xml:"sitemap"`
+	}
+	if err := xml.Unmarshal(body, &document); err != nil {
+		t.Fatalf("RenderSitemapIndex output is not valid XML: %v", err)
+	}
+	if len(document.Sitemaps) != 2 || document.Sitemaps[0].LastMod != "2025-03-01T00:00:00Z" || document.Sitemaps[1].LastMod != "" {
+		t.Errorf("RenderSitemapIndex produced an unexpected index: %+v", document)
+	}
+}
@@ -1,41 +1,89 @@
 package utils
 
 import (
-	"regexp"
 	"strings"
 )
 
+// Slug için varsayılan azami uzunluk (karakter)
+const DefaultSlugMaxLength = 80
+
+// SlugOptions configures slug generation for a language
+type SlugOptions struct {
+	Lang         string            // Dil kodu (örn: "de", "sr-Latn"); harf çevirisi tablosunu seçer
+	Replacements map[string]string // Dile özel ek değiştirmeler (örn: "&": "und")
+	StopWords    []string          // Slug'dan çıkarılacak kelimeler (örn: "the", "und")
+	MaxLength    int               // 0 ise DefaultSlugMaxLength
+}
+
 // GenerateSlug generates a URL-friendly slug from a given title
 func GenerateSlug(title string) string {
+	return GenerateLocalizedSlug(title, SlugOptions{})
+}
+
+// GenerateLocalizedSlug generates a URL-friendly slug using the transliteration rules of opts.Lang.
+// Kelimeler sınırında kısaltılır; stop-word'ler yalnızca geriye kelime kalıyorsa çıkarılır.
+func GenerateLocalizedSlug(title string, opts SlugOptions) string {
 	// Boş giriş için kontrol
 	if strings.TrimSpace(title) == "" {
 		return "default-slug"
 	}
 
-	// Büyük harfleri küçük harfe çevir
-	slug := strings.ToLower(title)
+	words := slugWords(Transliterate(title, opts.Lang, opts.Replacements))
 
-	// Türkçe karakterleri dönüştürme
-	trMap := map[string]string{
-		"ç": "c", "ğ": "g", "ı": "i", "ö": "o", "ş": "s", "ü": "u",
-	}
-	for k, v := range trMap {
-		slug = strings.ReplaceAll(slug, k, v)
+	// Stop-word'leri çıkar
+	if len(opts.StopWords) > 0 {
+		stop := map[string]bool{}
+		for _, word := range opts.StopWords {
+			for _, w := range slugWords(Transliterate(word, opts.Lang, opts.Replacements)) {
+				stop[w] = true
+			}
+		}
+		kept := make([]string, 0, len(words))
+		for _, word := range words {
+			if !stop[word] {
+				kept = append(kept, word)
+			}
+		}
+		if len(kept) > 0 {
+			words = kept
+		}
 	}
 
-	// Özel karakterleri ve noktalama işaretlerini kaldır
-	re := regexp.MustCompile(`[^a-z0-9\s-]`)
-	slug = re.ReplaceAllString(slug, "")
-
-	// Boşlukları tire (-) ile değiştir
-	slug = strings.ReplaceAll(slug, " ", "-")
-
-	// Birden fazla tireyi tek bir tireye indir
-	re = regexp.MustCompile(`-+`)
-	slug = re.ReplaceAllString(slug, "-")
+	maxLength := opts.MaxLength
+	if maxLength <= 0 {
+		maxLength = DefaultSlugMaxLength
+	}
+	slug := truncateWords(words, maxLength)
+	if slug == "" {
+		return "default-slug"
+	}
+	return slug
+}
 
-	// Baştaki ve sondaki tireleri kaldır
-	slug = strings.Trim(slug, "-")
+// slugWords splits transliterated text into lowercase [a-z0-9] words
+func slugWords(text string) []string {
+	return strings.FieldsFunc(text, func(r rune) bool {
+		return (r < 'a' || r > 'z') && (r < '0' || r > '9')
+	})
+}
 
-	return slug
+// truncateWords joins words with "-" without exceeding maxLength, cutting only at word boundaries.
+// İlk kelime tek başına sığmıyorsa kelime ortasından kesilir.
+func truncateWords(words []string, maxLength int) string {
+	var b strings.Builder
+	for _, word := range words {
+		if b.Len() == 0 {
+			if len(word) > maxLength {
+				word = word[:maxLength]
+			}
+			b.WriteString(word)
+			continue
+		}
+		if b.Len()+1+len(word) > maxLength {
+			break
+		}
+		b.WriteString("-")
+		b.WriteString(word)
+	}
+	return b.String()
 }
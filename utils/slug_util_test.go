@@ -11,3 +11,31 @@ func TestGenerateSlug(t *testing.T) {
 		t.Errorf("GenerateSlug failed: expected %s, got %s", expected, result)
 	}
 }
+
+func TestGenerateLocalizedSlug(t *testing.T) {
+	cases := []struct {
+		title    string
+		opts     SlugOptions
+		expected string
+	}{
+		{"İstanbul'da Çılgın Işıklar", SlugOptions{Lang: "tr"}, "istanbulda-cilgin-isiklar"},
+		{"Größe über Änderungen", SlugOptions{Lang: "de"}, "groesse-ueber-aenderungen"},
+		{"Größe über Änderungen", SlugOptions{Lang: "en"}, "grosse-uber-anderungen"},
+		{"Привет, мир", SlugOptions{Lang: "ru"}, "privet-mir"},
+		{"Γειά σου Κόσμε", SlugOptions{Lang: "el"}, "geia-sou-kosme"},
+		{"مرحبا بالعالم", SlugOptions{Lang: "ar"}, "mrhba-balalm"},
+		{"Café crème brûlée", SlugOptions{Lang: "fr"}, "cafe-creme-brulee"},
+		{"Tom & Jerry", SlugOptions{Lang: "de", Replacements: map[string]string{"&": " und "}}, "tom-und-jerry"},
+		{"The Art of War", SlugOptions{Lang: "en", StopWords: []string{"the", "of"}}, "art-war"},
+		{"The Of", SlugOptions{Lang: "en", StopWords: []string{"the", "of"}}, "the-of"},
+		{"one two three four", SlugOptions{MaxLength: 13}, "one-two-three"},
+		{"one two three four", SlugOptions{MaxLength: 12}, "one-two"},
+		{"!!!", SlugOptions{}, "default-slug"},
+	}
+
+	for _, tc := range cases {
+		if result := GenerateLocalizedSlug(tc.title, tc.opts); result != tc.expected {
+			t.Errorf("GenerateLocalizedSlug(%q) failed: expected %s, got %s", tc.title, tc.expected, result)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"io"
+	"mime"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+var (
+	ErrStorageNotFound   = errors.New("storage object not found")
+	ErrInvalidStorageKey = errors.New("invalid storage key")
+)
+
+// Storage is a backend media files are kept in; anahtarlar "/" ile ayrılmış göreli yollardır (örn: "renditions/<id>/medium.jpg")
+type Storage interface {
+	// Name returns the name the backend is registered under (örn: "local", "s3")
+	Name() string
+	// Put stores content of the given size under key, replacing an existing object
+	Put(ctx context.Context, key string, content io.Reader, size int64, contentType string) error
+	// Get opens an object; bulunamazsa ErrStorageNotFound döner
+	Get(ctx context.Context, key string) (io.ReadCloser, *StorageObject, error)
+	// Delete removes an object; olmayan nesneler hata vermez
+	Delete(ctx context.Context, key string) error
+	// Stat returns the size and type of an object; bulunamazsa ErrStorageNotFound döner
+	Stat(ctx context.Context, key string) (*StorageObject, error)
+	// URL returns the public address of an object
+	URL(key string) string
+	// SignedURL returns a temporary address of an object that works without a session
+	SignedURL(ctx context.Context, key string, expires time.Duration) (string, error)
+}
+
+// StorageObject describes a stored object
+type StorageObject struct {
+	Key          string
+	Size         int64
+	ContentType  string
+	LastModified time.Time
+}
+
+// CleanStorageKey normalizes a key and rejects absolute paths and ".." segments
+func CleanStorageKey(key string) (string, error) {
+	key = strings.ReplaceAll(key, "\\", "/")
+	if key == "" || strings.HasPrefix(key, "/") {
+		return "", ErrInvalidStorageKey
+	}
+	for _, segment := range strings.Split(key, "/") {
+		if segment == ".." {
+			return "", ErrInvalidStorageKey
+		}
+	}
+	cleaned := path.Clean(key)
+	if cleaned == "." {
+		return "", ErrInvalidStorageKey
+	}
+	return cleaned, nil
+}
+
+// escapeStorageKey escapes every segment of a key for use in a URL path
+func escapeStorageKey(key string) string {
+	segments := strings.Split(key, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+// LocalStorage keeps files in a directory that is served statically under BaseURL
+type LocalStorage struct {
+	Root    string // Örn: "uploads"
+	BaseURL string // Örn: "/uploads"
+}
+
+func (s *LocalStorage) Name() string { return "local" }
+
+// Path returns the file path of a key under the root directory
+func (s *LocalStorage) Path(key string) (string, error) {
+	key, err := CleanStorageKey(key)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(s.Root, filepath.FromSlash(key)), nil
+}
+
+// Put writes to a temporary file and renames it, böylece yarım dosya yayınlanmaz
+func (s *LocalStorage) Put(ctx context.Context, key string, content io.Reader, size int64, contentType string) error {
+	target, err := s.Path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(target), os.ModePerm); err != nil {
+		return err
+	}
+	file, err := os.CreateTemp(filepath.Dir(target), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	if _, err := io.Copy(file, content); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), target)
+}
+
+func (s *LocalStorage) Get(ctx context.Context, key string) (io.ReadCloser, *StorageObject, error) {
+	target, err := s.Path(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	file, err := os.Open(target)
+	if os.IsNotExist(err) {
+		return nil, nil, ErrStorageNotFound
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	object, err := s.stat(key, file.Stat)
+	if err != nil {
+		file.Close()
+		return nil, nil, err
+	}
+	return file, object, nil
+}
+
+func (s *LocalStorage) Delete(ctx context.Context, key string) error {
+	target, err := s.Path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(target); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+func (s *LocalStorage) Stat(ctx context.Context, key string) (*StorageObject, error) {
+	target, err := s.Path(key)
+	if err != nil {
+		return nil, err
+	}
+	return s.stat(key, func() (os.FileInfo, error) { return os.Stat(target) })
+}
+
+func (s *LocalStorage) stat(key string, statFunc func() (os.FileInfo, error)) (*StorageObject, error) {
+	info, err := statFunc()
+	if os.IsNotExist(err) {
+		return nil, ErrStorageNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		return nil, ErrStorageNotFound
+	}
+	return &StorageObject{
+		Key:          key,
+		Size:         info.Size(),
+		ContentType:  mime.TypeByExtension(strings.ToLower(path.Ext(key))),
+		LastModified: info.ModTime(),
+	}, nil
+}
+
+func (s *LocalStorage) URL(key string) string {
+	return strings.TrimRight(s.BaseURL, "/") + "/" + escapeStorageKey(key)
+}
+
+// SignedURL returns the public URL; yerel dosyalar zaten herkese açık yayınlandığından süre sınırı yoktur
+func (s *LocalStorage) SignedURL(ctx context.Context, key string, expires time.Duration) (string, error) {
+	if _, err := CleanStorageKey(key); err != nil {
+		return "", err
+	}
+	return s.URL(key), nil
+}
@@ -0,0 +1,155 @@
+package utils
+
+import (
+	"admin-panel/models"
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var languageCodePattern = regexp.MustCompile(`^[a-zA-Z]{2,3}(-[a-zA-Z0-9]{2,8})*$`)
+
+// IsValidLanguageCode reports whether code is a language tag safe to use in field paths (örn: "de-AT")
+func IsValidLanguageCode(code string) bool {
+	return languageCodePattern.MatchString(code)
+}
+
+// MergeDottedLocalizations folds the "<prefix>.<lang>[.<alan>...]" keys of an update map into a copy of the
+// stored localizations and removes them from the update. Dönen harita tüm dilleri içerir ve tam bir
+// yerelleştirme güncellemesi gibi temizlenip doğrulanır; noktalı anahtar yoksa nil döner.
+func MergeDottedLocalizations(prefix string, stored interface{}, update map[string]interface{}) (map[string]interface{}, error) {
+	var keys []string
+	for key := range update {
+		if strings.HasPrefix(key, prefix+".") {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return nil, nil
+	}
+	sort.Strings(keys) // Üst yol ("tr") alt yollarından ("tr.blocks") önce uygulanır
+
+	var errs ValidationErrors
+	if _, ok := update[prefix]; ok {
+		errs.add(prefix, "cannot be combined with %s.* keys", prefix)
+	}
+	document, ok := asDocument(stored)
+	if !ok {
+		errs.add(prefix, "stored value is not an object")
+	}
+	merged := copyDocument(document)
+	for _, key := range keys {
+		value := update[key]
+		delete(update, key)
+
+		parts := strings.Split(strings.TrimPrefix(key, prefix+"."), ".")
+		if !IsValidLanguageCode(parts[0]) {
+			errs.add(key, "invalid language code")
+			continue
+		}
+		node := merged
+		for i, part := range parts {
+			if part == "" {
+				errs.add(key, "invalid field path")
+				break
+			}
+			if i == len(parts)-1 {
+				node[part] = value
+				break
+			}
+			child, ok := asDocument(node[part])
+			if !ok {
+				errs.add(key, "%s is not an object", strings.Join(parts[:i+1], "."))
+				break
+			}
+			child = copyDocument(child)
+			node[part] = child
+			node = child
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	return merged, nil
+}
+
+// asDocument converts a decoded sub-document to a map; boş değer yeni bir belge sayılır
+func asDocument(value interface{}) (map[string]interface{}, bool) {
+	switch document := value.(type) {
+	case nil:
+		return map[string]interface{}{}, true
+	case map[string]interface{}:
+		return document, true
+	case primitive.M:
+		return document, true
+	case primitive.D:
+		converted := make(map[string]interface{}, len(document))
+		for _, element := range document {
+			converted[element.Key] = element.Value
+		}
+		return converted, true
+	}
+	return nil, false
+}
+
+func copyDocument(document map[string]interface{}) map[string]interface{} {
+	copied := make(map[string]interface{}, len(document))
+	for key, value := range document {
+		copied[key] = value
+	}
+	return copied
+}
+
+// TranslationHash returns a short digest of the translatable text of a field (slug hariç)
+func TranslationHash(field models.LocalizedField) string {
+	sum := sha256.Sum256([]byte(field.Title + "\x00" + field.Content))
+	return hex.EncodeToString(sum[:16])
+}
+
+// UpdateTranslationMeta refreshes the tracking entries after the translations were written.
+// Değişen her çeviri, o anki kaynak dil özetiyle işaretlenir; ikinci dönüş değeri bir değişiklik olup olmadığını bildirir.
+func UpdateTranslationMeta(previous map[string]models.TranslationMeta, fields map[string]models.LocalizedField, source string, now time.Time) (map[string]models.TranslationMeta, bool) {
+	sourceHash := previous[source].Hash
+	if field, ok := fields[source]; ok {
+		sourceHash = TranslationHash(field)
+	}
+
+	meta := make(map[string]models.TranslationMeta, len(fields))
+	changed := len(previous) != len(fields)
+	for lang, field := range fields {
+		hash := TranslationHash(field)
+		entry, ok := previous[lang]
+		if !ok || entry.Hash != hash {
+			entry = models.TranslationMeta{Hash: hash, SourceHash: sourceHash, UpdatedAt: now}
+			changed = true
+		}
+		if lang == source && entry.SourceHash != hash {
+			entry.SourceHash = hash
+			changed = true
+		}
+		meta[lang] = entry
+	}
+	return meta, changed
+}
+
+// TranslationStatus returns missing, outdated or up_to_date for lang relative to the source language
+func TranslationStatus(meta map[string]models.TranslationMeta, fields map[string]models.LocalizedField, source, lang string) string {
+	if _, ok := fields[lang]; !ok {
+		return models.TranslationMissing
+	}
+	entry, ok := meta[lang]
+	sourceEntry, sourceOK := meta[source]
+	if lang == source || !ok || !sourceOK {
+		// Takip bilgisi olmayan eski kayıtlar güncel kabul edilir
+		return models.TranslationUpToDate
+	}
+	if entry.SourceHash != sourceEntry.Hash {
+		return models.TranslationOutdated
+	}
+	return models.TranslationUpToDate
+}
@@ -0,0 +1,93 @@
+package utils
+
+import (
+	"admin-panel/models"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestTranslationStatus(t *testing.T) {
+	now := time.Now()
+	fields := map[string]models.LocalizedField{
+		"en": {Title: "Hello", Content: "World"},
+		"de": {Title: "Hallo", Content: "Welt"},
+	}
+	meta, _ := UpdateTranslationMeta(nil, fields, "en", now)
+
+	if status := TranslationStatus(meta, fields, "en", "de"); status != models.TranslationUpToDate {
+		t.Errorf("TranslationStatus failed: expected up_to_date, got %s", status)
+	}
+	if status := TranslationStatus(meta, fields, "en", "fr"); status != models.TranslationMissing {
+		t.Errorf("TranslationStatus failed: expected missing, got %s", status)
+	}
+
+	// Kaynak değişince çeviri eskir
+	fields["en"] = models.LocalizedField{Title: "Hello", Content: "World!"}
+	meta, changed := UpdateTranslationMeta(meta, fields, "en", now)
+	if !changed {
+		t.Error("UpdateTranslationMeta should report the source change")
+	}
+	if status := TranslationStatus(meta, fields, "en", "de"); status != models.TranslationOutdated {
+		t.Errorf("TranslationStatus failed: expected outdated, got %s", status)
+	}
+
+	// Çeviri güncellenince tekrar güncel olur
+	fields["de"] = models.LocalizedField{Title: "Hallo", Content: "Welt!"}
+	meta, _ = UpdateTranslationMeta(meta, fields, "en", now)
+	if status := TranslationStatus(meta, fields, "en", "de"); status != models.TranslationUpToDate {
+		t.Errorf("TranslationStatus failed: expected up_to_date, got %s", status)
+	}
+
+	if _, changed := UpdateTranslationMeta(meta, fields, "en", now); changed {
+		t.Error("UpdateTranslationMeta should not report unchanged translations")
+	}
+}
+
+func TestMergeDottedLocalizations(t *testing.T) {
+	stored := primitive.M{
+		"tr": primitive.M{"title": "Merhaba", "content": "<p>Eski</p>"},
+		"en": primitive.D{{Key: "title", Value: "Hello"}},
+	}
+	update := map[string]interface{}{
+		"localizations.tr.content": "<script>alert(1)</script>",
+		"localizations.de":         map[string]interface{}{"title": "Hallo"},
+		"localizations.de.blocks":  []interface{}{},
+		"status":                   "draft",
+	}
+	merged, err := MergeDottedLocalizations("localizations", stored, update)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(update) != 1 || update["status"] != "draft" {
+		t.Errorf("expected the dotted keys to be removed from the update, got %v", update)
+	}
+	tr, _ := asDocument(merged["tr"])
+	if tr["title"] != "Merhaba" || tr["content"] != "<script>alert(1)</script>" {
+		t.Errorf("expected the content to be merged into the stored language, got %v", tr)
+	}
+	if de, _ := asDocument(merged["de"]); de["title"] != "Hallo" || de["blocks"] == nil {
+		t.Errorf("expected the new language with its blocks, got %v", de)
+	}
+	if en, _ := asDocument(merged["en"]); en["title"] != "Hello" {
+		t.Errorf("expected untouched languages to be kept, got %v", merged["en"])
+	}
+	if stored["tr"].(primitive.M)["content"] != "<p>Eski</p>" {
+		t.Error("expected the stored localizations not to be modified")
+	}
+
+	if merged, err := MergeDottedLocalizations("localizations", stored, map[string]interface{}{"title": "x"}); merged != nil || err != nil {
+		t.Errorf("expected nil without dotted keys, got %v, %v", merged, err)
+	}
+	for _, update := range []map[string]interface{}{
+		{"localizations.tr.title": "x", "localizations": map[string]interface{}{}},
+		{"localizations.$where.title": "x"},
+		{"localizations.tr..title": "x"},
+		{"localizations.tr.title.text": "x"},
+	} {
+		if _, err := MergeDottedLocalizations("localizations", stored, update); err == nil {
+			t.Errorf("expected %v to be rejected", update)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package utils
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Dile bağlı olmayan harf çevirileri: ayrıştırılamayan Latin harfleri, Kiril, Yunan ve Arap alfabeleri
+var scriptTransliterations = map[rune]string{
+	// Latin (genişletilmiş); aksanlı harfler NFD ile temel harfe indirgenir
+	'ß': "ss", 'æ': "ae", 'œ': "oe", 'ø': "o", 'đ': "d", 'ð': "d", 'þ': "th", 'ł': "l",
+	'ı': "i", 'ħ': "h", 'ŧ': "t", 'ŋ': "ng", 'ĸ': "k", 'ſ': "s", 'ƒ': "f",
+
+	// Kiril (Rusça esas alınır)
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "yo", 'ж': "zh",
+	'з': "z", 'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o",
+	'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u", 'ф': "f", 'х': "kh", 'ц': "ts",
+	'ч': "ch", 'ш': "sh", 'щ': "shch", 'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu",
+	'я': "ya", 'є': "ye", 'і': "i", 'ї': "yi", 'ґ': "g", 'ў': "u", 'ђ': "dj", 'ј': "j",
+	'љ': "lj", 'њ': "nj", 'ћ': "c", 'џ': "dz", 'ѓ': "gj", 'ќ': "kj", 'ѕ': "dz",
+
+	// Yunan
+	'α': "a", 'β': "v", 'γ': "g", 'δ': "d", 'ε': "e", 'ζ': "z", 'η': "i", 'θ': "th",
+	'ι': "i", 'κ': "k", 'λ': "l", 'μ': "m", 'ν': "n", 'ξ': "x", 'ο': "o", 'π': "p",
+	'ρ': "r", 'σ': "s", 'ς': "s", 'τ': "t", 'υ': "y", 'φ': "f", 'χ': "ch", 'ψ': "ps",
+	'ω': "o",
+
+	// Arap (Farsça harfler dahil); hareke işaretleri birleşik işaret olarak atılır
+	'ا': "a", 'أ': "a", 'إ': "i", 'آ': "a", 'ٱ': "a", 'ب': "b", 'ت': "t", 'ث': "th",
+	'ج': "j", 'ح': "h", 'خ': "kh", 'د': "d", 'ذ': "dh", 'ر': "r", 'ز': "z", 'س': "s",
+	'ش': "sh", 'ص': "s", 'ض': "d", 'ط': "t", 'ظ': "z", 'ع': "", 'غ': "gh", 'ف': "f",
+	'ق': "q", 'ك': "k", 'ل': "l", 'م': "m", 'ن': "n", 'ه': "h", 'و': "w", 'ي': "y",
+	'ى': "a", 'ة': "h", 'ء': "", 'ؤ': "", 'ئ': "", 'ـ': "", 'پ': "p", 'چ': "ch",
+	'ژ': "zh", 'گ': "g", 'ک': "k", 'ی': "y",
+	'٠': "0", '١': "1", '٢': "2", '٣': "3", '٤': "4", '٥': "5", '٦': "6", '٧': "7", '٨': "8", '٩': "9",
+	'۰': "0", '۱': "1", '۲': "2", '۳': "3", '۴': "4", '۵': "5", '۶': "6", '۷': "7", '۸': "8", '۹': "9",
+}
+
+// Tek harf olarak çevrilemeyen harf çiftleri
+var digraphTransliterations = strings.NewReplacer("ου", "ou", "ού", "ou")
+
+// Dile özel harf çevirileri; genel tabloya göre önceliklidir (ana dil koduna göre, örn: "de-AT" -> "de")
+var languageTransliterations = map[string]map[rune]string{
+	"de": {'ä': "ae", 'ö': "oe", 'ü': "ue"},
+	"da": {'å': "aa", 'æ': "ae", 'ø': "oe"},
+	"nb": {'å': "aa", 'æ': "ae", 'ø': "oe"},
+	"no": {'å': "aa", 'æ': "ae", 'ø': "oe"},
+	"uk": {'г': "h", 'и': "y", 'х': "kh", 'щ': "shch"},
+	"bg": {'щ': "sht", 'ъ': "a", 'х': "h", 'ю': "yu", 'я': "ya"},
+	"sr": {'ж': "z", 'ч': "c", 'ш': "s", 'ц': "c", 'х': "h", 'ћ': "c", 'ђ': "dj"},
+	"mk": {'ж': "z", 'ч': "c", 'ш': "s", 'ц': "c", 'х': "h", 'ѓ': "gj", 'ќ': "kj"},
+}
+
+// Transliterate lowercases text and converts it to ASCII using the rules of lang.
+// replacements, harf çevirisinden önce uygulanır; bilinmeyen karakterler atılır,
+// boşluk ve tireler kelime ayırıcı olarak korunur.
+func Transliterate(text, lang string, replacements map[string]string) string {
+	base, _, _ := strings.Cut(strings.ToLower(lang), "-")
+
+	// Türkçe ve Azericede "I" -> "ı", "İ" -> "i"
+	if base == "tr" || base == "az" {
+		text = strings.ToLowerSpecial(unicode.TurkishCase, text)
+	} else {
+		text = strings.ToLower(text)
+	}
+
+	if len(replacements) > 0 {
+		text = replacer(replacements).Replace(text)
+	}
+
+	text = digraphTransliterations.Replace(text)
+
+	table := languageTransliterations[base]
+	var b strings.Builder
+	for _, r := range text {
+		b.WriteString(transliterateRune(r, table))
+	}
+	return b.String()
+}
+
+func transliterateRune(r rune, table map[rune]string) string {
+	if r < utf8.RuneSelf {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			return string(r)
+		case r == '-' || unicode.IsSpace(r):
+			return " "
+		}
+		return "" // Noktalama ve semboller
+	}
+	if s, ok := table[r]; ok {
+		return s
+	}
+	if s, ok := scriptTransliterations[r]; ok {
+		return s
+	}
+	if unicode.Is(unicode.Mn, r) {
+		return ""
+	}
+	if unicode.IsSpace(r) || unicode.Is(unicode.Pd, r) {
+		return " "
+	}
+
+	// Aksanlı harfler: temel harf + birleşik işaretler (é -> e, ά -> α -> a)
+	if decomposed := norm.NFD.String(string(r)); decomposed != string(r) {
+		var b strings.Builder
+		for _, d := range decomposed {
+			b.WriteString(transliterateRune(d, table))
+		}
+		return b.String()
+	}
+	return ""
+}
+
+// replacer builds a strings.Replacer that prefers the longest matching key
+func replacer(replacements map[string]string) *strings.Replacer {
+	keys := make([]string, 0, len(replacements))
+	for key := range replacements {
+		if key != "" {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if len(keys[i]) != len(keys[j]) {
+			return len(keys[i]) > len(keys[j])
+		}
+		return keys[i] < keys[j]
+	})
+
+	pairs := make([]string, 0, len(keys)*2)
+	for _, key := range keys {
+		pairs = append(pairs, strings.ToLower(key), replacements[key])
+	}
+	return strings.NewReplacer(pairs...)
+}
@@ -0,0 +1,227 @@
+package utils
+
+import (
+	"admin-panel/models"
+	"fmt"
+	"mime"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/gabriel-vasile/mimetype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Yükleme ret kodları
+const (
+	UploadErrorMissingFile       = "missing_file"
+	UploadErrorEmptyFile         = "empty_file"
+	UploadErrorInvalidName       = "invalid_file_name"
+	UploadErrorTooLarge          = "file_too_large"
+	UploadErrorTypeNotAllowed    = "mime_type_not_allowed"
+	UploadErrorExtensionMismatch = "extension_mismatch"
+	UploadErrorCorruptFile       = "corrupt_file"
+)
+
+// Yükleme politikası sınırları
+const (
+	MaxUploadSize        = 2 << 30   // Politikalarda tanımlanabilecek en büyük dosya (2 GB)
+	maxUploadNameLength  = 60        // Depolama anahtarındaki dosya adı (karakter)
+	DefaultUploadPolicy  = "default" // Politikası olmayan roller için
+	UploadSniffLength    = 3072      // Tip tespiti için okunan ilk bayt sayısı
+	uploadMimeGroupMatch = "/*"
+)
+
+// UploadError is returned when an upload is rejected; Code istemcilerin ayırt edebileceği sabit bir değerdir
+type UploadError struct {
+	Code    string `json:"code"`
+	Message string `json:"error"`
+}
+
+func (e *UploadError) Error() string {
+	return e.Code + ": " + e.Message
+}
+
+func uploadError(code, format string, args ...interface{}) *UploadError {
+	return &UploadError{Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+// DefaultUploadPolicies returns the built-in allow-lists; rolü için politika olmayan kullanıcılara "default" uygulanır.
+// Görseller yalnızca meta verisi temizlenebilen tiplerle (JPEG, PNG, WebP, GIF) sınırlıdır.
+// "image/*" SVG'yi kapsamaz; SVG betik içerebildiğinden ancak açıkça listelenirse kabul edilir.
+func DefaultUploadPolicies() map[string]models.UploadPolicy {
+	const mb = 1 << 20
+	images := func(maxSize int64) []models.UploadRule {
+		var rules []models.UploadRule
+		for _, mimeType := range []string{"image/jpeg", "image/png", "image/webp", "image/gif"} {
+			rules = append(rules, models.UploadRule{MimeType: mimeType, MaxSize: maxSize})
+		}
+		return rules
+	}
+	documents := []models.UploadRule{
+		{MimeType: "application/pdf", MaxSize: 20 * mb},
+		{MimeType: "application/vnd.openxmlformats-officedocument.wordprocessingml.document", MaxSize: 20 * mb},
+		{MimeType: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", MaxSize: 20 * mb},
+		{MimeType: "application/vnd.openxmlformats-officedocument.presentationml.presentation", MaxSize: 20 * mb},
+		{MimeType: "application/vnd.oasis.opendocument.text", MaxSize: 20 * mb},
+		{MimeType: "text/plain", MaxSize: 5 * mb},
+		{MimeType: "text/csv", MaxSize: 5 * mb},
+	}
+	return map[string]models.UploadPolicy{
+		"admin": {Role: "admin", Rules: append(append(images(20*mb),
+			models.UploadRule{MimeType: "video/*", MaxSize: 500 * mb},
+			models.UploadRule{MimeType: "audio/*", MaxSize: 50 * mb},
+			models.UploadRule{MimeType: "application/zip", MaxSize: 100 * mb},
+		), documents...)},
+		"editor": {Role: "editor", Rules: append(append(images(10*mb),
+			models.UploadRule{MimeType: "video/*", MaxSize: 200 * mb},
+			models.UploadRule{MimeType: "audio/*", MaxSize: 20 * mb},
+		), documents...)},
+		DefaultUploadPolicy: {Role: DefaultUploadPolicy, Rules: append(images(5*mb),
+			models.UploadRule{MimeType: "application/pdf", MaxSize: 10 * mb},
+		)},
+	}
+}
+
+// ValidateUploadPolicy normalizes the MIME types of a policy and checks its rules
+func ValidateUploadPolicy(policy *models.UploadPolicy) ValidationErrors {
+	var errs ValidationErrors
+	seen := map[string]bool{}
+	for i := range policy.Rules {
+		rule := &policy.Rules[i]
+		rule.MimeType = strings.ToLower(strings.TrimSpace(rule.MimeType))
+		field := fmt.Sprintf("rules[%d]", i)
+
+		major, minor, ok := strings.Cut(rule.MimeType, "/")
+		switch {
+		case !ok || major == "" || minor == "" || strings.ContainsAny(rule.MimeType, " ;,") || (strings.Contains(minor, "*") && minor != "*"):
+			errs.add(field+".mime_type", "must be a MIME type such as image/png or a group such as image/*")
+		case seen[rule.MimeType]:
+			errs.add(field+".mime_type", "is listed more than once")
+		}
+		seen[rule.MimeType] = true
+
+		if rule.MaxSize <= 0 || rule.MaxSize > MaxUploadSize {
+			errs.add(field+".max_size", "must be between 1 and %d bytes", int64(MaxUploadSize))
+		}
+	}
+	return errs
+}
+
+// MergeUploadRules combines the rules of several policies; aynı MIME tipi için en büyük sınır geçerlidir
+func MergeUploadRules(policies ...models.UploadPolicy) []models.UploadRule {
+	var rules []models.UploadRule
+	index := map[string]int{}
+	for _, policy := range policies {
+		for _, rule := range policy.Rules {
+			if i, ok := index[rule.MimeType]; ok {
+				rules[i].MaxSize = max(rules[i].MaxSize, rule.MaxSize)
+				continue
+			}
+			index[rule.MimeType] = len(rules)
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}
+
+// MatchUploadRule finds the rule of a MIME type; tam eşleşme grup kuralından (image/*) önce gelir
+func MatchUploadRule(rules []models.UploadRule, mimeType string) (models.UploadRule, bool) {
+	group := strings.SplitN(mimeType, "/", 2)[0] + uploadMimeGroupMatch
+	var match *models.UploadRule
+	for i, rule := range rules {
+		switch rule.MimeType {
+		case mimeType:
+			return rule, true
+		case group:
+			if mimeType != "image/svg+xml" {
+				match = &rules[i]
+			}
+		}
+	}
+	if match == nil {
+		return models.UploadRule{}, false
+	}
+	return *match, true
+}
+
+// DetectUploadType detects the MIME type of a file from its first bytes and checks the file name extension against it.
+// Uzantısı olmayan dosyalara tespit edilen tipin uzantısı verilir.
+func DetectUploadType(fileName string, header []byte) (string, string, *UploadError) {
+	detected := mimetype.Detect(header)
+	mimeType, _, err := mime.ParseMediaType(detected.String())
+	if err != nil {
+		mimeType = "application/octet-stream"
+	}
+
+	ext := strings.ToLower(filepath.Ext(fileName))
+	if ext == "" {
+		return mimeType, detected.Extension(), nil
+	}
+	for m := detected; m != nil; m = m.Parent() {
+		if m.Extension() == ext {
+			return mimeType, ext, nil
+		}
+		if declared, _, err := mime.ParseMediaType(mime.TypeByExtension(ext)); err == nil && m.Is(declared) {
+			return mimeType, ext, nil
+		}
+	}
+	return mimeType, ext, uploadError(UploadErrorExtensionMismatch, "File extension %s does not match its content (%s)", ext, mimeType)
+}
+
+// CheckUploadRules applies the allow-list and size limit of the uploader's roles
+func CheckUploadRules(rules []models.UploadRule, mimeType string, size int64) *UploadError {
+	rule, ok := MatchUploadRule(rules, mimeType)
+	if !ok {
+		return uploadError(UploadErrorTypeNotAllowed, "Files of type %s are not allowed for your role", mimeType)
+	}
+	if size > rule.MaxSize {
+		return uploadError(UploadErrorTooLarge, "File is %s; the limit for %s is %s", FormatBytes(size), mimeType, FormatBytes(rule.MaxSize))
+	}
+	return nil
+}
+
+// SanitizeFileName returns the base name of a client supplied file name without path parts and control characters
+func SanitizeFileName(name string) (string, *UploadError) {
+	name = norm.NFC.String(name)
+	name = path.Base(strings.ReplaceAll(name, "\\", "/"))
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) || r == '/' {
+			return -1
+		}
+		return r
+	}, name)
+	name = strings.TrimSpace(name)
+	if name == "" || name == "." || name == ".." || strings.Trim(name, ".") == "" {
+		return "", uploadError(UploadErrorInvalidName, "File name is missing or invalid")
+	}
+	return name, nil
+}
+
+// UploadStorageKey returns a collision-free key such as "2026/10/<id>-ekran-goruntusu.png"
+func UploadStorageKey(id primitive.ObjectID, fileName, ext string, at time.Time) string {
+	base := strings.TrimSuffix(fileName, filepath.Ext(fileName))
+	slug := GenerateLocalizedSlug(base, SlugOptions{MaxLength: maxUploadNameLength})
+	if slug == "default-slug" {
+		slug = "file"
+	}
+	return at.UTC().Format("2006/01") + "/" + id.Hex() + "-" + slug + ext
+}
+
+// FormatBytes formats a size for messages, örn: 10 MB
+func FormatBytes(size int64) string {
+	units := []string{"B", "KB", "MB", "GB"}
+	value := float64(size)
+	unit := 0
+	for value >= 1024 && unit < len(units)-1 {
+		value /= 1024
+		unit++
+	}
+	if value == float64(int64(value)) {
+		return fmt.Sprintf("%d %s", int64(value), units[unit])
+	}
+	return fmt.Sprintf("%.1f %s", value, units[unit])
+}
@@ -0,0 +1,147 @@
+package utils
+
+import (
+	"admin-panel/models"
+	"bytes"
+	"image"
+	"image/png"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestMatchUploadRule(t *testing.T) {
+	rules := []models.UploadRule{
+		{MimeType: "image/*", MaxSize: 100},
+		{MimeType: "image/gif", MaxSize: 10},
+	}
+	if rule, ok := MatchUploadRule(rules, "image/gif"); !ok || rule.MaxSize != 10 {
+		t.Errorf("expected the exact rule to win, got %+v", rule)
+	}
+	if rule, ok := MatchUploadRule(rules, "image/png"); !ok || rule.MaxSize != 100 {
+		t.Errorf("expected the group rule, got %+v", rule)
+	}
+	if _, ok := MatchUploadRule(rules, "image/svg+xml"); ok {
+		t.Error("expected SVG not to be covered by image/*")
+	}
+	if _, ok := MatchUploadRule(append(rules, models.UploadRule{MimeType: "image/svg+xml", MaxSize: 1}), "image/svg+xml"); !ok {
+		t.Error("expected an explicit SVG rule to match")
+	}
+	if _, ok := MatchUploadRule(rules, "application/pdf"); ok {
+		t.Error("expected no rule for application/pdf")
+	}
+}
+
+func TestMergeUploadRules(t *testing.T) {
+	policies := DefaultUploadPolicies()
+	rules := MergeUploadRules(policies[DefaultUploadPolicy], policies["editor"])
+	if rule, _ := MatchUploadRule(rules, "image/png"); rule.MaxSize != 10<<20 {
+		t.Errorf("expected the larger limit, got %d", rule.MaxSize)
+	}
+	if err := CheckUploadRules(rules, "application/zip", 1); err == nil || err.Code != UploadErrorTypeNotAllowed {
+		t.Errorf("expected %s, got %v", UploadErrorTypeNotAllowed, err)
+	}
+	for _, mimeType := range []string{"image/heic", "image/avif", "image/tiff"} {
+		if err := CheckUploadRules(rules, mimeType, 1); err == nil || err.Code != UploadErrorTypeNotAllowed {
+			t.Errorf("expected %s to be rejected by default, got %v", mimeType, err)
+		}
+	}
+	if err := CheckUploadRules(rules, "application/pdf", 21<<20); err == nil || err.Code != UploadErrorTooLarge {
+		t.Errorf("expected %s, got %v", UploadErrorTooLarge, err)
+	}
+	if err := CheckUploadRules(rules, "application/pdf", 20<<20); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestValidateUploadPolicy(t *testing.T) {
+	policy := models.UploadPolicy{Rules: []models.UploadRule{
+		{MimeType: " Image/PNG ", MaxSize: 1},
+		{MimeType: "image/png", MaxSize: 1},
+		{MimeType: "image", MaxSize: 1},
+		{MimeType: "image/p*", MaxSize: 1},
+		{MimeType: "video/*", MaxSize: 0},
+	}}
+	errs := ValidateUploadPolicy(&policy)
+	if policy.Rules[0].MimeType != "image/png" {
+		t.Errorf("expected the MIME type to be normalized, got %q", policy.Rules[0].MimeType)
+	}
+	if len(errs) != 4 {
+		t.Errorf("expected 4 errors, got %v", errs)
+	}
+	for _, policy := range DefaultUploadPolicies() {
+		if errs := ValidateUploadPolicy(&policy); len(errs) > 0 {
+			t.Errorf("%s: default policy is invalid: %v", policy.Role, errs)
+		}
+	}
+}
+
+func TestDetectUploadType(t *testing.T) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewGray(image.Rect(0, 0, 1, 1))); err != nil {
+		t.Fatal(err)
+	}
+	pngData := buf.Bytes()
+
+	cases := []struct {
+		name, mimeType, ext string
+		data                []byte
+		mismatch            bool
+	}{
+		{"photo.png", "image/png", ".png", pngData, false},
+		{"photo.PNG", "image/png", ".png", pngData, false},
+		{"photo", "image/png", ".png", pngData, false},
+		{"photo.jpg", "image/png", ".jpg", pngData, true},
+		{"notes.txt", "text/plain", ".txt", []byte("hello"), false},
+		{"invoice.pdf", "text/plain", ".pdf", []byte("hello"), true},
+		{"shell.php.png", "text/x-php", ".png", []byte("<?php echo 1;"), true},
+	}
+	for _, tc := range cases {
+		mimeType, ext, err := DetectUploadType(tc.name, tc.data)
+		if mimeType != tc.mimeType || ext != tc.ext || (err != nil) != tc.mismatch {
+			t.Errorf("%s: got %s %s %v", tc.name, mimeType, ext, err)
+		}
+		if err != nil && err.Code != UploadErrorExtensionMismatch {
+			t.Errorf("%s: unexpected code %s", tc.name, err.Code)
+		}
+	}
+}
+
+func TestSanitizeFileName(t *testing.T) {
+	cases := map[string]string{
+		"../../etc/passwd":      "passwd",
+		`C:\Users\ali\foto.png`: "foto.png",
+		" rapor\x00.pdf ":       "rapor.pdf",
+		"Ekran Görüntüsü.png":   "Ekran Görüntüsü.png",
+	}
+	for input, expected := range cases {
+		if got, err := SanitizeFileName(input); err != nil || got != expected {
+			t.Errorf("%q: got %q (%v)", input, got, err)
+		}
+	}
+	for _, input := range []string{"", "..", "/", "  "} {
+		if _, err := SanitizeFileName(input); err == nil || err.Code != UploadErrorInvalidName {
+			t.Errorf("%q: expected %s, got %v", input, UploadErrorInvalidName, err)
+		}
+	}
+}
+
+func TestUploadStorageKey(t *testing.T) {
+	id, _ := primitive.ObjectIDFromHex("652f1c2e9b1d8a0012345678")
+	at := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+	if got := UploadStorageKey(id, "Ekran Görüntüsü.PNG", ".png", at); got != "2026/10/652f1c2e9b1d8a0012345678-ekran-goruntusu.png" {
+		t.Errorf("unexpected key: %s", got)
+	}
+	if got := UploadStorageKey(id, "***.jpg", ".jpg", at); got != "2026/10/652f1c2e9b1d8a0012345678-file.jpg" {
+		t.Errorf("unexpected key: %s", got)
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	for size, expected := range map[int64]string{512: "512 B", 10 << 20: "10 MB", 1536: "1.5 KB"} {
+		if got := FormatBytes(size); got != expected {
+			t.Errorf("%d: got %s", size, got)
+		}
+	}
+}